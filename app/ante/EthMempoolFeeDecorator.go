@@ -49,7 +49,8 @@ func (emfd EthMempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 
 	feeInts := feeIntsPool.Get().(*[2]big.Int)
 
-	// fee = gas price * gas limit
+	// fee = gas price * gas limit, the gas price of the dynamic fee tx is its effective gas price
+	// which is the tip capped by the fee cap
 	fee := sdk.NewDecCoinFromDec(evmDenom, sdk.NewDecWithBigIntAndPrec(msgEthTx.CalcFee(&feeInts[0]), sdk.Precision))
 
 	minGasPrices := ctx.MinGasPrices()
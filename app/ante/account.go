@@ -10,7 +10,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcore "github.com/ethereum/go-ethereum/core"
-	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
@@ -157,7 +156,7 @@ func ethGasConsume(ek EVMKeeper, sk types.SupplyKeeper, ctx *sdk.Context, acc ex
 	gasLimit := msgEthTx.GetGas()
 
 	if shouldIntrinsicGas(ek, ctx, msgEthTx) {
-		gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, []ethtypes.AccessTuple{}, msgEthTx.To() == nil, true, false)
+		gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.Data.Accesses, msgEthTx.To() == nil, true, false)
 		if err != nil {
 			return sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 		}
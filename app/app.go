@@ -105,6 +105,7 @@ import (
 	"github.com/okex/exchain/x/gov/keeper"
 	"github.com/okex/exchain/x/infura"
 	"github.com/okex/exchain/x/order"
+	ordertypes "github.com/okex/exchain/x/order/types"
 	"github.com/okex/exchain/x/params"
 	paramsclient "github.com/okex/exchain/x/params/client"
 	paramstypes "github.com/okex/exchain/x/params/types"
@@ -614,7 +615,7 @@ func NewOKExChainApp(
 
 	wasmModule := wasm.NewAppModule(*app.marshal, &app.WasmKeeper)
 	app.WasmPermissionKeeper = wasmModule.GetPermissionKeeper()
-	app.VMBridgeKeeper = vmbridge.NewKeeper(app.marshal, app.Logger(), app.EvmKeeper, app.WasmPermissionKeeper, app.AccountKeeper, app.BankKeeper)
+	app.VMBridgeKeeper = vmbridge.NewKeeper(app.marshal, app.Logger(), app.EvmKeeper, app.WasmPermissionKeeper, app.AccountKeeper, app.BankKeeper, app.SwapKeeper)
 	app.EvmKeeper.SetCallToCM(vmbridge.PrecompileHooks(app.VMBridgeKeeper))
 	// Set EVM hooks
 	app.EvmKeeper.SetHooks(
@@ -673,6 +674,7 @@ func NewOKExChainApp(
 		order.ModuleName,
 		token.ModuleName,
 		dex.ModuleName,
+		ammswap.ModuleName,
 		mint.ModuleName,
 		distr.ModuleName,
 		slashing.ModuleName,
@@ -758,15 +760,19 @@ func NewOKExChainApp(
 	app.SetAccNonceHandler(NewAccNonceHandler(app.AccountKeeper))
 	app.AddCustomizeModuleOnStopLogic(NewEvmModuleStopLogic(app.EvmKeeper))
 	app.SetMptCommitHandler(NewMptCommitHandler(app.EvmKeeper))
+	app.SetRestoreHandler(NewRestoreHandler(app))
 	app.SetUpdateWasmTxCount(fixCosmosTxCountInWasmForParallelTx(app.WasmHandler.TXCounterStoreKey))
 	app.SetUpdateFeeCollectorAccHandler(updateFeeCollectorHandler(app.BankKeeper, app.SupplyKeeper))
 	app.SetGetFeeCollectorInfo(getFeeCollectorInfo(app.BankKeeper, app.SupplyKeeper))
 	app.SetParallelTxLogHandlers(fixLogForParallelTxHandler(app.EvmKeeper))
+	app.SetParallelTxsStatsHandler(parallelTxsStatsHandler())
 	app.SetPreDeliverTxHandler(preDeliverTxHandler(app.AccountKeeper))
 	app.SetPartialConcurrentHandlers(getTxFeeAndFromHandler(app.EvmKeeper))
+	app.SetParallelTxConflictHandlers(getTxAccessListHandler(), decodeEvmStorageKeyHandler(keys[evm.StoreKey]))
 	app.SetGetTxFeeHandler(getTxFeeHandler())
 	app.SetEvmSysContractAddressHandler(NewEvmSysContractAddressHandler(app.EvmKeeper))
 	app.SetEvmWatcherCollector(app.EvmKeeper.Watcher.Collect)
+	app.EvmKeeper.Watcher.SetStateReader(watchDBStateReader{app})
 	app.SetUpdateCMTxNonceHandler(NewUpdateCMTxNonceHandler())
 	app.SetGetGasConfigHandler(NewGetGasConfigHandler(app.ParamsKeeper))
 	app.SetGetBlockConfigHandler(NewGetBlockConfigHandler(app.ParamsKeeper))
@@ -810,6 +816,38 @@ func (app *OKExChainApp) InitUpgrade(ctx sdk.Context) {
 		app.WasmKeeper.UpdateMilestone(ctx, "wasm_v1", info.EffectiveHeight)
 	})
 
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS8_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus8Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS9_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus9Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS10_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus10Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS11_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus11Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS12_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus12Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS13_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus13Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS14_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus14Height(int64(info.EffectiveHeight))
+	})
+
+	app.ParamsKeeper.ClaimReadyForUpgrade(tmtypes.MILESTONE_VENUS15_NAME, func(info paramstypes.UpgradeInfo) {
+		tmtypes.InitMilestoneVenus15Height(int64(info.EffectiveHeight))
+	})
+
 	if err := app.ParamsKeeper.ApplyEffectiveUpgrade(ctx); err != nil {
 		tmos.Exit(fmt.Sprintf("failed apply effective upgrade height info: %s", err))
 	}
@@ -1000,6 +1038,9 @@ func PreRun(ctx *server.Context, cmd *cobra.Command) error {
 	// init tx signature cache
 	tmtypes.InitSignatureCache()
 
+	// emit the depth book updates for the websocket subscription
+	ordertypes.EnableDepthBookEvent = viper.GetBool(ordertypes.FlagEnableDepthBookEvent)
+
 	isFastStorage := appstatus.IsFastStorageStrategy()
 	iavl.SetEnableFastStorage(isFastStorage)
 	viper.Set(iavl.FlagIavlEnableFastStorage, isFastStorage)
@@ -1030,6 +1071,20 @@ func NewMptCommitHandler(ak *evm.Keeper) sdk.MptCommitHandler {
 	}
 }
 
+// NewRestoreHandler reloads the in-memory state of the keepers after the stores have been restored
+// from a state sync snapshot, the same way it is loaded on start.
+func NewRestoreHandler(app *OKExChainApp) sdk.RestoreHandler {
+	return func(ctx sdk.Context) {
+		app.EvmKeeper.SetTargetMptVersion(ctx.BlockHeight())
+		if err := app.WasmKeeper.InitializePinnedCodes(ctx); err != nil {
+			tmos.Exit(fmt.Sprintf("failed initialize pinned codes %s", err))
+		}
+		app.InitUpgrade(ctx)
+		app.WasmKeeper.UpdateGasRegister(ctx)
+		app.WasmKeeper.UpdateCurBlockNum(ctx)
+	}
+}
+
 func NewEvmSysContractAddressHandler(ak *evm.Keeper) sdk.EvmSysContractAddressHandler {
 	if ak == nil {
 		panic("NewEvmSysContractAddressHandler ak is nil")
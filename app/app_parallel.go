@@ -1,9 +1,13 @@
 package app
 
 import (
+	"bytes"
+	"encoding/hex"
 	"sort"
 	"strings"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
 	appante "github.com/okex/exchain/app/ante"
 	ethermint "github.com/okex/exchain/app/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/baseapp"
@@ -15,6 +19,7 @@ import (
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/types"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/common/monitor"
 	"github.com/okex/exchain/x/evm"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	wasmkeeper "github.com/okex/exchain/x/wasm/keeper"
@@ -55,6 +60,61 @@ func updateFeeCollectorHandler(bk bank.Keeper, sk supply.Keeper) sdk.UpdateFeeCo
 	}
 }
 
+// parallelTxsStatsHandler reports the stats of the txs of a block executed in parallel to the metrics
+func parallelTxsStatsHandler() sdk.ParallelTxsStatsHandler {
+	return func(_ int64, stats sdk.ParallelTxsStats) {
+		metrics := monitor.GetParallelTxsMetrics()
+		metrics.Txs.Set(float64(stats.Txs))
+		metrics.Conflicts.Set(float64(stats.Conflicts))
+		metrics.Reruns.Set(float64(stats.Reruns))
+		metrics.Speedup.Set(stats.Speedup)
+	}
+}
+
+// getTxAccessListHandler returns the accounts and the storage slots of the access list of an evm tx,
+// and its receiver if the tx transfers value to it.
+func getTxAccessListHandler() sdk.GetTxAccessListHandler {
+	return func(tx sdk.Tx) []sdk.TxAccess {
+		evmTx, ok := tx.(*evmtypes.MsgEthereumTx)
+		if !ok {
+			return nil
+		}
+
+		var accesses []sdk.TxAccess
+		for _, tuple := range evmTx.AccessList() {
+			address := hex.EncodeToString(tuple.Address.Bytes())
+			if len(tuple.StorageKeys) == 0 {
+				accesses = append(accesses, sdk.TxAccess{Address: address})
+				continue
+			}
+			for _, key := range tuple.StorageKeys {
+				// the slots are stored under the hash of the contract and the key
+				slot := evmtypes.GetStorageByAddressKey(tuple.Address.Bytes(), key.Bytes())
+				accesses = append(accesses, sdk.TxAccess{Address: address, Slot: hex.EncodeToString(slot.Bytes())})
+			}
+		}
+		if to := evmTx.To(); to != nil && evmTx.Data.Amount != nil && evmTx.Data.Amount.Sign() > 0 {
+			accesses = append(accesses, sdk.TxAccess{Address: hex.EncodeToString(to.Bytes())})
+		}
+		return accesses
+	}
+}
+
+// decodeEvmStorageKeyHandler returns the contract and the storage slot of a key of the evm store.
+func decodeEvmStorageKeyHandler(evmStoreKey sdk.StoreKey) sdk.DecodeStorageKeyHandler {
+	prefixLen := len(evmtypes.KeyPrefixStorage)
+	return func(storeKey sdk.StoreKey, key []byte) (sdk.TxAccess, bool) {
+		if storeKey != evmStoreKey || len(key) != prefixLen+ethcmn.AddressLength+ethcmn.HashLength ||
+			!bytes.HasPrefix(key, evmtypes.KeyPrefixStorage) {
+			return sdk.TxAccess{}, false
+		}
+		return sdk.TxAccess{
+			Address: hex.EncodeToString(key[prefixLen : prefixLen+ethcmn.AddressLength]),
+			Slot:    hex.EncodeToString(key[prefixLen+ethcmn.AddressLength:]),
+		}, true
+	}
+}
+
 // fixLogForParallelTxHandler fix log for parallel tx
 func fixLogForParallelTxHandler(ek *evm.Keeper) sdk.LogFix {
 	return func(tx []sdk.Tx, logIndex []int, hasEnterEvmTx []bool, anteErrs []error, resp []abci.ResponseDeliverTx) (logs [][]byte) {
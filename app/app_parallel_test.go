@@ -15,17 +15,21 @@ import (
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/okex/exchain/app"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	apptypes "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
 	"github.com/okex/exchain/libs/cosmos-sdk/codec"
 	"github.com/okex/exchain/libs/cosmos-sdk/simapp/helpers"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	sm "github.com/okex/exchain/libs/tendermint/state"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	tokentypes "github.com/okex/exchain/x/token/types"
@@ -264,12 +268,24 @@ func TestParallelTxs(t *testing.T) {
 		env.addr[i] = addr
 	}
 	chainA, chainB := NewChain(env), NewChain(env)
+	// chainC executes the txs in parallel with the stm scheduler
+	viper.Set(sm.FlagParallelScheduler, sm.ParallelSchedulerSTM)
+	chainC := NewChain(env)
+	viper.Set(sm.FlagParallelScheduler, sm.ParallelSchedulerGroup)
+	// chainD groups the txs by their predicted conflicts
+	viper.Set(sm.FlagParallelConflictPrediction, true)
+	chainD := NewChain(env)
+	viper.Set(sm.FlagParallelConflictPrediction, false)
 
 	VMBPrecompileSetup(t, chainA)
 	VMBPrecompileSetup(t, chainB)
+	VMBPrecompileSetup(t, chainC)
+	VMBPrecompileSetup(t, chainD)
 
 	DeployContractAndGetContractAddress(t, chainA)
 	DeployContractAndGetContractAddress(t, chainB)
+	DeployContractAndGetContractAddress(t, chainC)
+	DeployContractAndGetContractAddress(t, chainD)
 
 	testCases := []struct {
 		title         string
@@ -1046,14 +1062,101 @@ func TestParallelTxs(t *testing.T) {
 		t.Run(tc.title, func(t *testing.T) {
 			retA, resultHashA, appHashA := tc.executeTxs(t, chainA, true)
 			retB, resultHashB, appHashB := tc.executeTxs(t, chainB, false)
+			retC, resultHashC, appHashC := tc.executeTxs(t, chainC, true)
+			retD, resultHashD, appHashD := tc.executeTxs(t, chainD, true)
 			checkCodes(t, tc.title, retA, tc.expectedCodes)
 			checkCodes(t, tc.title, retB, tc.expectedCodes)
+			checkCodes(t, tc.title, retC, tc.expectedCodes)
+			checkCodes(t, tc.title, retD, tc.expectedCodes)
 			require.True(t, reflect.DeepEqual(resultHashA, resultHashB))
 			require.True(t, reflect.DeepEqual(appHashA, appHashB))
+			require.True(t, reflect.DeepEqual(resultHashC, resultHashB))
+			require.True(t, reflect.DeepEqual(appHashC, appHashB))
+			require.True(t, reflect.DeepEqual(resultHashD, resultHashB))
+			require.True(t, reflect.DeepEqual(appHashD, appHashB))
 		})
 	}
 }
 
+func TestParallelTxsSchedulers(t *testing.T) {
+	viper.Set(flags.FlagHome, t.TempDir())
+	tmtypes.UnittestOnlySetMilestoneVenusHeight(-1)
+	tmtypes.UnittestOnlySetMilestoneVenus1Height(1)
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneVenus6Height(1)
+
+	env := new(Env)
+	env.priv = make([]ethsecp256k1.PrivKey, 10)
+	env.addr = make([]sdk.AccAddress, 10)
+	for i := 0; i < 10; i++ {
+		priv, _ := ethsecp256k1.GenerateKey()
+		env.priv[i] = priv
+		env.addr[i] = sdk.AccAddress(priv.PubKey().Address())
+	}
+	serial := NewChain(env)
+	group := NewChain(env)
+	viper.Set(sm.FlagParallelScheduler, sm.ParallelSchedulerSTM)
+	stm := NewChain(env)
+	viper.Set(sm.FlagParallelScheduler, sm.ParallelSchedulerGroup)
+
+	// the same block of conflicting txs: the cosmos tx is only executed when it's committed, so the
+	// speculative execution of the evm tx of its receiver reads a stale account and is executed again,
+	// the evm txs of a chain of senders and the contract calls read the values written by the txs before
+	executeTxs := func(chain *Chain, isParallel bool) []*abci.ResponseDeliverTx {
+		var rawTxs [][]byte
+		rawTxs = append(rawTxs, createTokenSendTx(t, chain, 0))
+		for i := 1; i < 4; i++ {
+			rawTxs = append(rawTxs, createEthTx(t, chain, i))
+		}
+		for i := 4; i < 9; i++ {
+			rawTxs = append(rawTxs, callContract(t, chain, i))
+		}
+		rawTxs = append(rawTxs, createEthTx(t, chain, 1))
+		return runTxs(chain, rawTxs, isParallel)
+	}
+	feeCollector := func(chain *Chain) sdk.Coins {
+		ctx := chain.app.BaseApp.NewContext(true, abci.Header{Height: chain.app.LastBlockHeight()})
+		return chain.app.SupplyKeeper.GetModuleAccount(ctx, auth.FeeCollectorName).GetCoins()
+	}
+
+	DeployContractAndGetContractAddress(t, serial)
+	DeployContractAndGetContractAddress(t, group)
+	DeployContractAndGetContractAddress(t, stm)
+
+	expectedCodes := []uint32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	retSerial := executeTxs(serial, false)
+	retGroup := executeTxs(group, true)
+	retSTM := executeTxs(stm, true)
+	require.NotZero(t, lastParallelTxsConflicts(t))
+
+	checkCodes(t, "serial", retSerial, expectedCodes)
+	checkCodes(t, "group", retGroup, expectedCodes)
+	checkCodes(t, "stm", retSTM, expectedCodes)
+	for i := range retSerial {
+		require.Equal(t, *retSerial[i], *retGroup[i])
+		require.Equal(t, *retSerial[i], *retSTM[i])
+	}
+	require.False(t, feeCollector(serial).IsZero())
+	require.Equal(t, feeCollector(serial), feeCollector(group))
+	require.Equal(t, feeCollector(serial), feeCollector(stm))
+	require.Equal(t, serial.app.BaseApp.LastCommitID(), group.app.BaseApp.LastCommitID())
+	require.Equal(t, serial.app.BaseApp.LastCommitID(), stm.app.BaseApp.LastCommitID())
+}
+
+// lastParallelTxsConflicts returns the conflicts of the last block executed in parallel reported to the metrics
+func lastParallelTxsConflicts(t *testing.T) float64 {
+	mfs, err := stdprometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, mf := range mfs {
+		if mf.GetName() == "x_parallel_conflicts" {
+			return mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatal("no parallel txs conflicts metric")
+	return 0
+}
+
 func resultHash(txs []*abci.ResponseDeliverTx) []byte {
 	results := tmtypes.NewResults(txs)
 	return results.Hash()
@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -14,12 +16,14 @@ import (
 
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	"github.com/okex/exchain/app/rpc/backend"
+	"github.com/okex/exchain/app/rpc/monitor"
 	"github.com/okex/exchain/app/rpc/namespaces/debug"
 	"github.com/okex/exchain/app/rpc/namespaces/eth"
 	"github.com/okex/exchain/app/rpc/namespaces/eth/filters"
 	"github.com/okex/exchain/app/rpc/namespaces/net"
 	"github.com/okex/exchain/app/rpc/namespaces/personal"
 	"github.com/okex/exchain/app/rpc/namespaces/web3"
+	"github.com/okex/exchain/app/rpc/ratelimit"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
 	cosmost "github.com/okex/exchain/libs/cosmos-sdk/store/types"
 )
@@ -124,6 +128,40 @@ func getRateLimiter() map[string]*rate.Limiter {
 	return rateLimiters
 }
 
+// withClientRateLimiter returns the handler behind the per-client rate limiter, or the handler
+// itself if the per-client rate limiting is disabled.
+func withClientRateLimiter(handler http.Handler) http.Handler {
+	clientRate := viper.GetFloat64(FlagRateLimitClientRate)
+	if clientRate <= 0 {
+		return handler
+	}
+	namespaces, err := ratelimit.ParseNamespaceLimits(viper.GetString(FlagRateLimitClientNamespaces))
+	if err != nil {
+		panic(fmt.Errorf("invalid %s: %w", FlagRateLimitClientNamespaces, err))
+	}
+	costs, err := ratelimit.ParseMethodCosts(viper.GetString(FlagRateLimitMethodCost))
+	if err != nil {
+		panic(fmt.Errorf("invalid %s: %w", FlagRateLimitMethodCost, err))
+	}
+	trustedProxies, err := ratelimit.ParseTrustedProxies(viper.GetString(FlagRateLimitTrustedProxies))
+	if err != nil {
+		panic(fmt.Errorf("invalid %s: %w", FlagRateLimitTrustedProxies, err))
+	}
+
+	var metrics *monitor.RateLimitMetrics
+	if viper.GetBool(monitor.FlagEnableMonitor) {
+		metrics = monitor.MakeRateLimitMetrics()
+	}
+	cfg := ratelimit.Config{
+		Default:     ratelimit.Limit{Rate: clientRate, Burst: viper.GetInt(FlagRateLimitClientBurst)},
+		Namespaces:  namespaces,
+		MethodCosts: costs,
+		DailyQuota:  viper.GetInt64(FlagRateLimitDailyQuota),
+	}
+	apiKeys := ratelimit.ParseAPIKeys(viper.GetString(FlagRateLimitAPIKeys))
+	return ratelimit.NewLimiter(cfg, metrics).Handler(handler, trustedProxies, apiKeys)
+}
+
 func getDisableAPI() map[string]bool {
 	disableAPI := viper.GetString(FlagDisableAPI)
 	apiMap := make(map[string]bool)
@@ -38,9 +38,10 @@ import (
 )
 
 const (
-	FlagLogsLimit   = "rpc.logs-limit"
-	FlagLogsTimeout = "rpc.logs-timeout"
-	blockCacheSize  = 1024
+	FlagLogsLimit      = "rpc.logs-limit"
+	FlagLogsTimeout    = "rpc.logs-timeout"
+	FlagLogsMaxResults = "rpc.logs-max-results"
+	blockCacheSize     = 1024
 )
 
 var ErrTimeout = errors.New("query timeout exceeded")
@@ -69,6 +70,7 @@ type Backend interface {
 	UserPendingTransactions(address string, limit int) ([]*watcher.Transaction, error)
 	PendingAddressList() ([]string, error)
 	GetPendingNonce(address string) (uint64, bool)
+	QueuedTransactions() (map[string][]*watcher.Transaction, error)
 
 	// Used by log filter
 	GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error)
@@ -98,6 +100,7 @@ type EthermintBackend struct {
 	backendCache      Cache
 	logsLimit         int
 	logsTimeout       int // timeout second
+	logsMaxResults    int
 	blockCache        *lru.Cache
 	pruneEverything   bool
 }
@@ -117,6 +120,7 @@ func New(clientCtx clientcontext.CLIContext, log log.Logger, rateLimiters map[st
 		backendCache:      NewLruCache(),
 		logsLimit:         viper.GetInt(FlagLogsLimit),
 		logsTimeout:       viper.GetInt(FlagLogsTimeout),
+		logsMaxResults:    viper.GetInt(FlagLogsMaxResults),
 		pruneEverything:   viper.GetString(server.FlagPruning) == types.PruningOptionEverything,
 	}
 	b.blockCache, _ = lru.New(blockCacheSize)
@@ -135,6 +139,28 @@ func (b *EthermintBackend) LogsTimeout() time.Duration {
 	return time.Duration(b.logsTimeout) * time.Second
 }
 
+// LogsMaxResults returns the maximum number of logs of a query served by the log index, the
+// larger results have to be paginated.
+func (b *EthermintBackend) LogsMaxResults() int {
+	if b.logsLimit > 0 && (b.logsMaxResults <= 0 || b.logsLimit < b.logsMaxResults) {
+		return b.logsLimit
+	}
+	return b.logsMaxResults
+}
+
+// LogIndexBase returns the first height covered by the log index of the watch db, and false if
+// the log index is disabled.
+func (b *EthermintBackend) LogIndexBase() (uint64, bool) {
+	return b.wrappedBackend.LogIndexBase()
+}
+
+// FilterLogsFromIndex returns at most limit logs matching the criteria from the log index, after
+// the position if not nil, along with the position to resume from if there are more logs.
+func (b *EthermintBackend) FilterLogsFromIndex(ctx context.Context, from, to uint64, addresses []common.Address,
+	topics [][]common.Hash, after *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error) {
+	return b.wrappedBackend.FilterLogsFromIndex(ctx, from, to, addresses, topics, after, limit)
+}
+
 // BlockNumber returns the current block number.
 func (b *EthermintBackend) BlockNumber() (hexutil.Uint64, error) {
 	committedHeight := global.GetGlobalHeight()
@@ -382,6 +408,36 @@ func (b *EthermintBackend) UserPendingTransactions(address string, limit int) ([
 	return transactions, nil
 }
 
+// QueuedTransactions returns the txs of the pending pool, parked behind a nonce gap, by address.
+func (b *EthermintBackend) QueuedTransactions() (map[string][]*watcher.Transaction, error) {
+	lastHeight, err := b.clientCtx.Client.LatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.clientCtx.Client.GetPendingPoolTxs()
+	if err != nil {
+		return nil, err
+	}
+	queued := make(map[string][]*watcher.Transaction, len(result.Txs))
+	for address, txs := range result.Txs {
+		transactions := make([]*watcher.Transaction, 0, len(txs))
+		for _, tx := range txs {
+			ethTx, err := rpctypes.RawTxToEthTx(b.clientCtx, tx.Tx, lastHeight)
+			if err != nil {
+				// ignore non Ethermint EVM transactions
+				continue
+			}
+			rpcTx, err := watcher.NewTransaction(ethTx, common.BytesToHash(ethTx.Hash), common.Hash{}, 0, 0)
+			if err != nil {
+				return nil, err
+			}
+			transactions = append(transactions, rpcTx)
+		}
+		queued[address] = transactions
+	}
+	return queued, nil
+}
+
 func (b *EthermintBackend) PendingAddressList() ([]string, error) {
 	res, err := b.clientCtx.Client.GetAddressList()
 	if err != nil {
@@ -34,6 +34,14 @@ const (
 	FlagDisableAPI            = "rpc.disable-api"
 	FlagKafkaAddr             = "pendingtx.kafka-addr"
 	FlagKafkaTopic            = "pendingtx.kafka-topic"
+	FlagPendingTxTCPAddr      = "pendingtx.tcp-addr"
+	FlagPendingTxTCPBacklog   = "pendingtx.tcp-backlog"
+	FlagPendingTxFileDir      = "pendingtx.file-dir"
+	FlagPendingTxFileMaxSize  = "pendingtx.file-max-size"
+	FlagPendingTxFileMaxFiles = "pendingtx.file-max-files"
+	FlagPendingTxWebhookURL   = "pendingtx.webhook-url"
+	FlagPendingTxWebhookRetry = "pendingtx.webhook-max-retries"
+	FlagPendingTxOffsetFile   = "pendingtx.offset-file"
 	FlagNacosTmrpcUrls        = "rpc.tmrpc_nacos_urls"
 	FlagNacosTmrpcNamespaceID = "rpc.tmrpc_nacos_namespace_id"
 	FlagNacosTmrpcAppName     = "rpc.tmrpc_application_name"
@@ -42,6 +50,14 @@ const (
 	FlagRestNacosUrls         = "rest.nacos_urls"
 	FlagRestNacosNamespaceId  = "rest.nacos_namespace_id"
 	FlagExternalListenAddr    = "rest.external_laddr"
+
+	FlagRateLimitClientRate       = "rpc.rate-limit-client-rate"
+	FlagRateLimitClientBurst      = "rpc.rate-limit-client-burst"
+	FlagRateLimitClientNamespaces = "rpc.rate-limit-client-namespaces"
+	FlagRateLimitMethodCost       = "rpc.rate-limit-method-cost"
+	FlagRateLimitDailyQuota       = "rpc.rate-limit-daily-quota"
+	FlagRateLimitTrustedProxies   = "rpc.rate-limit-trusted-proxies"
+	FlagRateLimitAPIKeys          = "rpc.rate-limit-api-keys"
 )
 
 // RegisterRoutes creates a new server and registers the `/rpc` endpoint.
@@ -89,8 +105,8 @@ func RegisterRoutes(rs *lcd.RestServer) {
 		}
 	}
 
-	// Web3 RPC API route
-	rs.Mux.HandleFunc("/", server.ServeHTTP).Methods("POST", "OPTIONS")
+	// Web3 RPC API route, behind the per-client rate limiter if enabled
+	rs.Mux.Handle("/", withClientRateLimiter(server)).Methods("POST", "OPTIONS")
 
 	// start websockets server
 	websocketAddr := viper.GetString(FlagWebsocket)
@@ -98,13 +114,44 @@ func RegisterRoutes(rs *lcd.RestServer) {
 	ws.Start()
 
 	// pending tx watcher
+	senders := newPendingTxSenders(rs.Logger())
+	if len(senders) != 0 {
+		offsets, err := pendingtx.NewOffsetStore(viper.GetString(FlagPendingTxOffsetFile))
+		if err != nil {
+			panic(err)
+		}
+		ptw := pendingtx.NewWatcher(rs.CliCtx, rs.Logger(), pendingtx.NewMultiSender(senders...), offsets)
+		ptw.Start()
+	}
+}
+
+// newPendingTxSenders creates the senders of the pending tx watcher enabled by the flags.
+func newPendingTxSenders(logger log.Logger) []pendingtx.Sender {
+	var senders []pendingtx.Sender
 	kafkaAddrs := viper.GetString(FlagKafkaAddr)
 	kafkaTopic := viper.GetString(FlagKafkaTopic)
 	if kafkaAddrs != "" && kafkaTopic != "" {
-		kafkaClient := pendingtx.NewKafkaClient(strings.Split(kafkaAddrs, ","), kafkaTopic)
-		ptw := pendingtx.NewWatcher(rs.CliCtx, rs.Logger(), kafkaClient)
-		ptw.Start()
+		senders = append(senders, pendingtx.NewKafkaClient(strings.Split(kafkaAddrs, ","), kafkaTopic))
+	}
+	if addr := viper.GetString(FlagPendingTxTCPAddr); addr != "" {
+		tcpSender, err := pendingtx.NewTCPSender(addr, viper.GetInt(FlagPendingTxTCPBacklog), logger)
+		if err != nil {
+			panic(fmt.Sprintf("failed to listen on %s for pending txs: %s", addr, err))
+		}
+		senders = append(senders, tcpSender)
+	}
+	if dir := viper.GetString(FlagPendingTxFileDir); dir != "" {
+		fileSender, err := pendingtx.NewFileSender(dir,
+			viper.GetInt64(FlagPendingTxFileMaxSize), viper.GetInt(FlagPendingTxFileMaxFiles))
+		if err != nil {
+			panic(err)
+		}
+		senders = append(senders, fileSender)
+	}
+	if url := viper.GetString(FlagPendingTxWebhookURL); url != "" {
+		senders = append(senders, pendingtx.NewWebhookSender(url, viper.GetInt(FlagPendingTxWebhookRetry), logger))
 	}
+	return senders
 }
 
 func unlockKeyFromNameAndPassphrase(accountNames []string, passphrase string) ([]ethsecp256k1.PrivKey, error) {
@@ -83,3 +83,41 @@ func (m *Monitor) OnEnd(args ...interface{}) {
 	}
 	m.metrics.Histogram.With(MetricsMethodLabel, m.method).Observe(elapsed)
 }
+
+const (
+	RateLimitNamespaceLabel = "namespace"
+	RateLimitResultLabel    = "result"
+)
+
+// RateLimitMetrics contains the metrics of the per-client rate limiter of the rpc server.
+type RateLimitMetrics struct {
+	// Requests counts the requests by namespace and result: allowed, rate_limited or quota_exceeded
+	Requests metrics.Counter
+	// Cost counts the cost of the allowed requests by namespace
+	Cost metrics.Counter
+	// Clients is the number of clients tracked by the rate limiter
+	Clients metrics.Gauge
+}
+
+func MakeRateLimitMetrics() *RateLimitMetrics {
+	return &RateLimitMetrics{
+		Requests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limit_requests",
+			Help:      "Number of requests checked by the rate limiter, by namespace and result.",
+		}, []string{RateLimitNamespaceLabel, RateLimitResultLabel}),
+		Cost: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limit_cost",
+			Help:      "Cost of the requests allowed by the rate limiter, by namespace.",
+		}, []string{RateLimitNamespaceLabel}),
+		Clients: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limit_clients",
+			Help:      "Number of clients tracked by the rate limiter.",
+		}, nil),
+	}
+}
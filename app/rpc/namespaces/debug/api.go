@@ -3,16 +3,22 @@ package debug
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/spf13/viper"
 
 	"github.com/ethereum/go-ethereum/common"
 	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	authclient "github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
 
 	"github.com/okex/exchain/app/rpc/backend"
 	"github.com/okex/exchain/app/rpc/monitor"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	ethermint "github.com/okex/exchain/app/types"
+	"github.com/okex/exchain/libs/tendermint/global"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	evmtypes "github.com/okex/exchain/x/evm/types"
 )
@@ -83,3 +89,166 @@ func (api *PublicDebugAPI) TraceTransaction(txHash common.Hash, config evmtypes.
 
 	return decodedResult, nil
 }
+
+// TraceBlockByNumber returns the structured logs created during the execution of
+// all the EVM txs in the block, replaying them in order on the state of the parent block.
+func (api *PublicDebugAPI) TraceBlockByNumber(blockNum rpctypes.BlockNumber, config evmtypes.TraceConfig) ([]sdk.QueryTraceTxResult, error) {
+	monitor := monitor.GetMonitor("debug_traceBlockByNumber", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+	height := blockNum.Int64()
+	if blockNum == rpctypes.LatestBlockNumber || blockNum == rpctypes.PendingBlockNumber {
+		latest, err := api.backend.LatestBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		height = latest
+	}
+	return api.traceBlock(height, config)
+}
+
+// TraceBlockByHash returns the structured logs created during the execution of
+// all the EVM txs in the block, replaying them in order on the state of the parent block.
+func (api *PublicDebugAPI) TraceBlockByHash(hash common.Hash, config evmtypes.TraceConfig) ([]sdk.QueryTraceTxResult, error) {
+	monitor := monitor.GetMonitor("debug_traceBlockByHash", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+	header, err := api.backend.HeaderByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return api.traceBlock(header.Number.Int64(), config)
+}
+
+// TraceCall returns the structured logs created during the execution of the call
+// on the state of the given block, with the state overrides of the config applied.
+func (api *PublicDebugAPI) TraceCall(args rpctypes.CallArgs, blockNrOrHash rpctypes.BlockNumberOrHash, config evmtypes.TraceCallConfig) (interface{}, error) {
+	monitor := monitor.GetMonitor("debug_traceCall", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd()
+	err := evmtypes.TestTracerConfig(&config.TraceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("tracer err : %s", err.Error())
+	}
+	configBytes, err := json.Marshal(config.TraceConfig)
+	if err != nil {
+		return nil, err
+	}
+	var overridesBytes []byte
+	if config.StateOverrides != nil {
+		if err := config.StateOverrides.Check(); err != nil {
+			return nil, err
+		}
+		if overridesBytes, err = config.StateOverrides.GetBytes(); err != nil {
+			return nil, fmt.Errorf("fail to encode overrides")
+		}
+	}
+	blockNum, err := api.backend.ConvertToBlockNumber(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	clientCtx := api.clientCtx
+	// pass the given block height to the context if the height is not pending or latest
+	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
+		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
+	}
+
+	txBytes, from, err := buildCallTx(clientCtx, args)
+	if err != nil {
+		return nil, err
+	}
+	queryParam := sdk.QueryTraceCall{
+		TxBytes:        txBytes,
+		From:           from,
+		ConfigBytes:    configBytes,
+		OverridesBytes: overridesBytes,
+	}
+	queryBytes, err := json.Marshal(&queryParam)
+	if err != nil {
+		return nil, err
+	}
+	resTrace, _, err := clientCtx.QueryWithData("app/traceCall", queryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res sdk.Result
+	if err := clientCtx.Codec.UnmarshalBinaryBare(resTrace, &res); err != nil {
+		return nil, err
+	}
+	var decodedResult interface{}
+	if err := json.Unmarshal(res.Data, &decodedResult); err != nil {
+		return nil, err
+	}
+
+	return decodedResult, nil
+}
+
+func (api *PublicDebugAPI) traceBlock(height int64, config evmtypes.TraceConfig) ([]sdk.QueryTraceTxResult, error) {
+	err := evmtypes.TestTracerConfig(&config)
+	if err != nil {
+		return nil, fmt.Errorf("tracer err : %s", err.Error())
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	queryParam := sdk.QueryTraceBlock{
+		Height:      height,
+		ConfigBytes: configBytes,
+	}
+	queryBytes, err := json.Marshal(&queryParam)
+	if err != nil {
+		return nil, err
+	}
+	resTrace, _, err := api.clientCtx.QueryWithData("app/traceBlock", queryBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var res sdk.Result
+	if err := api.clientCtx.Codec.UnmarshalBinaryBare(resTrace, &res); err != nil {
+		return nil, err
+	}
+	var results []sdk.QueryTraceTxResult
+	if err := json.Unmarshal(res.Data, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// buildCallTx generates the unsigned tx of the call args, and returns it with the sender
+func buildCallTx(clientCtx clientcontext.CLIContext, args rpctypes.CallArgs) ([]byte, string, error) {
+	gas := uint64(ethermint.DefaultRPCGasLimit)
+	if args.Gas != nil {
+		gas = uint64(*args.Gas)
+	}
+	gasPrice := new(big.Int).SetUint64(ethermint.DefaultGasPrice)
+	if args.GasPrice != nil {
+		gasPrice = args.GasPrice.ToInt()
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+	var from string
+	if args.From != nil {
+		from = args.From.String()
+	}
+
+	msg := evmtypes.NewMsgEthereumTx(0, args.To, value, gas, gasPrice, data)
+	var txEncoder sdk.TxEncoder
+	if tmtypes.HigherThanVenus(global.GetGlobalHeight()) {
+		txEncoder = authclient.GetTxEncoder(nil, authclient.WithEthereumTx())
+	} else {
+		txEncoder = authclient.GetTxEncoder(clientCtx.Codec)
+	}
+	// rlp encoder need pointer type, amino encoder will first dereference pointers.
+	txBytes, err := txEncoder(msg)
+	if err != nil {
+		return nil, "", err
+	}
+	return txBytes, from, nil
+}
@@ -0,0 +1,149 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/app/rpc/backend"
+	rpctypes "github.com/okex/exchain/app/rpc/types"
+	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/bytes"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	rpcclient "github.com/okex/exchain/libs/tendermint/rpc/client"
+	"github.com/okex/exchain/libs/tendermint/rpc/client/mock"
+	ctypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+// mockBackend serves the block headers of the blocks of the mock node
+type mockBackend struct {
+	backend.Backend
+	latest int64
+	hashes map[common.Hash]int64
+}
+
+func (b *mockBackend) LatestBlockNumber() (int64, error) {
+	return b.latest, nil
+}
+
+func (b *mockBackend) HeaderByHash(hash common.Hash) (*ethtypes.Header, error) {
+	height, ok := b.hashes[hash]
+	if !ok {
+		return nil, fmt.Errorf("block not found for hash %s", hash.Hex())
+	}
+	return &ethtypes.Header{Number: big.NewInt(height)}, nil
+}
+
+// mockTraceClient answers the app/traceBlock queries with the trace results of its blocks
+type mockTraceClient struct {
+	mock.Client
+	cdc     *codec.Codec
+	blocks  map[int64][]sdk.QueryTraceTxResult
+	queries []sdk.QueryTraceBlock
+}
+
+func (c *mockTraceClient) ABCIQueryWithOptions(path string, data bytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if path != "app/traceBlock" {
+		return nil, fmt.Errorf("unexpected query path %s", path)
+	}
+	var query sdk.QueryTraceBlock
+	if err := json.Unmarshal(data, &query); err != nil {
+		return nil, err
+	}
+	c.queries = append(c.queries, query)
+
+	results, ok := c.blocks[query.Height]
+	if !ok {
+		return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: 1, Log: "invalid trace block height"}}, nil
+	}
+	resultsBytes, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{
+		Value: c.cdc.MustMarshalBinaryBare(&sdk.Result{Data: resultsBytes}),
+	}}, nil
+}
+
+func newTestAPI() (*PublicDebugAPI, *mockTraceClient) {
+	cdc := codec.New()
+	client := &mockTraceClient{
+		cdc: cdc,
+		blocks: map[int64][]sdk.QueryTraceTxResult{
+			2: {
+				{TxHash: common.HexToHash("0x21"), Result: json.RawMessage(`{"failed":false}`)},
+				{TxHash: common.HexToHash("0x22"), Error: "out of gas"},
+			},
+			3: {
+				{TxHash: common.HexToHash("0x31"), Result: json.RawMessage(`{"failed":true}`)},
+			},
+		},
+	}
+	clientCtx := clientcontext.CLIContext{Client: client, Codec: cdc, TrustNode: true}
+	b := &mockBackend{
+		latest: 3,
+		hashes: map[common.Hash]int64{
+			common.HexToHash("0x02"): 2,
+			common.HexToHash("0x03"): 3,
+		},
+	}
+	return NewAPI(clientCtx, log.NewNopLogger(), b), client
+}
+
+func TestTraceBlockByNumber(t *testing.T) {
+	api, client := newTestAPI()
+
+	results, err := api.TraceBlockByNumber(2, evmtypes.TraceConfig{})
+	require.NoError(t, err)
+	require.Equal(t, client.blocks[2], results)
+
+	// the latest and pending blocks are resolved to the latest height
+	results, err = api.TraceBlockByNumber(rpctypes.LatestBlockNumber, evmtypes.TraceConfig{})
+	require.NoError(t, err)
+	require.Equal(t, client.blocks[3], results)
+	results, err = api.TraceBlockByNumber(rpctypes.PendingBlockNumber, evmtypes.TraceConfig{})
+	require.NoError(t, err)
+	require.Equal(t, client.blocks[3], results)
+
+	// the config is passed to the app
+	_, err = api.TraceBlockByNumber(2, evmtypes.TraceConfig{Tracer: "callTracer"})
+	require.NoError(t, err)
+	var config evmtypes.TraceConfig
+	require.NoError(t, json.Unmarshal(client.queries[len(client.queries)-1].ConfigBytes, &config))
+	require.Equal(t, "callTracer", config.Tracer)
+
+	// a missing block
+	_, err = api.TraceBlockByNumber(10, evmtypes.TraceConfig{})
+	require.Error(t, err)
+
+	// an invalid tracer is rejected before the query
+	queried := len(client.queries)
+	_, err = api.TraceBlockByNumber(2, evmtypes.TraceConfig{Tracer: "unknownTracer"})
+	require.Error(t, err)
+	require.Equal(t, queried, len(client.queries))
+}
+
+func TestTraceBlockByHash(t *testing.T) {
+	api, client := newTestAPI()
+
+	byHash, err := api.TraceBlockByHash(common.HexToHash("0x02"), evmtypes.TraceConfig{})
+	require.NoError(t, err)
+	byNumber, err := api.TraceBlockByNumber(2, evmtypes.TraceConfig{})
+	require.NoError(t, err)
+	require.Equal(t, byNumber, byHash)
+	require.Equal(t, client.queries[0], client.queries[1])
+
+	// a missing block is not queried
+	_, err = api.TraceBlockByHash(common.HexToHash("0x10"), evmtypes.TraceConfig{})
+	require.Error(t, err)
+	require.Equal(t, 2, len(client.queries))
+}
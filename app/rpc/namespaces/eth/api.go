@@ -1474,6 +1474,7 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (*watcher.
 		TransactionIndex:  hexutil.Uint64(tx.Index),
 		From:              ethTx.GetFrom(),
 		To:                ethTx.To(),
+		Type:              hexutil.Uint64(ethTx.Data.Type),
 	}
 
 	return receipt, nil
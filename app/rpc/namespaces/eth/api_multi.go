@@ -285,6 +285,7 @@ func (api *PublicEthereumAPI) GetTransactionReceiptsByBlock(blockNrOrHash rpctyp
 			TransactionIndex: hexutil.Uint64(tx.Index),
 			From:             ethTx.GetFrom(),
 			To:               ethTx.To(),
+			Type:             hexutil.Uint64(ethTx.Data.Type),
 		}
 		receipts = append(receipts, receipt)
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
@@ -47,6 +48,11 @@ type Backend interface {
 	// logs limitations
 	LogsLimit() int
 	LogsTimeout() time.Duration
+	LogsMaxResults() int
+	// the log index of the watch db
+	LogIndexBase() (uint64, bool)
+	FilterLogsFromIndex(ctx context.Context, from, to uint64, addresses []common.Address, topics [][]common.Hash,
+		after *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error)
 }
 
 // consider a filter inactive if it has not been polled for within deadline
@@ -496,6 +502,62 @@ func (api *PublicFilterAPI) GetLogs(ctx context.Context, criteria filters.Filter
 	return returnLogs(logs), nil
 }
 
+// LogsPage is a page of the logs matching a filter criteria, NextCursor is the cursor of the next
+// page if there is one.
+type LogsPage struct {
+	Logs       []*ethtypes.Log `json:"logs"`
+	NextCursor *hexutil.Bytes  `json:"nextCursor"`
+}
+
+// GetLogsPage returns the logs matching the given argument page by page, from the log index. The
+// first page is requested with a nil cursor, the next ones with the cursor of the previous page.
+func (api *PublicFilterAPI) GetLogsPage(ctx context.Context, criteria filters.FilterCriteria, cursor *hexutil.Bytes) (*LogsPage, error) {
+	monitor := monitor.GetMonitor("eth_getLogsPage", api.logger, api.Metrics).OnBegin()
+	defer monitor.OnEnd("args", criteria, "cursor", cursor)
+	if api.backend.IsDisabled("eth_getLogsPage") {
+		return nil, ErrMethodNotAllowed
+	}
+	rateLimiter := api.backend.GetRateLimiter("eth_getLogsPage")
+	if rateLimiter != nil && !rateLimiter.Allow() {
+		return nil, rpctypes.ErrServerBusy
+	}
+
+	var after *watcher.LogPosition
+	if cursor != nil {
+		pos, err := watcher.LogPositionFromBytes(*cursor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %s", err)
+		}
+		after = &pos
+	}
+
+	var filter *Filter
+	if criteria.BlockHash != nil {
+		filter = NewBlockFilter(api.backend, criteria)
+	} else {
+		begin := rpc.LatestBlockNumber.Int64()
+		if criteria.FromBlock != nil {
+			begin = criteria.FromBlock.Int64()
+		}
+		end := rpc.LatestBlockNumber.Int64()
+		if criteria.ToBlock != nil {
+			end = criteria.ToBlock.Int64()
+		}
+		filter = NewRangeFilter(api.backend, begin, end, criteria.Addresses, criteria.Topics)
+	}
+
+	logs, next, err := filter.LogsPage(ctx, after)
+	if err != nil {
+		return nil, err
+	}
+	page := &LogsPage{Logs: returnLogs(logs)}
+	if next != nil {
+		nextCursor := hexutil.Bytes(next.Bytes())
+		page.NextCursor = &nextCursor
+	}
+	return page, nil
+}
+
 // UninstallFilter removes the filter with the given filter id.
 //
 // https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_uninstallfilter
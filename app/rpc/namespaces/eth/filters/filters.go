@@ -2,6 +2,7 @@ package filters
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -13,11 +14,14 @@ import (
 	"github.com/ethereum/go-ethereum/eth/filters"
 	rpctypes "github.com/okex/exchain/app/rpc/types"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/spf13/viper"
 )
 
 const FlagGetLogsHeightSpan = "logs-height-span"
 
+var errLogIndexDisabled = errors.New("the node connected does not maintain a log index")
+
 // Filter can be used to retrieve and filter logs.
 type Filter struct {
 	backend  Backend
@@ -95,29 +99,13 @@ func (f *Filter) Logs(ctx context.Context) ([]*ethtypes.Log, error) {
 		return f.blockLogs(header)
 	}
 
-	// Figure out the limits of the filter range
-	header, err := f.backend.HeaderByNumber(rpctypes.LatestBlockNumber)
-	if err != nil {
+	if ok, err := f.resolveRange(); !ok || err != nil {
 		return nil, err
 	}
 
-	if header == nil || header.Number == nil {
-		return nil, nil
-	}
-
-	head := header.Number.Int64()
-	if f.criteria.FromBlock.Int64() == -1 {
-		f.criteria.FromBlock = big.NewInt(head)
-	}
-	if f.criteria.ToBlock.Int64() == -1 {
-		f.criteria.ToBlock = big.NewInt(head)
-	}
-	if f.criteria.ToBlock.Int64() > head {
-		f.criteria.ToBlock = big.NewInt(head)
-	}
-	if f.criteria.FromBlock.Int64() <= tmtypes.GetStartBlockHeight() ||
-		f.criteria.ToBlock.Int64() <= tmtypes.GetStartBlockHeight() {
-		return nil, fmt.Errorf("from and to block height must greater than %d", tmtypes.GetStartBlockHeight())
+	// the ranges covered by the log index need no bloom scanning nor height span
+	if base, ok := f.backend.LogIndexBase(); ok && f.criteria.FromBlock.Uint64() >= base {
+		return f.logIndexLogs(ctx)
 	}
 
 	heightSpan := viper.GetInt64(FlagGetLogsHeightSpan)
@@ -149,6 +137,80 @@ func (f *Filter) Logs(ctx context.Context) ([]*ethtypes.Log, error) {
 	return logs, err
 }
 
+// resolveRange replaces the latest block height of the range by the head height, it returns false
+// if there is no block yet.
+func (f *Filter) resolveRange() (bool, error) {
+	header, err := f.backend.HeaderByNumber(rpctypes.LatestBlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if header == nil || header.Number == nil {
+		return false, nil
+	}
+
+	head := header.Number.Int64()
+	if f.criteria.FromBlock.Int64() == -1 {
+		f.criteria.FromBlock = big.NewInt(head)
+	}
+	if f.criteria.ToBlock.Int64() == -1 {
+		f.criteria.ToBlock = big.NewInt(head)
+	}
+	if f.criteria.ToBlock.Int64() > head {
+		f.criteria.ToBlock = big.NewInt(head)
+	}
+	if f.criteria.FromBlock.Int64() <= tmtypes.GetStartBlockHeight() ||
+		f.criteria.ToBlock.Int64() <= tmtypes.GetStartBlockHeight() {
+		return false, fmt.Errorf("from and to block height must greater than %d", tmtypes.GetStartBlockHeight())
+	}
+	return true, nil
+}
+
+// logIndexLogs returns the logs matching the filter criteria from the log index, it fails if there
+// are more logs than the max results.
+func (f *Filter) logIndexLogs(ctx context.Context) ([]*ethtypes.Log, error) {
+	maxResults := f.backend.LogsMaxResults()
+	logs, next, err := f.logIndexPage(ctx, nil, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		return nil, fmt.Errorf("query returned more than %d results, narrow the block range or use eth_getLogsPage", maxResults)
+	}
+	return logs, nil
+}
+
+func (f *Filter) logIndexPage(ctx context.Context, after *watcher.LogPosition, limit int) ([]*ethtypes.Log, *watcher.LogPosition, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.backend.LogsTimeout())
+	defer cancel()
+	logs, next, err := f.backend.FilterLogsFromIndex(ctx, f.criteria.FromBlock.Uint64(), f.criteria.ToBlock.Uint64(),
+		f.criteria.Addresses, f.criteria.Topics, after, limit)
+	if err == context.DeadlineExceeded {
+		return nil, nil, backend.ErrTimeout
+	}
+	return logs, next, err
+}
+
+// LogsPage returns a page of at most LogsMaxResults logs matching the filter criteria from the log
+// index, after the position if not nil, and the position of the next page if any.
+func (f *Filter) LogsPage(ctx context.Context, after *watcher.LogPosition) ([]*ethtypes.Log, *watcher.LogPosition, error) {
+	if f.criteria.BlockHash != nil && *f.criteria.BlockHash != (common.Hash{}) {
+		logs, err := f.Logs(ctx)
+		return logs, nil, err
+	}
+	base, ok := f.backend.LogIndexBase()
+	if !ok {
+		return nil, nil, errLogIndexDisabled
+	}
+	if ok, err := f.resolveRange(); !ok || err != nil {
+		return nil, nil, err
+	}
+	if f.criteria.FromBlock.Uint64() < base {
+		return nil, nil, fmt.Errorf("the log index starts at block %d", base)
+	}
+	return f.logIndexPage(ctx, after, f.backend.LogsMaxResults())
+}
+
 // blockLogs returns the logs matching the filter criteria within a single block.
 func (f *Filter) blockLogs(header *ethtypes.Header) ([]*ethtypes.Log, error) {
 	if !bloomFilter(header.Bloom, f.criteria.Addresses, f.criteria.Topics) {
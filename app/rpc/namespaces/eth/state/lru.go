@@ -59,3 +59,11 @@ func SetStateToLru(key []byte, value []byte) {
 	}
 	cache.Add(amino.BytesToStr(key), value)
 }
+
+func RemoveStateFromLru(key []byte) {
+	cache := InstanceOfStateLru()
+	if cache == nil {
+		return
+	}
+	cache.Remove(amino.BytesToStr(key))
+}
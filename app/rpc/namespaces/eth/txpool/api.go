@@ -2,15 +2,50 @@ package txpool
 
 import (
 	"fmt"
+	"math/big"
+	"sort"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
-	"github.com/okex/exchain/x/evm/watcher"
+	"github.com/spf13/viper"
 
+	appconfig "github.com/okex/exchain/app/config"
 	"github.com/okex/exchain/app/rpc/backend"
+	clientcontext "github.com/okex/exchain/libs/cosmos-sdk/client/context"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	authtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	tmconfig "github.com/okex/exchain/libs/tendermint/config"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/mempool"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+const (
+	flagPendingPoolMaxTxPerAddress = "mempool.pending_pool_max_tx_per_address"
+
+	// StuckReasonNonceGap is a queued tx behind a missing nonce of its sender.
+	StuckReasonNonceGap = "nonce_gap"
+	// StuckReasonUnderpriced is a queued tx with a gas price below the recommended gas price.
+	StuckReasonUnderpriced = "underpriced"
+	// StuckReasonAddressLimit is a queued tx of a sender beyond the maximum number of txs per
+	// address of the pending pool.
+	StuckReasonAddressLimit = "address_limit"
+	// StuckReasonBlacklisted is a queued tx of a sender in the pending pool blacklist.
+	StuckReasonBlacklisted = "blacklisted"
 )
 
+// StuckTx reports why a queued tx is not executable, ExpectedNonce being the nonce missing before
+// it. A queued tx without reason is promoted to the pending txs as soon as the mempool has room for it.
+type StuckTx struct {
+	Hash                common.Hash    `json:"hash"`
+	Nonce               hexutil.Uint64 `json:"nonce"`
+	GasPrice            *hexutil.Big   `json:"gasPrice"`
+	Reasons             []string       `json:"reasons"`
+	ExpectedNonce       hexutil.Uint64 `json:"expectedNonce"`
+	RecommendedGasPrice *hexutil.Big   `json:"recommendedGasPrice"`
+}
+
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
 type PublicTxPoolAPI struct {
 	clientCtx clientcontext.CLIContext
@@ -28,30 +63,36 @@ func NewAPI(clientCtx clientcontext.CLIContext, log log.Logger, backend backend.
 	return api
 }
 
-// Content returns the transactions contained within the transaction pool.
+// Content returns the transactions contained within the transaction pool: the executable txs of
+// the mempool are pending, the txs of the pending pool behind a nonce gap are queued.
 func (s *PublicTxPoolAPI) Content() map[string]map[string]map[string]*watcher.Transaction {
-	addressList, err := s.backend.PendingAddressList()
+	pending, queued := s.content()
+	return map[string]map[string]map[string]*watcher.Transaction{
+		"pending": flatten(pending),
+		"queued":  flatten(queued),
+	}
+}
+
+// ContentFrom returns the transactions of an address contained within the transaction pool.
+func (s *PublicTxPoolAPI) ContentFrom(address common.Address) map[string]map[string]*watcher.Transaction {
+	content := map[string]map[string]*watcher.Transaction{
+		"pending": make(map[string]*watcher.Transaction),
+		"queued":  make(map[string]*watcher.Transaction),
+	}
+	txs, err := s.backend.UserPendingTransactions(address.String(), -1)
 	if err != nil {
-		s.logger.Error("txpool.Content addressList err: ", err)
+		s.logger.Error("txpool.ContentFrom err: ", err)
 	}
-	content := map[string]map[string]map[string]*watcher.Transaction{
-		"queued": make(map[string]map[string]*watcher.Transaction),
+	for _, tx := range txs {
+		content["pending"][fmt.Sprintf("%d", tx.Nonce)] = tx
 	}
-
-	for _, address := range addressList {
-		txs, err := s.backend.UserPendingTransactions(address, -1)
-		if err != nil {
-			s.logger.Error("txpool.Content err: ", err)
-		}
-
-		// Flatten the queued transactions
-		dump := make(map[string]*watcher.Transaction)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce)] = tx
-		}
-		content["queued"][address] = dump
+	queued, err := s.backend.QueuedTransactions()
+	if err != nil {
+		s.logger.Error("txpool.ContentFrom queued err: ", err)
+	}
+	for _, tx := range queued[address.String()] {
+		content["queued"][fmt.Sprintf("%d", tx.Nonce)] = tx
 	}
-
 	return content
 }
 
@@ -62,42 +103,174 @@ func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
 		s.logger.Error("txpool.Status err: ", err)
 		return nil
 	}
+	queued, err := s.backend.QueuedTransactions()
+	if err != nil {
+		s.logger.Error("txpool.Status queued err: ", err)
+		return nil
+	}
+	var numQueued int
+	for _, txs := range queued {
+		numQueued += len(txs)
+	}
 	return map[string]hexutil.Uint{
-		"queued": hexutil.Uint(numRes),
+		"pending": hexutil.Uint(numRes),
+		"queued":  hexutil.Uint(numQueued),
 	}
 }
 
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
 func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
-	addressList, err := s.backend.PendingAddressList()
-	if err != nil {
-		s.logger.Error("txpool.Inspect addressList err: ", err)
+	// Define a formatter to flatten a transaction into a string
+	var format = func(tx *watcher.Transaction) string {
+		if to := tx.To; to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value, tx.Gas, tx.GasPrice)
+		}
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value, tx.Gas, tx.GasPrice)
 	}
+
+	pending, queued := s.content()
 	content := map[string]map[string]map[string]string{
-		"queued": make(map[string]map[string]string),
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
+	}
+	for kind, txsByAddress := range map[string]map[string][]*watcher.Transaction{"pending": pending, "queued": queued} {
+		for address, txs := range txsByAddress {
+			// Flatten the transactions
+			dump := make(map[string]string)
+			for _, tx := range txs {
+				dump[fmt.Sprintf("%d", tx.Nonce)] = format(tx)
+			}
+			content[kind][address] = dump
+		}
+	}
+	return content
+}
+
+// StuckReasons reports why each queued transaction is not executable, by address and nonce.
+func (s *PublicTxPoolAPI) StuckReasons() map[string]map[string]*StuckTx {
+	queued, err := s.backend.QueuedTransactions()
+	if err != nil {
+		s.logger.Error("txpool.StuckReasons err: ", err)
+	}
+
+	recommendedGP := new(big.Int).Set(mempool.GlobalRecommendedGP)
+	maxTxPerAddress := pendingPoolMaxTxPerAddress()
+	blacklist := make(map[string]bool)
+	for _, address := range strings.Split(appconfig.GetOecConfig().GetPendingPoolBlacklist(), ",") {
+		if address != "" {
+			blacklist[address] = true
+		}
+	}
+
+	content := make(map[string]map[string]*StuckTx)
+	for address, txs := range queued {
+		dump := make(map[string]*StuckTx)
+		for _, tx := range stuckTxs(txs, s.expectedNonce(address), recommendedGP, maxTxPerAddress, blacklist[address]) {
+			dump[fmt.Sprintf("%d", tx.Nonce)] = tx
+		}
+		content[address] = dump
+	}
+	return content
+}
+
+// pendingPoolMaxTxPerAddress returns the maximum number of txs per address of the pending pool,
+// the default of the mempool if it is not configured.
+func pendingPoolMaxTxPerAddress() int {
+	if viper.IsSet(flagPendingPoolMaxTxPerAddress) {
+		return viper.GetInt(flagPendingPoolMaxTxPerAddress)
+	}
+	return tmconfig.DefaultMempoolConfig().PendingPoolMaxTxPerAddress
+}
+
+// content returns the pending and the queued transactions by address.
+func (s *PublicTxPoolAPI) content() (pending, queued map[string][]*watcher.Transaction) {
+	addressList, err := s.backend.PendingAddressList()
+	if err != nil {
+		s.logger.Error("txpool addressList err: ", err)
 	}
+	pending = make(map[string][]*watcher.Transaction, len(addressList))
 	for _, address := range addressList {
 		txs, err := s.backend.UserPendingTransactions(address, -1)
 		if err != nil {
-			s.logger.Error("txpool.Inspect err: ", err)
+			s.logger.Error("txpool pending txs err: ", err)
 		}
+		pending[address] = txs
+	}
 
-		// Define a formatter to flatten a transaction into a string
-		var format = func(tx *watcher.Transaction) string {
-			if to := tx.To; to != nil {
-				return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To.Hex(), tx.Value, tx.Gas, tx.GasPrice)
-			}
-			return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value, tx.Gas, tx.GasPrice)
+	queued, err = s.backend.QueuedTransactions()
+	if err != nil {
+		s.logger.Error("txpool queued txs err: ", err)
+	}
+	return pending, queued
+}
+
+// expectedNonce returns the nonce following the txs of address executed or pending.
+func (s *PublicTxPoolAPI) expectedNonce(address string) uint64 {
+	if pendingNonce, ok := s.backend.GetPendingNonce(address); ok {
+		return pendingNonce + 1
+	}
+	account, err := authtypes.NewAccountRetriever(s.clientCtx).GetAccount(sdk.AccAddress(common.HexToAddress(address).Bytes()))
+	if err != nil {
+		// the account does not exist yet
+		return 0
+	}
+	return account.GetSequence()
+}
+
+// stuckTxs returns the reasons why the queued txs of an address are not executable.
+func stuckTxs(txs []*watcher.Transaction, expectedNonce uint64, recommendedGP *big.Int,
+	maxTxPerAddress int, blacklisted bool) []*StuckTx {
+	sorted := make([]*watcher.Transaction, len(txs))
+	copy(sorted, txs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Nonce < sorted[j].Nonce })
+
+	stuck := make([]*StuckTx, 0, len(sorted))
+	next, gap := expectedNonce, false
+	for i, tx := range sorted {
+		// the txs after a missing nonce stay behind it
+		if uint64(tx.Nonce) != next {
+			gap = true
+		}
+		expected := next
+		if !gap {
+			next++
 		}
 
-		// Flatten the queued transactions
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce)] = format(tx)
+		reasons := make([]string, 0)
+		if gap {
+			reasons = append(reasons, StuckReasonNonceGap)
+		}
+		if tx.GasPrice != nil && tx.GasPrice.ToInt().Cmp(recommendedGP) < 0 {
+			reasons = append(reasons, StuckReasonUnderpriced)
+		}
+		if maxTxPerAddress > 0 && i >= maxTxPerAddress {
+			reasons = append(reasons, StuckReasonAddressLimit)
+		}
+		if blacklisted {
+			reasons = append(reasons, StuckReasonBlacklisted)
 		}
-		content["queued"][address] = dump
+		stuck = append(stuck, &StuckTx{
+			Hash:                tx.Hash,
+			Nonce:               tx.Nonce,
+			GasPrice:            tx.GasPrice,
+			Reasons:             reasons,
+			ExpectedNonce:       hexutil.Uint64(expected),
+			RecommendedGasPrice: (*hexutil.Big)(recommendedGP),
+		})
 	}
+	return stuck
+}
 
+// flatten indexes the transactions by address and nonce.
+func flatten(txsByAddress map[string][]*watcher.Transaction) map[string]map[string]*watcher.Transaction {
+	content := make(map[string]map[string]*watcher.Transaction, len(txsByAddress))
+	for address, txs := range txsByAddress {
+		dump := make(map[string]*watcher.Transaction, len(txs))
+		for _, tx := range txs {
+			dump[fmt.Sprintf("%d", tx.Nonce)] = tx
+		}
+		content[address] = dump
+	}
 	return content
 }
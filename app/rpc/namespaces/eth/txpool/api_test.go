@@ -0,0 +1,69 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	tmconfig "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+func newQueuedTx(nonce uint64, gasPrice int64) *watcher.Transaction {
+	return &watcher.Transaction{
+		Nonce:    hexutil.Uint64(nonce),
+		GasPrice: (*hexutil.Big)(big.NewInt(gasPrice)),
+	}
+}
+
+func TestStuckTxs(t *testing.T) {
+	recommendedGP := big.NewInt(100)
+	txs := []*watcher.Transaction{newQueuedTx(7, 100), newQueuedTx(5, 100), newQueuedTx(6, 10)}
+
+	// the txs are contiguous from the expected nonce and promoted once the mempool has room
+	stuck := stuckTxs(txs, 5, recommendedGP, 0, false)
+	require.Len(t, stuck, 3)
+	require.Equal(t, hexutil.Uint64(5), stuck[0].Nonce)
+	require.Empty(t, stuck[0].Reasons)
+	require.Equal(t, []string{StuckReasonUnderpriced}, stuck[1].Reasons)
+	require.Empty(t, stuck[2].Reasons)
+
+	// all the txs are behind the missing nonce 4
+	stuck = stuckTxs(txs, 4, recommendedGP, 3, true)
+	for _, tx := range stuck {
+		require.Contains(t, tx.Reasons, StuckReasonNonceGap)
+		require.NotContains(t, tx.Reasons, StuckReasonAddressLimit)
+		require.Contains(t, tx.Reasons, StuckReasonBlacklisted)
+		require.Equal(t, hexutil.Uint64(4), tx.ExpectedNonce)
+	}
+	require.Contains(t, stuck[1].Reasons, StuckReasonUnderpriced)
+
+	// only the txs beyond the limit of the address are flagged
+	stuck = stuckTxs(txs, 5, recommendedGP, 2, false)
+	require.Empty(t, stuck[0].Reasons)
+	require.Equal(t, []string{StuckReasonUnderpriced}, stuck[1].Reasons)
+	require.Equal(t, []string{StuckReasonAddressLimit}, stuck[2].Reasons)
+
+	// the txs following a gap stay behind it
+	stuck = stuckTxs([]*watcher.Transaction{newQueuedTx(4, 100), newQueuedTx(6, 100), newQueuedTx(7, 100)},
+		4, recommendedGP, 0, false)
+	require.Empty(t, stuck[0].Reasons)
+	require.Equal(t, []string{StuckReasonNonceGap}, stuck[1].Reasons)
+	require.Equal(t, []string{StuckReasonNonceGap}, stuck[2].Reasons)
+	require.Equal(t, hexutil.Uint64(5), stuck[1].ExpectedNonce)
+	require.Equal(t, hexutil.Uint64(5), stuck[2].ExpectedNonce)
+}
+
+func TestPendingPoolMaxTxPerAddress(t *testing.T) {
+	defer viper.Reset()
+
+	// the default of the mempool when it is not configured
+	require.Equal(t, tmconfig.DefaultMempoolConfig().PendingPoolMaxTxPerAddress, pendingPoolMaxTxPerAddress())
+	require.Equal(t, 100, pendingPoolMaxTxPerAddress())
+
+	viper.Set(flagPendingPoolMaxTxPerAddress, 20)
+	require.Equal(t, 20, pendingPoolMaxTxPerAddress())
+}
@@ -0,0 +1,117 @@
+package pendingtx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const filePattern = "pendingtx-*.jsonl"
+
+// FileSender writes the txs as JSON lines to a file of dir, rolled to a new file once maxSize
+// bytes are written. Only the last maxFiles files are kept, all of them if maxFiles is 0.
+// The files are named after their creation time, so that their names sort in the order of the
+// offsets of their txs.
+type FileSender struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	mtx  sync.Mutex
+	file *os.File
+	size int64
+}
+
+func NewFileSender(dir string, maxSize int64, maxFiles int) (*FileSender, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileSender{dir: dir, maxSize: maxSize, maxFiles: maxFiles}, nil
+}
+
+func (f *FileSender) SendPending(_ []byte, tx *PendingTx) error {
+	msg, err := marshalPending(tx)
+	if err != nil {
+		return err
+	}
+	return f.write(msg)
+}
+
+func (f *FileSender) SendRmPending(_ []byte, tx *RmPendingTx) error {
+	msg, err := marshalRmPending(tx)
+	if err != nil {
+		return err
+	}
+	return f.write(msg)
+}
+
+// Close closes the current file.
+func (f *FileSender) Close() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *FileSender) write(msg []byte) error {
+	line := append(msg, '\n')
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.file == nil || (f.maxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxSize) {
+		if err := f.roll(); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	return err
+}
+
+// roll must be called with mtx held.
+func (f *FileSender) roll() error {
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+	}
+
+	name := fmt.Sprintf("pendingtx-%s.jsonl", time.Now().UTC().Format("20060102T150405.000000000"))
+	file, err := os.OpenFile(filepath.Join(f.dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file, f.size = file, info.Size()
+	return f.removeOldest()
+}
+
+func (f *FileSender) removeOldest() error {
+	if f.maxFiles <= 0 {
+		return nil
+	}
+	names, err := filepath.Glob(filepath.Join(f.dir, filePattern))
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+	for len(names) > f.maxFiles {
+		if err := os.Remove(names[0]); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
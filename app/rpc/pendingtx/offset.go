@@ -0,0 +1,81 @@
+package pendingtx
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// offsetReserve is the number of offsets reserved by a write of the offset file
+const offsetReserve = 1000
+
+// OffsetStore assigns increasing offsets to the pending and rm pending txs. With a file, the
+// offsets keep increasing after a restart: the store persists the highest offset reserved and
+// restarts after it, so that the consumers resuming from an offset never see an offset twice.
+type OffsetStore struct {
+	mtx      sync.Mutex
+	path     string
+	next     uint64
+	reserved uint64
+}
+
+// NewOffsetStore loads the offset file, the offsets restart from 1 if path is empty.
+func NewOffsetStore(path string) (*OffsetStore, error) {
+	s := &OffsetStore{path: path, next: 1}
+	if path == "" {
+		return s, nil
+	}
+	bz, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := strconv.ParseUint(strings.TrimSpace(string(bz)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid offset file %s: %s", path, err)
+	}
+	s.next, s.reserved = reserved+1, reserved
+	return s, nil
+}
+
+// Next returns the next offset.
+func (s *OffsetStore) Next() (uint64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.path != "" && s.next > s.reserved {
+		reserved := s.next + offsetReserve - 1
+		if err := s.persist(reserved); err != nil {
+			return 0, err
+		}
+		s.reserved = reserved
+	}
+	offset := s.next
+	s.next++
+	return offset, nil
+}
+
+func (s *OffsetStore) persist(reserved uint64) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.WriteString(strconv.FormatUint(reserved, 10)); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
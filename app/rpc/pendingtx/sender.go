@@ -0,0 +1,44 @@
+package pendingtx
+
+import (
+	"encoding/json"
+)
+
+type multiSender []Sender
+
+// NewMultiSender returns a Sender pushing the txs to all the senders.
+func NewMultiSender(senders ...Sender) Sender {
+	if len(senders) == 1 {
+		return senders[0]
+	}
+	return multiSender(senders)
+}
+
+func (ms multiSender) SendPending(hash []byte, tx *PendingTx) error {
+	var err error
+	for _, s := range ms {
+		if e := s.SendPending(hash, tx); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (ms multiSender) SendRmPending(hash []byte, tx *RmPendingTx) error {
+	var err error
+	for _, s := range ms {
+		if e := s.SendRmPending(hash, tx); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// marshalPending encodes the messages of the tcp, file and webhook senders.
+func marshalPending(tx *PendingTx) ([]byte, error) {
+	return json.Marshal(PendingMsg{Topic: TopicPending, Data: tx})
+}
+
+func marshalRmPending(tx *RmPendingTx) ([]byte, error) {
+	return json.Marshal(RmPendingMsg{Topic: TopicRmPending, Data: tx})
+}
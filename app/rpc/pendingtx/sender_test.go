@@ -0,0 +1,201 @@
+package pendingtx
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffsetStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offset")
+	s, err := NewOffsetStore(path)
+	require.NoError(t, err)
+	for i := uint64(1); i <= 3; i++ {
+		offset, err := s.Next()
+		require.NoError(t, err)
+		require.Equal(t, i, offset)
+	}
+
+	// the offsets keep increasing after a restart, skipping the offsets reserved
+	s, err = NewOffsetStore(path)
+	require.NoError(t, err)
+	offset, err := s.Next()
+	require.NoError(t, err)
+	require.Equal(t, uint64(offsetReserve+1), offset)
+
+	// without file the offsets restart from 1
+	s, err = NewOffsetStore("")
+	require.NoError(t, err)
+	offset, err = s.Next()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), offset)
+}
+
+func TestReasons(t *testing.T) {
+	require.Equal(t, "confirmed", RmReasonConfirmed.String())
+	require.Equal(t, "min_gas_price", RmReasonMinGasPrice.String())
+	require.Equal(t, "new", AddReasonNew.String())
+
+	// the reasons are encoded as before
+	bz, err := marshalRmPending(&RmPendingTx{Reason: RmReasonConfirmed, Offset: 7})
+	require.NoError(t, err)
+	var msg struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bz, &msg))
+	require.Equal(t, float64(2), msg.Data["reason"])
+	require.Equal(t, float64(7), msg.Data["offset"])
+}
+
+func TestFileSender(t *testing.T) {
+	dir := t.TempDir()
+	msg, err := marshalRmPending(&RmPendingTx{Offset: 1})
+	require.NoError(t, err)
+	// two messages per file
+	f, err := NewFileSender(dir, int64(2*(len(msg)+1)), 2)
+	require.NoError(t, err)
+
+	for i := uint64(1); i <= 5; i++ {
+		require.NoError(t, f.SendRmPending(nil, &RmPendingTx{Offset: i}))
+		// files named after their creation time
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, f.Close())
+
+	names, err := filepath.Glob(filepath.Join(dir, filePattern))
+	require.NoError(t, err)
+	require.Len(t, names, 2)
+	var offsets []uint64
+	for _, name := range names {
+		bz, err := ioutil.ReadFile(name)
+		require.NoError(t, err)
+		offsets = append(offsets, readOffsets(t, string(bz))...)
+	}
+	require.Equal(t, []uint64{3, 4, 5}, offsets)
+}
+
+func readOffsets(t *testing.T, lines string) []uint64 {
+	var offsets []uint64
+	scanner := bufio.NewScanner(strings.NewReader(lines))
+	for scanner.Scan() {
+		var msg RmPendingMsg
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		require.Equal(t, TopicRmPending, msg.Topic)
+		offsets = append(offsets, msg.Data.Offset)
+	}
+	return offsets
+}
+
+func subscribe(t *testing.T, addr net.Addr, sub string) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", addr.String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_, err = conn.Write([]byte(sub + "\n"))
+	require.NoError(t, err)
+	r := bufio.NewReader(conn)
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "+OK\n", line)
+	return conn, r
+}
+
+func readTCPOffset(t *testing.T, conn net.Conn, r *bufio.Reader) uint64 {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	line, err := r.ReadBytes('\n')
+	require.NoError(t, err)
+	var msg RmPendingMsg
+	require.NoError(t, json.Unmarshal(line, &msg))
+	return msg.Data.Offset
+}
+
+func TestTCPSender(t *testing.T) {
+	s, err := NewTCPSender("127.0.0.1:0", 3, log.NewNopLogger())
+	require.NoError(t, err)
+	defer s.Close()
+
+	live, liveReader := subscribe(t, s.Addr(), "SUB")
+	for i := uint64(1); i <= 5; i++ {
+		require.NoError(t, s.SendRmPending(nil, &RmPendingTx{Offset: i}))
+	}
+	for i := uint64(1); i <= 5; i++ {
+		require.Equal(t, i, readTCPOffset(t, live, liveReader))
+	}
+
+	// resume after offset 3 from the backlog, then receive the new txs
+	resumed, resumedReader := subscribe(t, s.Addr(), "SUB 3")
+	require.NoError(t, s.SendRmPending(nil, &RmPendingTx{Offset: 6}))
+	for i := uint64(4); i <= 6; i++ {
+		require.Equal(t, i, readTCPOffset(t, resumed, resumedReader))
+	}
+
+	// an offset of a previous run replays the whole backlog
+	restarted, restartedReader := subscribe(t, s.Addr(), "SUB 100")
+	for i := uint64(4); i <= 6; i++ {
+		require.Equal(t, i, readTCPOffset(t, restarted, restartedReader))
+	}
+
+	// invalid subscription
+	conn, err := net.Dial("tcp", s.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("PUB\n"))
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "-ERR expected SUB [offset]\n", line)
+}
+
+func TestWebhookSender(t *testing.T) {
+	var mtx sync.Mutex
+	var offsets []string
+	failures := 2
+	received := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		offset := r.Header.Get("X-Pendingtx-Offset")
+		switch {
+		case offset == "2":
+			// not retried
+			w.WriteHeader(http.StatusBadRequest)
+		case failures > 0:
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		default:
+			offsets = append(offsets, offset)
+		}
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	w := NewWebhookSender(server.URL, 0, log.NewNopLogger())
+	w.minBackoff, w.maxBackoff = time.Millisecond, 2*time.Millisecond
+	defer w.Close()
+
+	for i := uint64(1); i <= 3; i++ {
+		require.NoError(t, w.SendPending(nil, &PendingTx{Offset: i}))
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			t.Fatal("webhook not posted")
+		}
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Equal(t, []string{"1", "3"}, offsets)
+	require.Equal(t, 0, failures)
+}
@@ -0,0 +1,230 @@
+package pendingtx
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const (
+	// tcpHandshakeTimeout is the time given to a client to subscribe after connecting
+	tcpHandshakeTimeout = 5 * time.Second
+	// tcpClientBuffer is the number of messages queued for a client, a slower client is
+	// disconnected and resumes from its last offset
+	tcpClientBuffer = 1024
+)
+
+type tcpMessage struct {
+	offset uint64
+	line   []byte
+}
+
+type tcpClient struct {
+	conn net.Conn
+	out  chan []byte
+}
+
+// TCPSender fans the txs out to the clients connected, as JSON lines. A client subscribes by
+// sending "SUB\n" to receive the new txs, or "SUB <offset>\n" to receive first the txs after
+// offset still kept in the backlog, so that a client reconnecting does not miss the txs removed
+// meanwhile. The server answers "+OK\n", or "-ERR <reason>\n" before closing the connection.
+type TCPSender struct {
+	listener net.Listener
+	logger   log.Logger
+
+	mtx sync.Mutex
+	// backlog is a ring of the last messages, the oldest one at head once full
+	backlog    []tcpMessage
+	head       int
+	lastOffset uint64
+	clients    map[*tcpClient]struct{}
+	closed     bool
+}
+
+// NewTCPSender listens on addr and keeps the last backlogSize messages for the clients resuming.
+func NewTCPSender(addr string, backlogSize int, logger log.Logger) (*TCPSender, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &TCPSender{
+		listener: listener,
+		logger:   logger.With("module", "pendingtx-tcp"),
+		backlog:  make([]tcpMessage, 0, backlogSize),
+		clients:  make(map[*tcpClient]struct{}),
+	}
+	go s.acceptRoutine()
+	return s, nil
+}
+
+// Addr returns the address listened.
+func (s *TCPSender) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *TCPSender) SendPending(_ []byte, tx *PendingTx) error {
+	msg, err := marshalPending(tx)
+	if err != nil {
+		return err
+	}
+	s.publish(tx.Offset, msg)
+	return nil
+}
+
+func (s *TCPSender) SendRmPending(_ []byte, tx *RmPendingTx) error {
+	msg, err := marshalRmPending(tx)
+	if err != nil {
+		return err
+	}
+	s.publish(tx.Offset, msg)
+	return nil
+}
+
+// Close stops listening and disconnects the clients.
+func (s *TCPSender) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	for c := range s.clients {
+		s.dropClient(c)
+	}
+	return s.listener.Close()
+}
+
+func (s *TCPSender) publish(offset uint64, msg []byte) {
+	line := append(msg, '\n')
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.backlog) < cap(s.backlog) {
+		s.backlog = append(s.backlog, tcpMessage{offset: offset, line: line})
+	} else if len(s.backlog) > 0 {
+		s.backlog[s.head] = tcpMessage{offset: offset, line: line}
+		s.head = (s.head + 1) % len(s.backlog)
+	}
+	s.lastOffset = offset
+
+	for c := range s.clients {
+		select {
+		case c.out <- line:
+		default:
+			s.logger.Error("pendingtx client too slow, disconnecting", "client", c.conn.RemoteAddr())
+			s.dropClient(c)
+		}
+	}
+}
+
+// dropClient must be called with mtx held, closing the connection unblocks the writes.
+func (s *TCPSender) dropClient(c *tcpClient) {
+	delete(s.clients, c)
+	close(c.out)
+	c.conn.Close()
+}
+
+func (s *TCPSender) acceptRoutine() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mtx.Lock()
+			closed := s.closed
+			s.mtx.Unlock()
+			if !closed {
+				s.logger.Error("failed to accept pendingtx client", "error", err)
+			}
+			return
+		}
+		go s.serve(conn)
+	}
+}
+
+func (s *TCPSender) serve(conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(tcpHandshakeTimeout))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return
+	}
+	from, resume, err := parseSubscription(line)
+	if err != nil {
+		fmt.Fprintf(conn, "-ERR %s\n", err)
+		conn.Close()
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	c := &tcpClient{conn: conn, out: make(chan []byte, tcpClientBuffer)}
+	s.mtx.Lock()
+	if s.closed {
+		s.mtx.Unlock()
+		conn.Close()
+		return
+	}
+	var replay [][]byte
+	if resume {
+		// an offset above the last one was given by a previous run without offset file
+		restarted := from > s.lastOffset
+		for i := range s.backlog {
+			m := s.backlog[(s.head+i)%len(s.backlog)]
+			if restarted || m.offset > from {
+				replay = append(replay, m.line)
+			}
+		}
+	}
+	s.clients[c] = struct{}{}
+	s.mtx.Unlock()
+
+	s.logger.Debug("pendingtx client subscribed", "client", conn.RemoteAddr(), "replay", len(replay))
+	defer func() {
+		s.mtx.Lock()
+		if _, ok := s.clients[c]; ok {
+			s.dropClient(c)
+		}
+		s.mtx.Unlock()
+		conn.Close()
+	}()
+
+	w := bufio.NewWriter(conn)
+	if _, err = w.WriteString("+OK\n"); err != nil {
+		return
+	}
+	for _, line := range replay {
+		if _, err = w.Write(line); err != nil {
+			return
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	for line := range c.out {
+		if _, err = w.Write(line); err != nil {
+			return
+		}
+		if len(c.out) == 0 {
+			if err = w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseSubscription parses "SUB" or "SUB <offset>".
+func parseSubscription(line string) (from uint64, resume bool, err error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "SUB") || len(fields) > 2 {
+		return 0, false, fmt.Errorf("expected SUB [offset]")
+	}
+	if len(fields) == 1 {
+		return 0, false, nil
+	}
+	from, err = strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid offset %q", fields[1])
+	}
+	return from, true, nil
+}
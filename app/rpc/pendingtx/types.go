@@ -3,8 +3,60 @@ package pendingtx
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+// Topics of the messages pushed to the tcp, file and webhook senders, the kafka messages keep the
+// kafka topic.
+const (
+	TopicPending   = "pending"
+	TopicRmPending = "rm_pending"
 )
 
+// AddReason is the reason why a tx is pushed as pending.
+type AddReason int
+
+const (
+	// AddReasonNew is a tx entering the mempool, either received or promoted from the pending pool
+	// once its nonce gap is filled.
+	AddReasonNew AddReason = iota
+)
+
+func (r AddReason) String() string {
+	switch r {
+	case AddReasonNew:
+		return "new"
+	default:
+		return "unknown"
+	}
+}
+
+// RmReason is the reason why a pending tx is removed from the mempool.
+type RmReason int
+
+const (
+	// RmReasonRecheck is a tx invalidated by the recheck after a block.
+	RmReasonRecheck = RmReason(tmtypes.Recheck)
+	// RmReasonMinGasPrice is a tx evicted by a tx with a higher gas price when the mempool is full.
+	RmReasonMinGasPrice = RmReason(tmtypes.MinGasPrice)
+	// RmReasonConfirmed is a tx included in a block.
+	RmReasonConfirmed = RmReason(tmtypes.Confirmed)
+)
+
+func (r RmReason) String() string {
+	switch r {
+	case RmReasonRecheck:
+		return "recheck"
+	case RmReasonMinGasPrice:
+		return "min_gas_price"
+	case RmReasonConfirmed:
+		return "confirmed"
+	default:
+		return "unknown"
+	}
+}
+
 type PendingMsg struct {
 	Topic  string      `json:"topic"`
 	Source interface{} `json:"source"`
@@ -20,6 +72,9 @@ type PendingTx struct {
 	Nonce    hexutil.Uint64  `json:"nonce"`
 	To       *common.Address `json:"to"`
 	Value    *hexutil.Big    `json:"value"`
+	Reason   AddReason       `json:"reason"`
+	// Offset orders the pending and rm pending txs pushed by the watcher
+	Offset uint64 `json:"offset"`
 }
 
 type RmPendingMsg struct {
@@ -29,9 +84,10 @@ type RmPendingMsg struct {
 }
 
 type RmPendingTx struct {
-	From   string `json:"from"`
-	Hash   string `json:"hash"`
-	Nonce  string `json:"nonce"`
-	Delete bool   `json:"delete"`
-	Reason int    `json:"reason"`
+	From   string   `json:"from"`
+	Hash   string   `json:"hash"`
+	Nonce  string   `json:"nonce"`
+	Delete bool     `json:"delete"`
+	Reason RmReason `json:"reason"`
+	Offset uint64   `json:"offset"`
 }
@@ -20,21 +20,28 @@ type Watcher struct {
 	events    *rpcfilters.EventSystem
 	logger    log.Logger
 
-	sender Sender
+	sender  Sender
+	offsets *OffsetStore
 }
 
+// Sender pushes the pending txs and the txs removed from the mempool, in the order of their offsets.
 type Sender interface {
 	SendPending(hash []byte, tx *PendingTx) error
 	SendRmPending(hash []byte, tx *RmPendingTx) error
 }
 
-func NewWatcher(clientCtx context.CLIContext, log log.Logger, sender Sender) *Watcher {
+// NewWatcher creates a watcher pushing the txs to sender, the offsets restart from 1 if offsets is nil.
+func NewWatcher(clientCtx context.CLIContext, log log.Logger, sender Sender, offsets *OffsetStore) *Watcher {
+	if offsets == nil {
+		offsets, _ = NewOffsetStore("")
+	}
 	return &Watcher{
 		clientCtx: clientCtx,
 		events:    rpcfilters.NewEventSystem(clientCtx.Client),
 		logger:    log.With("module", "pendingtx-watcher"),
 
-		sender: sender,
+		sender:  sender,
+		offsets: offsets,
 	}
 }
 
@@ -84,6 +91,11 @@ func (w *Watcher) Start() {
 					input = string(b)
 				}
 
+				offset, err := w.offsets.Next()
+				if err != nil {
+					w.logger.Error("failed to assign offset", "hash", txHash.String(), "error", err)
+					continue
+				}
 				pendingTx := &PendingTx{
 					From:     tx.GetFrom(),
 					To:       to,
@@ -93,15 +105,15 @@ func (w *Watcher) Start() {
 					Gas:      hexutil.Uint64(tx.GetGas()),
 					GasPrice: (*hexutil.Big)(tx.GetGasPrice()),
 					Input:    input,
+					Reason:   AddReasonNew,
+					Offset:   offset,
 				}
 
-				go func() {
-					w.logger.Debug("push pending tx to MQ", "txHash=", pendingTx.Hash.String())
-					err = w.sender.SendPending(pendingTx.Hash.Bytes(), pendingTx)
-					if err != nil {
-						w.logger.Error("failed to send pending tx", "hash", pendingTx.Hash.String(), "error", err)
-					}
-				}()
+				// sent in order, the senders queue the txs themselves
+				w.logger.Debug("push pending tx to MQ", "txHash=", pendingTx.Hash.String())
+				if err = w.sender.SendPending(pendingTx.Hash.Bytes(), pendingTx); err != nil {
+					w.logger.Error("failed to send pending tx", "hash", pendingTx.Hash.String(), "error", err)
+				}
 			case re := <-rmPendingdCh:
 				data, ok := re.Data.(tmtypes.EventDataRmPendingTx)
 				if !ok {
@@ -109,19 +121,23 @@ func (w *Watcher) Start() {
 					continue
 				}
 				txHash := common.BytesToHash(data.Hash).String()
-				go func() {
-					w.logger.Debug("push rm pending tx to MQ", "txHash=", txHash)
-					err = w.sender.SendRmPending(data.Hash, &RmPendingTx{
-						From:   data.From,
-						Hash:   txHash,
-						Nonce:  hexutil.Uint64(data.Nonce).String(),
-						Delete: true,
-						Reason: int(data.Reason),
-					})
-					if err != nil {
-						w.logger.Error("failed to send rm pending tx", "hash", txHash, "error", err)
-					}
-				}()
+				offset, err := w.offsets.Next()
+				if err != nil {
+					w.logger.Error("failed to assign offset", "hash", txHash, "error", err)
+					continue
+				}
+				w.logger.Debug("push rm pending tx to MQ", "txHash=", txHash)
+				err = w.sender.SendRmPending(data.Hash, &RmPendingTx{
+					From:   data.From,
+					Hash:   txHash,
+					Nonce:  hexutil.Uint64(data.Nonce).String(),
+					Delete: true,
+					Reason: RmReason(data.Reason),
+					Offset: offset,
+				})
+				if err != nil {
+					w.logger.Error("failed to send rm pending tx", "hash", txHash, "error", err)
+				}
 			}
 		}
 	}(pendingSub.Event(), rmPendingSub.Event())
@@ -0,0 +1,152 @@
+package pendingtx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const (
+	webhookQueueSize  = 10000
+	webhookTimeout    = 10 * time.Second
+	webhookMinBackoff = 500 * time.Millisecond
+	webhookMaxBackoff = 30 * time.Second
+)
+
+type webhookMessage struct {
+	offset uint64
+	body   []byte
+}
+
+// WebhookSender posts the txs as JSON to a url, one request per tx and in order. A failed request
+// is retried with an exponential backoff, up to maxRetries times or forever if maxRetries is 0,
+// except for the client errors other than 429 which are not retried.
+type WebhookSender struct {
+	url        string
+	maxRetries int
+	client     *http.Client
+	logger     log.Logger
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	queue chan webhookMessage
+	quit  chan struct{}
+	done  chan struct{}
+}
+
+func NewWebhookSender(url string, maxRetries int, logger log.Logger) *WebhookSender {
+	w := &WebhookSender{
+		url:        url,
+		maxRetries: maxRetries,
+		client:     &http.Client{Timeout: webhookTimeout},
+		logger:     logger.With("module", "pendingtx-webhook"),
+		minBackoff: webhookMinBackoff,
+		maxBackoff: webhookMaxBackoff,
+		queue:      make(chan webhookMessage, webhookQueueSize),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go w.postRoutine()
+	return w
+}
+
+func (w *WebhookSender) SendPending(_ []byte, tx *PendingTx) error {
+	msg, err := marshalPending(tx)
+	if err != nil {
+		return err
+	}
+	return w.enqueue(tx.Offset, msg)
+}
+
+func (w *WebhookSender) SendRmPending(_ []byte, tx *RmPendingTx) error {
+	msg, err := marshalRmPending(tx)
+	if err != nil {
+		return err
+	}
+	return w.enqueue(tx.Offset, msg)
+}
+
+// Close stops posting, the txs queued are dropped.
+func (w *WebhookSender) Close() error {
+	close(w.quit)
+	<-w.done
+	return nil
+}
+
+func (w *WebhookSender) enqueue(offset uint64, body []byte) error {
+	select {
+	case w.queue <- webhookMessage{offset: offset, body: body}:
+		return nil
+	default:
+		return fmt.Errorf("webhook queue is full, dropped offset %d", offset)
+	}
+}
+
+func (w *WebhookSender) postRoutine() {
+	defer close(w.done)
+	for {
+		select {
+		case msg := <-w.queue:
+			w.postWithRetry(msg)
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+func (w *WebhookSender) postWithRetry(msg webhookMessage) {
+	backoff := w.minBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(msg)
+		if err == nil {
+			return
+		}
+		if !retry || (w.maxRetries > 0 && attempt >= w.maxRetries) {
+			w.logger.Error("failed to post pending tx", "offset", msg.offset, "attempts", attempt+1, "error", err)
+			return
+		}
+		w.logger.Debug("retry posting pending tx", "offset", msg.offset, "backoff", backoff, "error", err)
+		select {
+		case <-time.After(backoff):
+		case <-w.quit:
+			return
+		}
+		if backoff *= 2; backoff > w.maxBackoff {
+			backoff = w.maxBackoff
+		}
+	}
+}
+
+// post returns whether a failed request can be retried.
+func (w *WebhookSender) post(msg webhookMessage) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(msg.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Pendingtx-Offset", strconv.FormatUint(msg.offset, 10))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	// drain the body to reuse the connection
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook responded %s", resp.Status)
+	}
+}
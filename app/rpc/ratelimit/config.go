@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ParseNamespaceLimits parses limits of the form "debug:1:100,eth:50:200", that is
+// namespace:rate:burst separated by commas.
+func ParseNamespaceLimits(s string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	for _, item := range splitList(s) {
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid namespace limit %q, expected namespace:rate:burst", item)
+		}
+		if !knownNamespaces[parts[0]] {
+			return nil, fmt.Errorf("unknown namespace %q", parts[0])
+		}
+		rate, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate in namespace limit %q", item)
+		}
+		burst, err := strconv.Atoi(parts[2])
+		if err != nil || burst < 0 {
+			return nil, fmt.Errorf("invalid burst in namespace limit %q", item)
+		}
+		limits[parts[0]] = Limit{Rate: rate, Burst: burst}
+	}
+	return limits, nil
+}
+
+// ParseMethodCosts parses costs of the form "eth_getLogs:20,eth_call:5".
+func ParseMethodCosts(s string) (map[string]int, error) {
+	costs := make(map[string]int)
+	for _, item := range splitList(s) {
+		parts := strings.Split(item, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid method cost %q, expected method:cost", item)
+		}
+		cost, err := strconv.Atoi(parts[1])
+		if err != nil || cost < 0 {
+			return nil, fmt.Errorf("invalid cost in method cost %q", item)
+		}
+		costs[parts[0]] = cost
+	}
+	return costs, nil
+}
+
+// ParseTrustedProxies parses a list of IP addresses and CIDR ranges separated by commas.
+func ParseTrustedProxies(s string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, item := range splitList(s) {
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", item)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %s", item, err)
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+// ParseAPIKeys parses a list of API keys separated by commas.
+func ParseAPIKeys(s string) map[string]bool {
+	apiKeys := make(map[string]bool)
+	for _, item := range splitList(s) {
+		apiKeys[item] = true
+	}
+	return apiKeys
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
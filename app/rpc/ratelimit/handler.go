@@ -0,0 +1,185 @@
+package ratelimit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ErrCodeLimitExceeded is the JSON-RPC error code of the rejected requests, see EIP-1474
+	ErrCodeLimitExceeded = -32005
+
+	// maxRequestContentLength is the request size limit of the go-ethereum rpc server, larger
+	// requests are passed through and rejected by the server
+	maxRequestContentLength = 1024 * 1024 * 5
+)
+
+type jsonrpcRequest struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+}
+
+type jsonrpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+type jsonrpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *jsonrpcError   `json:"error"`
+}
+
+// RetryData is the data of the JSON-RPC error of a rejected request.
+type RetryData struct {
+	Reason string `json:"reason"`
+	// RetryAfter is the number of seconds to wait before retrying
+	RetryAfter int64 `json:"retryAfter"`
+}
+
+// Handler returns a handler limiting the JSON-RPC requests to next, the clients are identified by
+// their API key, or by their IP address.
+func (l *Limiter) Handler(next http.Handler, trustedProxies []*net.IPNet, apiKeys map[string]bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.ContentLength > maxRequestContentLength {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestContentLength))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		requests, batch, ok := parseRequests(body)
+		if !ok {
+			// let the server answer with the parse error
+			next.ServeHTTP(w, r)
+			return
+		}
+		methods := make([]string, len(requests))
+		for i, req := range requests {
+			methods[i] = req.Method
+		}
+
+		rejection := l.Allow(ClientKey(r, trustedProxies, apiKeys), methods)
+		if rejection == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		writeRejection(w, rejection, requests, batch)
+	})
+}
+
+func parseRequests(body []byte) ([]jsonrpcRequest, bool, bool) {
+	body = bytes.TrimLeft(body, " \t\r\n")
+	if len(body) > 0 && body[0] == '[' {
+		var requests []jsonrpcRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, true, false
+		}
+		return requests, true, true
+	}
+	var req jsonrpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, false, false
+	}
+	return []jsonrpcRequest{req}, false, true
+}
+
+func writeRejection(w http.ResponseWriter, rejection *Rejection, requests []jsonrpcRequest, batch bool) {
+	retryAfter := int64(math.Ceil(rejection.RetryAfter.Seconds()))
+	responses := make([]jsonrpcResponse, len(requests))
+	for i, req := range requests {
+		id := req.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		responses[i] = jsonrpcResponse{
+			Version: "2.0",
+			ID:      id,
+			Error: &jsonrpcError{
+				Code:    ErrCodeLimitExceeded,
+				Message: rejection.Error(),
+				Data:    RetryData{Reason: rejection.Reason, RetryAfter: retryAfter},
+			},
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	}
+	w.WriteHeader(http.StatusTooManyRequests)
+	if batch {
+		_ = json.NewEncoder(w).Encode(responses)
+	} else {
+		_ = json.NewEncoder(w).Encode(responses[0])
+	}
+}
+
+// ClientKey returns the key the client of the request is accounted by: its API key if the
+// Authorization header holds one of the configured keys, otherwise its IP address, so that
+// clients can't dodge their limits with made-up headers. The X-Forwarded-For header is followed
+// from the loopback addresses, such as the websocket server, and the trusted proxies.
+func ClientKey(r *http.Request, trustedProxies []*net.IPNet, apiKeys map[string]bool) string {
+	if apiKey := authorizationKey(r); apiKey != "" && apiKeys[apiKey] {
+		hash := sha256.Sum256([]byte(apiKey))
+		return "auth:" + hex.EncodeToString(hash[:16])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	// the last hops are added by the closest proxies, the first untrusted one is the client
+	for len(hops) > 0 && isTrusted(host, trustedProxies) {
+		host, hops = hops[len(hops)-1], hops[:len(hops)-1]
+	}
+	return "ip:" + host
+}
+
+// authorizationKey returns the credentials of the Authorization header, with or without the
+// Bearer scheme.
+func authorizationKey(r *http.Request) string {
+	const bearerScheme = "Bearer "
+	auth := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(auth) > len(bearerScheme) && strings.EqualFold(auth[:len(bearerScheme)], bearerScheme) {
+		auth = strings.TrimSpace(auth[len(bearerScheme):])
+	}
+	return auth
+}
+
+func isTrusted(host string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,301 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+	"golang.org/x/time/rate"
+
+	"github.com/okex/exchain/app/rpc/monitor"
+)
+
+const (
+	ResultAllowed       = "allowed"
+	ResultRateLimited   = "rate_limited"
+	ResultQuotaExceeded = "quota_exceeded"
+
+	// clients idle for this long are forgotten, their daily quota usage is kept until the day rolls over
+	clientIdleTimeout = 10 * time.Minute
+	pruneInterval     = time.Minute
+	// DefaultMaxClients is the number of clients tracked at most, the least recently seen one is
+	// forgotten to make room for a new one
+	DefaultMaxClients = 100000
+)
+
+// defaultMethodCosts are the costs of the methods heavier than a plain state read.
+var defaultMethodCosts = map[string]int{
+	"eth_getLogs":               20,
+	"eth_getFilterLogs":         20,
+	"eth_getLogsPage":           20,
+	"eth_call":                  5,
+	"eth_estimateGas":           5,
+	"eth_getProof":              5,
+	"eth_feeHistory":            5,
+	"eth_getBlockByNumber":      2,
+	"eth_getBlockByHash":        2,
+	"eth_getTransactionLogs":    2,
+	"eth_getTransactionReceipt": 2,
+	"eth_sendRawTransaction":    2,
+	"debug_traceTransaction":    50,
+	"debug_traceCall":           50,
+	"debug_traceBlockByNumber":  100,
+	"debug_traceBlockByHash":    100,
+}
+
+// knownNamespaces are the namespaces served by the rpc server, the others are accounted as
+// otherNamespace so that clients can't blow up the buckets or the metrics cardinality.
+var knownNamespaces = map[string]bool{
+	"eth": true, "net": true, "web3": true, "personal": true, "txpool": true, "debug": true,
+}
+
+const otherNamespace = "other"
+
+// Limit is the token bucket of a client in a namespace: Rate cost units per second, up to Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Config is the configuration of the per-client rate limiter.
+type Config struct {
+	// Default is the limit of a client in the namespaces without a limit of their own
+	Default Limit
+	// Namespaces holds the limits of specific namespaces, such as debug
+	Namespaces map[string]Limit
+	// MethodCosts overrides the cost of methods, a method not listed costs 1
+	MethodCosts map[string]int
+	// DailyQuota is the total cost a client may spend per UTC day, 0 for no quota
+	DailyQuota int64
+	// MaxClients is the number of clients tracked at most, 0 for DefaultMaxClients
+	MaxClients int
+}
+
+// Rejection is returned when a request is rejected by the limiter.
+type Rejection struct {
+	Namespace  string
+	Reason     string // ResultRateLimited or ResultQuotaExceeded
+	RetryAfter time.Duration
+}
+
+func (r *Rejection) Error() string {
+	if r.Reason == ResultQuotaExceeded {
+		return "daily request quota exceeded"
+	}
+	return fmt.Sprintf("request rate limit exceeded for namespace %s", r.Namespace)
+}
+
+type client struct {
+	buckets  map[string]*rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter limits the requests of each client with a token bucket per namespace, where every
+// method has a cost, and a daily quota over all the namespaces.
+type Limiter struct {
+	cfg     Config
+	metrics *monitor.RateLimitMetrics
+	now     func() time.Time
+
+	mtx sync.Mutex
+	// clients holds the *client of every key, from the least to the most recently seen
+	clients   *simplelru.LRU
+	lastPrune time.Time
+	// used holds the quota used by every client in the UTC day usedDay, apart from the clients
+	// so that forgetting an idle client doesn't give it a new quota, from the least to the most
+	// recently charged one and bounded by MaxClients as well
+	usedDay int64
+	used    *simplelru.LRU
+}
+
+// NewLimiter returns a Limiter, metrics may be nil.
+func NewLimiter(cfg Config, metrics *monitor.RateLimitMetrics) *Limiter {
+	costs := make(map[string]int, len(defaultMethodCosts)+len(cfg.MethodCosts))
+	for method, cost := range defaultMethodCosts {
+		costs[method] = cost
+	}
+	for method, cost := range cfg.MethodCosts {
+		costs[method] = cost
+	}
+	cfg.MethodCosts = costs
+	if cfg.MaxClients <= 0 {
+		cfg.MaxClients = DefaultMaxClients
+	}
+	clients, err := simplelru.NewLRU(cfg.MaxClients, nil)
+	if err != nil {
+		panic(err)
+	}
+	used, err := simplelru.NewLRU(cfg.MaxClients, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Limiter{
+		cfg:     cfg,
+		metrics: metrics,
+		now:     time.Now,
+		clients: clients,
+		used:    used,
+	}
+}
+
+// Cost returns the cost of a method.
+func (l *Limiter) Cost(method string) int {
+	if cost, ok := l.cfg.MethodCosts[method]; ok {
+		return cost
+	}
+	return 1
+}
+
+// Allow charges the client for the methods, a batch is either allowed or rejected as a whole.
+func (l *Limiter) Allow(clientKey string, methods []string) *Rejection {
+	if len(methods) == 0 {
+		return nil
+	}
+	costs := make(map[string]int)
+	var namespaces []string
+	var total int64
+	for _, method := range methods {
+		namespace := namespaceOf(method)
+		if _, ok := costs[namespace]; !ok {
+			namespaces = append(namespaces, namespace)
+		}
+		cost := l.Cost(method)
+		costs[namespace] += cost
+		total += int64(cost)
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	c := l.getClient(clientKey, now)
+
+	day := now.Unix() / 86400
+	if l.usedDay != day {
+		l.usedDay = day
+		l.used.Purge()
+	}
+	var used int64
+	if value, ok := l.used.Get(clientKey); ok {
+		used = value.(int64)
+	}
+	if l.cfg.DailyQuota > 0 && used+total > l.cfg.DailyQuota {
+		rejection := &Rejection{
+			Namespace:  namespaces[0],
+			Reason:     ResultQuotaExceeded,
+			RetryAfter: time.Unix((day+1)*86400, 0).Sub(now),
+		}
+		l.record(namespaces, costs, rejection)
+		return rejection
+	}
+
+	// reserve the cost in every namespace, and give it all back if one has to wait
+	reservations := make([]*rate.Reservation, 0, len(namespaces))
+	var rejection *Rejection
+	for _, namespace := range namespaces {
+		bucket := c.bucket(namespace, l.limit(namespace))
+		r := bucket.ReserveN(now, costs[namespace])
+		if !r.OK() {
+			// the cost is above the burst, the request can never be served
+			rejection = &Rejection{Namespace: namespace, Reason: ResultRateLimited}
+			break
+		}
+		reservations = append(reservations, r)
+		if delay := r.DelayFrom(now); delay > 0 {
+			rejection = &Rejection{Namespace: namespace, Reason: ResultRateLimited, RetryAfter: delay}
+			break
+		}
+	}
+	if rejection != nil {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+		l.record(namespaces, costs, rejection)
+		return rejection
+	}
+
+	if l.cfg.DailyQuota > 0 {
+		l.used.Add(clientKey, used+total)
+	}
+	l.record(namespaces, costs, nil)
+	return nil
+}
+
+func (l *Limiter) limit(namespace string) Limit {
+	if limit, ok := l.cfg.Namespaces[namespace]; ok {
+		return limit
+	}
+	return l.cfg.Default
+}
+
+func (l *Limiter) getClient(key string, now time.Time) *client {
+	var c *client
+	if value, ok := l.clients.Get(key); ok {
+		c = value.(*client)
+	} else {
+		c = &client{buckets: make(map[string]*rate.Limiter)}
+		l.clients.Add(key, c)
+		if l.metrics != nil {
+			l.metrics.Clients.Set(float64(l.clients.Len()))
+		}
+	}
+	c.lastSeen = now
+	return c
+}
+
+func (c *client) bucket(namespace string, limit Limit) *rate.Limiter {
+	bucket, ok := c.buckets[namespace]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+		c.buckets[namespace] = bucket
+	}
+	return bucket
+}
+
+// prune forgets the idle clients, their buckets are full again anyway. The clients are ordered
+// by the time they were last seen, so it stops at the first one which is not idle.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < pruneInterval {
+		return
+	}
+	l.lastPrune = now
+	for {
+		_, value, ok := l.clients.GetOldest()
+		if !ok || now.Sub(value.(*client).lastSeen) < clientIdleTimeout {
+			break
+		}
+		l.clients.RemoveOldest()
+	}
+	if l.metrics != nil {
+		l.metrics.Clients.Set(float64(l.clients.Len()))
+	}
+}
+
+func (l *Limiter) record(namespaces []string, costs map[string]int, rejection *Rejection) {
+	if l.metrics == nil {
+		return
+	}
+	for _, namespace := range namespaces {
+		result := ResultAllowed
+		if rejection != nil {
+			result = rejection.Reason
+		}
+		l.metrics.Requests.With(monitor.RateLimitNamespaceLabel, namespace,
+			monitor.RateLimitResultLabel, result).Add(1)
+		if rejection == nil {
+			l.metrics.Cost.With(monitor.RateLimitNamespaceLabel, namespace).Add(float64(costs[namespace]))
+		}
+	}
+}
+
+// namespaceOf returns the namespace of a method, eth for eth_call.
+func namespaceOf(method string) string {
+	if i := strings.IndexByte(method, '_'); i > 0 && knownNamespaces[method[:i]] {
+		return method[:i]
+	}
+	return otherNamespace
+}
@@ -0,0 +1,255 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(cfg Config) (*Limiter, *time.Time) {
+	l := NewLimiter(cfg, nil)
+	now := time.Date(2022, 6, 1, 23, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiterCost(t *testing.T) {
+	l, now := newTestLimiter(Config{
+		Default:     Limit{Rate: 10, Burst: 20},
+		Namespaces:  map[string]Limit{"debug": {Rate: 1, Burst: 100}},
+		MethodCosts: map[string]int{"eth_call": 8},
+	})
+	require.Equal(t, 20, l.Cost("eth_getLogs"))
+	require.Equal(t, 8, l.Cost("eth_call"))
+	require.Equal(t, 1, l.Cost("eth_blockNumber"))
+
+	// the bucket holds one eth_getLogs, or twenty eth_blockNumber
+	require.Nil(t, l.Allow("a", []string{"eth_getLogs"}))
+	rejection := l.Allow("a", []string{"eth_blockNumber"})
+	require.NotNil(t, rejection)
+	require.Equal(t, ResultRateLimited, rejection.Reason)
+	require.Equal(t, "eth", rejection.Namespace)
+	require.Equal(t, 100*time.Millisecond, rejection.RetryAfter)
+
+	// other clients and namespaces have their own buckets
+	require.Nil(t, l.Allow("b", []string{"eth_getLogs"}))
+	require.Nil(t, l.Allow("a", []string{"debug_traceTransaction", "net_version"}))
+
+	*now = now.Add(time.Second)
+	for i := 0; i < 10; i++ {
+		require.Nil(t, l.Allow("a", []string{"eth_blockNumber"}))
+	}
+	require.NotNil(t, l.Allow("a", []string{"eth_blockNumber"}))
+
+	// a cost above the burst is never allowed
+	rejection = l.Allow("c", []string{"debug_traceBlockByNumber", "debug_traceTransaction"})
+	require.NotNil(t, rejection)
+	require.Equal(t, time.Duration(0), rejection.RetryAfter)
+}
+
+func TestLimiterBatch(t *testing.T) {
+	l, _ := newTestLimiter(Config{Default: Limit{Rate: 1, Burst: 10}})
+
+	// a batch is charged as a whole, and nothing is charged when it is rejected
+	require.NotNil(t, l.Allow("a", []string{"eth_blockNumber", "net_version", "eth_getLogs"}))
+	require.Nil(t, l.Allow("a", []string{"eth_call", "eth_call", "net_version"}))
+	require.Nil(t, l.Allow("a", []string{"net_version"}))
+
+	// unknown namespaces share a single bucket
+	require.Equal(t, otherNamespace, namespaceOf("foo_bar"))
+	require.Equal(t, otherNamespace, namespaceOf("nonamespace"))
+	require.Nil(t, l.Allow("a", make([]string, 0)))
+}
+
+func TestLimiterDailyQuota(t *testing.T) {
+	l, now := newTestLimiter(Config{Default: Limit{Rate: 1000, Burst: 1000}, DailyQuota: 30})
+
+	require.Nil(t, l.Allow("a", []string{"eth_getLogs"}))
+	require.Nil(t, l.Allow("a", []string{"eth_call", "eth_call"}))
+	rejection := l.Allow("a", []string{"eth_blockNumber"})
+	require.NotNil(t, rejection)
+	require.Equal(t, ResultQuotaExceeded, rejection.Reason)
+	require.Equal(t, time.Hour, rejection.RetryAfter)
+
+	// the client is kept until it is idle
+	l.prune(now.Add(clientIdleTimeout - time.Second))
+	require.Equal(t, 1, l.clients.Len())
+
+	// the quota usage outlives the idle client
+	*now = now.Add(clientIdleTimeout + pruneInterval)
+	l.prune(*now)
+	require.Equal(t, 0, l.clients.Len())
+	rejection = l.Allow("a", []string{"eth_blockNumber"})
+	require.NotNil(t, rejection)
+	require.Equal(t, ResultQuotaExceeded, rejection.Reason)
+	require.Equal(t, time.Hour-clientIdleTimeout-pruneInterval, rejection.RetryAfter)
+
+	// the quota is reset the next day
+	*now = now.Add(time.Hour)
+	require.Nil(t, l.Allow("a", []string{"eth_getLogs"}))
+
+	*now = now.Add(24 * time.Hour)
+	l.prune(*now)
+	require.Equal(t, 0, l.clients.Len())
+}
+
+func TestLimiterPrune(t *testing.T) {
+	l, now := newTestLimiter(Config{Default: Limit{Rate: 1000, Burst: 1000}, DailyQuota: 1000})
+
+	require.Nil(t, l.Allow("a", []string{"eth_call"}))
+	*now = now.Add(clientIdleTimeout / 2)
+	require.Nil(t, l.Allow("b", []string{"eth_call"}))
+
+	// only the idle clients are forgotten, even with a daily quota
+	*now = now.Add(clientIdleTimeout / 2)
+	l.prune(*now)
+	require.False(t, l.clients.Contains("a"))
+	require.True(t, l.clients.Contains("b"))
+
+	// the prune runs once per interval, b is idle from the 15th minute
+	*now = now.Add(clientIdleTimeout/2 - 30*time.Second)
+	require.Nil(t, l.Allow("c", []string{"eth_call"}))
+	l.prune(now.Add(40 * time.Second))
+	require.True(t, l.clients.Contains("b"))
+	l.prune(now.Add(pruneInterval))
+	require.False(t, l.clients.Contains("b"))
+	require.True(t, l.clients.Contains("c"))
+}
+
+func TestLimiterMaxClients(t *testing.T) {
+	l, _ := newTestLimiter(Config{Default: Limit{Rate: 1000, Burst: 1000}, MaxClients: 2})
+
+	require.Nil(t, l.Allow("a", []string{"eth_call"}))
+	require.Nil(t, l.Allow("b", []string{"eth_call"}))
+	require.Nil(t, l.Allow("a", []string{"eth_call"}))
+	// the least recently seen client makes room for the new one
+	require.Nil(t, l.Allow("c", []string{"eth_call"}))
+	require.Equal(t, 2, l.clients.Len())
+	require.True(t, l.clients.Contains("a"))
+	require.False(t, l.clients.Contains("b"))
+	require.True(t, l.clients.Contains("c"))
+
+	// the evicted client keeps its quota usage
+	l, _ = newTestLimiter(Config{Default: Limit{Rate: 1000, Burst: 1000}, DailyQuota: 5, MaxClients: 1})
+	require.Nil(t, l.Allow("a", []string{"eth_call"}))
+	require.NotNil(t, l.Allow("b", []string{"debug_traceCall"}))
+	require.False(t, l.clients.Contains("a"))
+	rejection := l.Allow("a", []string{"eth_call"})
+	require.NotNil(t, rejection)
+	require.Equal(t, ResultQuotaExceeded, rejection.Reason)
+
+	// the quota usage is bounded by MaxClients as well, the least recently charged one is forgotten
+	require.Nil(t, l.Allow("c", []string{"eth_call"}))
+	require.Equal(t, 1, l.used.Len())
+	require.False(t, l.used.Contains("a"))
+	require.Nil(t, l.Allow("a", []string{"eth_call"}))
+
+	l = NewLimiter(Config{}, nil)
+	require.Equal(t, DefaultMaxClients, l.cfg.MaxClients)
+}
+
+func TestHandler(t *testing.T) {
+	l, _ := newTestLimiter(Config{Default: Limit{Rate: 1, Burst: 20}})
+	var served int
+	handler := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+		w.WriteHeader(http.StatusOK)
+	}), nil, nil)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.RemoteAddr = "1.2.3.4:5678"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, served)
+
+	w = post(`{"jsonrpc":"2.0","id":2,"method":"eth_getLogs","params":[]}`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "20", w.Header().Get("Retry-After"))
+	var res jsonrpcResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, json.RawMessage("2"), res.ID)
+	require.Equal(t, ErrCodeLimitExceeded, res.Error.Code)
+	require.Equal(t, map[string]interface{}{"reason": ResultRateLimited, "retryAfter": float64(20)}, res.Error.Data)
+
+	w = post(`[{"jsonrpc":"2.0","id":"a","method":"eth_call"},{"jsonrpc":"2.0","id":"b","method":"eth_call"}]`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	var batch []jsonrpcResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &batch))
+	require.Len(t, batch, 2)
+	require.Equal(t, json.RawMessage(`"b"`), batch[1].ID)
+
+	// invalid requests are left to the server
+	w = post(`{"jsonrpc":`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 2, served)
+}
+
+func TestClientKey(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.1")
+	require.NoError(t, err)
+
+	newRequest := func(remoteAddr string, forwardedFor ...string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = remoteAddr
+		for _, hop := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", hop)
+		}
+		return req
+	}
+
+	require.Equal(t, "ip:1.2.3.4", ClientKey(newRequest("1.2.3.4:80"), proxies, nil))
+	// untrusted peers can't forge their address
+	require.Equal(t, "ip:1.2.3.4", ClientKey(newRequest("1.2.3.4:80", "5.6.7.8"), proxies, nil))
+	// the hops of the trusted proxies are followed to the first untrusted one
+	require.Equal(t, "ip:5.6.7.8", ClientKey(newRequest("127.0.0.1:80", "5.6.7.8"), proxies, nil))
+	require.Equal(t, "ip:5.6.7.8",
+		ClientKey(newRequest("10.1.2.3:80", "9.9.9.9, 5.6.7.8", "192.168.1.1"), proxies, nil))
+	require.Equal(t, "ip:10.1.2.3", ClientKey(newRequest("127.0.0.1:80", "10.1.2.3"), nil, nil))
+
+	apiKeys := ParseAPIKeys("key1, key2")
+	require.Equal(t, map[string]bool{"key1": true, "key2": true}, apiKeys)
+	newAuthRequest := func(remoteAddr, auth string) *http.Request {
+		req := newRequest(remoteAddr)
+		req.Header.Set("Authorization", auth)
+		return req
+	}
+	// the clients with a configured API key are accounted by it wherever they come from
+	keyed := ClientKey(newAuthRequest("1.2.3.4:80", "Bearer key1"), nil, apiKeys)
+	require.True(t, strings.HasPrefix(keyed, "auth:"))
+	require.Equal(t, keyed, ClientKey(newAuthRequest("5.6.7.8:80", "key1"), nil, apiKeys))
+	require.Equal(t, keyed, ClientKey(newAuthRequest("5.6.7.8:80", "bearer key1"), nil, apiKeys))
+	require.NotEqual(t, keyed, ClientKey(newAuthRequest("1.2.3.4:80", "Bearer key2"), nil, apiKeys))
+	// an unknown Authorization value can't reset the limits of the client
+	require.Equal(t, "ip:1.2.3.4", ClientKey(newAuthRequest("1.2.3.4:80", "Bearer random"), nil, apiKeys))
+	require.Equal(t, "ip:1.2.3.4", ClientKey(newAuthRequest("1.2.3.4:80", "Bearer key1"), nil, nil))
+}
+
+func TestParseConfig(t *testing.T) {
+	limits, err := ParseNamespaceLimits("debug:0.5:100, eth:50:200")
+	require.NoError(t, err)
+	require.Equal(t, map[string]Limit{"debug": {0.5, 100}, "eth": {50, 200}}, limits)
+	_, err = ParseNamespaceLimits("debug:1")
+	require.Error(t, err)
+	_, err = ParseNamespaceLimits("foo:1:1")
+	require.Error(t, err)
+
+	costs, err := ParseMethodCosts("eth_getLogs:50,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"eth_getLogs": 50}, costs)
+	_, err = ParseMethodCosts("eth_getLogs:x")
+	require.Error(t, err)
+
+	_, err = ParseTrustedProxies("10.0.0.0/33")
+	require.Error(t, err)
+}
@@ -524,3 +524,6 @@ func (c MockClient) GetPendingNonce(address string) (*ctypes.ResultPendingNonce,
 		Nonce: nonce,
 	}, true
 }
+func (c MockClient) GetPendingPoolTxs() (*ctypes.ResultPendingTxs, error) {
+	return &ctypes.ResultPendingTxs{Txs: c.env.Mempool.GetPendingPoolTxsBytes()}, nil
+}
@@ -59,6 +59,7 @@ func ToTransaction(tx *evmtypes.MsgEthereumTx, from *common.Address) *watcher.Tr
 		R:        (*hexutil.Big)(tx.Data.R),
 		S:        (*hexutil.Big)(tx.Data.S),
 	}
+	rpcTx.SetTypedTxFields(tx)
 	return rpcTx
 }
 
@@ -332,6 +333,7 @@ func RawTxResultToEthReceipt(chainID *big.Int, tr *ctypes.ResultTx, realTx sdk.T
 		TransactionIndex: hexutil.Uint64(tr.Index),
 		From:             ethTx.GetFrom(),
 		To:               ethTx.To(),
+		Type:             hexutil.Uint64(ethTx.Data.Type),
 	}
 
 	rpcTx, err := watcher.NewTransaction(ethTx, common.BytesToHash(tr.Hash),
@@ -1,13 +1,16 @@
 package websockets
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	coretypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/evm/watcher"
+	ordertypes "github.com/okex/exchain/x/order/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -76,6 +79,28 @@ func (api *PubSubAPI) subscribe(conn *wsConn, params []interface{}) (rpc.ID, err
 	}
 }
 
+func (api *PubSubAPI) okcSubscribe(conn *wsConn, params []interface{}) (rpc.ID, error) {
+	method, ok := params[0].(string)
+	if !ok {
+		return "0", fmt.Errorf("invalid parameters")
+	}
+
+	switch method {
+	case "depthBook":
+		var product string
+		if len(params) > 1 {
+			product, _ = params[1].(string)
+		}
+		if product == "" {
+			return "0", fmt.Errorf("invalid parameters; product is required")
+		}
+		return api.subscribeDepthBook(conn, product)
+
+	default:
+		return "0", fmt.Errorf("unsupported method %s", method)
+	}
+}
+
 func (api *PubSubAPI) unsubscribe(id rpc.ID) bool {
 	api.filtersMu.Lock()
 	defer api.filtersMu.Unlock()
@@ -632,3 +657,112 @@ func (api *PubSubAPI) subscribeLatestBlockTime(conn *wsConn) (rpc.ID, error) {
 
 	return sub.ID(), nil
 }
+
+func (api *PubSubAPI) subscribeDepthBook(conn *wsConn, product string) (rpc.ID, error) {
+	sub, _, err := api.events.SubscribeNewHeads()
+	if err != nil {
+		return "", fmt.Errorf("error creating block filter: %s", err.Error())
+	}
+
+	unsubscribed := make(chan struct{})
+	api.filtersMu.Lock()
+	api.filters[sub.ID()] = &wsSubscription{
+		sub:          sub,
+		conn:         conn,
+		unsubscribed: unsubscribed,
+	}
+	api.filtersMu.Unlock()
+
+	go func(headersCh <-chan coretypes.ResultEvent, errCh <-chan error) {
+		for {
+			select {
+			case event := <-headersCh:
+				data, ok := event.Data.(tmtypes.EventDataNewBlockHeader)
+				if !ok {
+					api.logger.Error(fmt.Sprintf("invalid data type %T, expected EventDataNewBlockHeader", event.Data), "ID", sub.ID())
+					continue
+				}
+				result, err := depthBookResultFromEvents(product, data.Header.Height, data.ResultEndBlock.Events)
+				if err != nil {
+					api.logger.Error("failed to parse depth book update", "ID", sub.ID(), "height", data.Header.Height, "error", err)
+					continue
+				}
+
+				api.filtersMu.RLock()
+				if f, found := api.filters[sub.ID()]; found {
+					// write to ws conn
+					res := &SubscriptionNotification{
+						Jsonrpc: "2.0",
+						Method:  "okc_subscription",
+						Params: &SubscriptionResult{
+							Subscription: sub.ID(),
+							Result:       result,
+						},
+					}
+
+					err = f.conn.WriteJSON(res)
+					if err != nil {
+						api.logger.Error("failed to write depth book", "ID", sub.ID(), "height", result.Sequence, "error", err)
+					} else {
+						api.logger.Debug("successfully write depth book", "ID", sub.ID(), "height", result.Sequence)
+					}
+				}
+				api.filtersMu.RUnlock()
+
+				if err != nil {
+					api.unsubscribe(sub.ID())
+				}
+			case err := <-errCh:
+				if err != nil {
+					api.unsubscribe(sub.ID())
+					api.logger.Error("websocket recv error, close the conn", "ID", sub.ID(), "error", err)
+				}
+				return
+			case <-unsubscribed:
+				api.logger.Debug("DepthBook channel is closed", "ID", sub.ID())
+				return
+			}
+		}
+	}(sub.Event(), sub.Err())
+
+	return sub.ID(), nil
+}
+
+// depthBookResultFromEvents finds the depth book update of the product in the EndBlock events,
+// the result of a block without any update has no items
+func depthBookResultFromEvents(product string, height int64, events []abci.Event) (*DepthBookResult, error) {
+	result := &DepthBookResult{
+		Product:  product,
+		Sequence: height,
+		Items:    []ordertypes.DepthBookItem{},
+	}
+	for _, event := range events {
+		if event.Type != ordertypes.EventTypeDepthBook {
+			continue
+		}
+		var eventProduct string
+		var updateBytes []byte
+		for _, attr := range event.Attributes {
+			switch string(attr.Key) {
+			case ordertypes.AttributeKeyProduct:
+				eventProduct = string(attr.Value)
+			case ordertypes.AttributeKeyDepthBookUpdate:
+				updateBytes = attr.Value
+			}
+		}
+		if eventProduct != product {
+			continue
+		}
+
+		var update ordertypes.DepthBookUpdate
+		if err := json.Unmarshal(updateBytes, &update); err != nil {
+			return nil, err
+		}
+		if len(update.Items) > 0 {
+			result.Items = update.Items
+		}
+		result.Fills = update.Fills
+		break
+	}
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -114,8 +115,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.connPool <- struct{}{}
 	s.currentConnNum.Set(float64(len(s.connPool)))
 	go s.readLoop(&wsConn{
-		mux:  new(sync.Mutex),
-		conn: conn,
+		mux:    new(sync.Mutex),
+		conn:   conn,
+		header: forwardHeader(r),
 	})
 }
 
@@ -142,6 +144,8 @@ type wsConn struct {
 	conn     *websocket.Conn
 	mux      *sync.Mutex
 	subCount int
+	// header identifies the client to the rate limiter of the rest-server
+	header http.Header
 }
 
 func (w *wsConn) GetSubCount() int {
@@ -191,13 +195,13 @@ func (s *Server) readLoop(wsConn *wsConn) {
 			continue
 		}
 
-		// check if method == eth_subscribe or eth_unsubscribe
+		// check if method == eth_subscribe/okc_subscribe or eth_unsubscribe/okc_unsubscribe
 		method := msg["method"]
 		methodStr, ok := method.(string)
 		if !ok {
 			s.sendErrResponse(wsConn, "invalid request")
 		}
-		if methodStr == "eth_subscribe" {
+		if methodStr == "eth_subscribe" || methodStr == "okc_subscribe" {
 			if wsConn.GetSubCount() >= s.maxSubLimit {
 				s.sendErrResponse(wsConn,
 					fmt.Sprintf("subscription has reached the upper limit(%d)", s.maxSubLimit))
@@ -215,7 +219,12 @@ func (s *Server) readLoop(wsConn *wsConn) {
 				continue
 			}
 
-			id, err := s.api.subscribe(wsConn, params)
+			var id rpc.ID
+			if methodStr == "okc_subscribe" {
+				id, err = s.api.okcSubscribe(wsConn, params)
+			} else {
+				id, err = s.api.subscribe(wsConn, params)
+			}
 			if err != nil {
 				s.sendErrResponse(wsConn, err.Error())
 				continue
@@ -236,7 +245,7 @@ func (s *Server) readLoop(wsConn *wsConn) {
 			subIds[id] = struct{}{}
 			wsConn.AddSubCount(1)
 			continue
-		} else if methodStr == "eth_unsubscribe" {
+		} else if methodStr == "eth_unsubscribe" || methodStr == "okc_unsubscribe" {
 			ids, ok := msg["params"].([]interface{})
 			if len(ids) == 0 {
 				s.sendErrResponse(wsConn, "invalid parameters")
@@ -273,7 +282,7 @@ func (s *Server) readLoop(wsConn *wsConn) {
 		}
 
 		// otherwise, call the usual rpc server to respond
-		data, err := s.getRpcResponse(mb)
+		data, err := s.getRpcResponse(mb, wsConn.header)
 		if err != nil {
 			s.sendErrResponse(wsConn, err.Error())
 		} else {
@@ -283,11 +292,14 @@ func (s *Server) readLoop(wsConn *wsConn) {
 }
 
 // getRpcResponse connects to the rest-server over tcp, posts a JSON-RPC request, and return response
-func (s *Server) getRpcResponse(mb []byte) (interface{}, error) {
+func (s *Server) getRpcResponse(mb []byte, header http.Header) (interface{}, error) {
 	req, err := http.NewRequest(http.MethodPost, s.rpcAddr, bytes.NewReader(mb))
 	if err != nil {
 		return nil, fmt.Errorf("failed to request; %s", err)
 	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -308,6 +320,22 @@ func (s *Server) getRpcResponse(mb []byte) (interface{}, error) {
 	return wsSend, nil
 }
 
+// forwardHeader returns the header identifying the websocket client to the rest-server: its
+// Authorization header and its address appended to the X-Forwarded-For header.
+func forwardHeader(r *http.Request) http.Header {
+	header := make(http.Header)
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		header.Set("Authorization", auth)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	hops := append(r.Header.Values("X-Forwarded-For"), host)
+	header.Set("X-Forwarded-For", strings.Join(hops, ", "))
+	return header
+}
+
 func (s *Server) closeWsConnection(subIds map[rpc.ID]struct{}) {
 	for id := range subIds {
 		s.api.unsubscribe(id)
@@ -333,7 +361,7 @@ func (s *Server) batchCall(mb []byte, wsConn *wsConn) error {
 			break
 		}
 
-		data, err := s.getRpcResponse(b)
+		data, err := s.getRpcResponse(b, wsConn.header)
 		if err != nil {
 			data = makeErrResponse(err.Error())
 		}
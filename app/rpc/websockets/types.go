@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	rpcfilters "github.com/okex/exchain/app/rpc/namespaces/eth/filters"
+	ordertypes "github.com/okex/exchain/x/order/types"
 )
 
 type SubscriptionResponseJSON struct {
@@ -41,3 +42,13 @@ type wsSubscription struct {
 	unsubscribed chan struct{} // closed when unsubscribing
 	conn         *wsConn
 }
+
+// DepthBookResult is pushed to the depthBook subscriber every block.
+// Sequence is the block height, so a gap is found if it's not increased by one,
+// then the client should resync from the depth book query whose height is the sequence of the snapshot.
+type DepthBookResult struct {
+	Product  string                     `json:"product"`
+	Sequence int64                      `json:"sequence"`
+	Items    []ordertypes.DepthBookItem `json:"items"`
+	Fills    *ordertypes.MatchResult    `json:"fills,omitempty"`
+}
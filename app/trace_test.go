@@ -0,0 +1,141 @@
+package app_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+)
+
+func newTraceTestChain(t *testing.T) *Chain {
+	tmtypes.UnittestOnlySetMilestoneVenusHeight(-1)
+	tmtypes.UnittestOnlySetMilestoneVenus1Height(1)
+	tmtypes.UnittestOnlySetMilestoneVenus2Height(1)
+	tmtypes.UnittestOnlySetMilestoneEarthHeight(1)
+	tmtypes.UnittestOnlySetMilestoneVenus6Height(1)
+
+	env := new(Env)
+	env.priv = make([]ethsecp256k1.PrivKey, 10)
+	env.addr = make([]sdk.AccAddress, 10)
+	for i := 0; i < 10; i++ {
+		priv, err := ethsecp256k1.GenerateKey()
+		require.NoError(t, err)
+		env.priv[i] = priv
+		env.addr[i] = sdk.AccAddress(priv.PubKey().Address())
+	}
+	return NewChain(env)
+}
+
+// traceResult is the result of the default struct logger
+type traceResult struct {
+	Failed     bool              `json:"failed"`
+	StructLogs []json.RawMessage `json:"structLogs"`
+}
+
+func TestTraceBlock(t *testing.T) {
+	chain := newTraceTestChain(t)
+
+	// two evm txs of the same sender must be replayed in order, the cosmos tx is run without tracing
+	rawTxs := [][]byte{
+		createEthTx(t, chain, 0),
+		createTokenSendTx(t, chain, 2),
+		createEthTx(t, chain, 0),
+		createEthTx(t, chain, 4),
+	}
+	resps := runTxs(chain, rawTxs, false)
+	checkCodes(t, "trace block", resps, []uint32{0, 0, 0, 0})
+
+	height := chain.app.LastBlockHeight()
+	block := &tmtypes.Block{
+		Header: tmtypes.Header{
+			ChainID: chain.chainIdStr,
+			Height:  height,
+			Time:    time.Date(chain.timeYear+1, 4, 11, 13, 33, 37, 0, time.UTC),
+		},
+		Data: tmtypes.Data{Txs: tmtypes.Txs{rawTxs[0], rawTxs[1], rawTxs[2], rawTxs[3]}},
+	}
+	results, err := chain.app.BaseApp.TraceBlock(sdk.QueryTraceBlock{Height: height}, block)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(results))
+
+	for i, rawTx := range [][]byte{rawTxs[0], rawTxs[2], rawTxs[3]} {
+		require.Equal(t, ethcmn.BytesToHash(tmtypes.Tx(rawTx).Hash(height)), results[i].TxHash)
+		require.Empty(t, results[i].Error)
+
+		var res traceResult
+		require.NoError(t, json.Unmarshal(results[i].Result, &res))
+		require.False(t, res.Failed)
+	}
+
+	// the native tracer of the config is used for every evm tx
+	configBytes, err := json.Marshal(evmtypes.TraceConfig{Tracer: "callTracer"})
+	require.NoError(t, err)
+	results, err = chain.app.BaseApp.TraceBlock(sdk.QueryTraceBlock{Height: height, ConfigBytes: configBytes}, block)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(results))
+	for _, result := range results {
+		var call map[string]interface{}
+		require.NoError(t, json.Unmarshal(result.Result, &call))
+		require.Equal(t, "CALL", call["type"])
+	}
+}
+
+func TestTraceCallStateOverrides(t *testing.T) {
+	chain := newTraceTestChain(t)
+	// commit a block, so the call is traced on a committed height
+	runTxs(chain, [][]byte{createEthTx(t, chain, 0)}, false)
+	height := chain.app.LastBlockHeight()
+
+	// the sender has no balance in the state
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	from := ethcmn.BytesToAddress(priv.PubKey().Address())
+	to := ethcmn.BytesToAddress(chain.addr[1])
+	value := big.NewInt(1000)
+
+	traceCall := func(overrides *evmtypes.StateOverrides) (*sdk.Result, error) {
+		msg := evmtypes.NewMsgEthereumTx(0, &to, value, 100000, big.NewInt(1), nil)
+		txBytes, err := rlp.EncodeToBytes(msg)
+		require.NoError(t, err)
+		query := sdk.QueryTraceCall{
+			TxBytes: txBytes,
+			From:    from.String(),
+		}
+		if overrides != nil {
+			query.OverridesBytes, err = overrides.GetBytes()
+			require.NoError(t, err)
+		}
+		return chain.app.BaseApp.TraceCall(query, msg, height)
+	}
+
+	res, err := traceCall(nil)
+	require.NoError(t, err)
+	var result traceResult
+	require.NoError(t, json.Unmarshal(res.Data, &result))
+	require.True(t, result.Failed)
+
+	balance := (*hexutil.Big)(big.NewInt(1000000))
+	overrides := evmtypes.StateOverrides{
+		from: evmtypes.OverrideAccount{Balance: &balance},
+	}
+	res, err = traceCall(&overrides)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res.Data, &result))
+	require.False(t, result.Failed)
+
+	// the overrides are never written to the state
+	res, err = traceCall(nil)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(res.Data, &result))
+	require.True(t, result.Failed)
+}
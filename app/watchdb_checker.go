@@ -0,0 +1,44 @@
+package app
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/x/evm/watcher"
+)
+
+var _ watcher.StateReader = watchDBStateReader{}
+
+// watchDBStateReader reads the committed state the watchDB is checked against.
+type watchDBStateReader struct {
+	app *OKExChainApp
+}
+
+func (r watchDBStateReader) queryContext(height int64) (sdk.Context, error) {
+	if height == 0 {
+		height = r.app.LastBlockHeight()
+	}
+	cacheMS, err := r.app.GetCMS().CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, err
+	}
+	return sdk.NewContext(cacheMS, abci.Header{Height: height}, true, r.app.Logger()), nil
+}
+
+func (r watchDBStateReader) GetAccount(height int64, addr sdk.AccAddress) (auth.Account, error) {
+	ctx, err := r.queryContext(height)
+	if err != nil {
+		return nil, err
+	}
+	return r.app.AccountKeeper.GetAccount(ctx, addr), nil
+}
+
+func (r watchDBStateReader) GetState(height int64, addr ethcmn.Address, key ethcmn.Hash) (ethcmn.Hash, error) {
+	ctx, err := r.queryContext(height)
+	if err != nil {
+		return ethcmn.Hash{}, err
+	}
+	return r.app.EvmKeeper.GetStateByKey(ctx, addr, key), nil
+}
@@ -21,6 +21,7 @@ import (
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/evm/watcher"
 	"github.com/okex/exchain/x/infura"
+	ordertypes "github.com/okex/exchain/x/order/types"
 	"github.com/okex/exchain/x/token"
 	"github.com/okex/exchain/x/wasm"
 )
@@ -34,6 +35,11 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().Int(backend.FlagApiBackendBlockLruCache, 30000, "Set the size of block LRU cache for backend mem cache")
 	cmd.Flags().Int(backend.FlagApiBackendTxLruCache, 100000, "Set the size of tx LRU cache for backend mem cache")
 	cmd.Flags().Bool(watcher.FlagCheckWd, false, "Enable check watchDB in log")
+	cmd.Flags().Int64(watcher.FlagCheckInterval, 0, "Compare samples of the watchDB with the state every n blocks, 0 to disable")
+	cmd.Flags().Int(watcher.FlagCheckSamples, 100, "Number of accounts and of storage slots sampled by each watchDB consistency check")
+	cmd.Flags().Bool(watcher.FlagCheckRepair, false, "Repair the watchDB entries found different from the state by the consistency checks")
+	cmd.Flags().Uint64(watcher.FlagRetainBlocks, 0, "Keep the transactions, receipts and blocks of the last n blocks in the watchDB and prune the older ones in the background, 0 to keep all")
+	cmd.Flags().Bool(watcher.FlagLogIndex, false, "Maintain an index of the event logs in the watchDB to serve eth_getLogs without bloom scanning")
 	cmd.Flags().Bool(rpc.FlagPersonalAPI, true, "Enable the personal_ prefixed set of APIs in the Web3 JSON-RPC spec")
 	cmd.Flags().Bool(rpc.FlagDebugAPI, false, "Enable the debug_ prefixed set of APIs in the Web3 JSON-RPC spec")
 	cmd.Flags().Bool(evmtypes.FlagEnableBloomFilter, true, "Enable bloom filter for event logs")
@@ -58,6 +64,13 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().String(rpc.FlagRateLimitAPI, "", "Set the RPC API to be controlled by the rate limit policy, such as \"eth_getLogs,eth_newFilter,eth_newBlockFilter,eth_newPendingTransactionFilter,eth_getFilterChanges\"")
 	cmd.Flags().Int(rpc.FlagRateLimitCount, 0, "Set the count of requests allowed per second of rpc rate limiter")
 	cmd.Flags().Int(rpc.FlagRateLimitBurst, 1, "Set the concurrent count of requests allowed of rpc rate limiter")
+	cmd.Flags().Float64(rpc.FlagRateLimitClientRate, 0, "Set the request cost allowed per second to each RPC client in each namespace, 0 disables the per-client rate limiter")
+	cmd.Flags().Int(rpc.FlagRateLimitClientBurst, 100, "Set the request cost an RPC client can spend at once in each namespace, it must cover the most expensive method")
+	cmd.Flags().String(rpc.FlagRateLimitClientNamespaces, "", "Set the per-client rate limits of specific namespaces as namespace:rate:burst, such as \"debug:1:100\"")
+	cmd.Flags().String(rpc.FlagRateLimitMethodCost, "", "Set the cost of RPC methods for the per-client rate limiter, such as \"eth_getLogs:20,eth_call:5\" (default cost is 1)")
+	cmd.Flags().Int64(rpc.FlagRateLimitDailyQuota, 0, "Set the total request cost an RPC client can spend per UTC day, 0 means no quota")
+	cmd.Flags().String(rpc.FlagRateLimitTrustedProxies, "", "Set the proxies whose X-Forwarded-For header identifies RPC clients, such as \"10.0.0.0/8\"")
+	cmd.Flags().String(rpc.FlagRateLimitAPIKeys, "", "Set the API keys separated by commas, an RPC client sending one in its Authorization header is limited by the key instead of its IP address")
 	cmd.Flags().Uint64(config.FlagGasLimitBuffer, 50, "Percentage to increase gas limit")
 	cmd.Flags().String(rpc.FlagDisableAPI, "", "Set the RPC API to be disabled, such as \"eth_getLogs,eth_newFilter,eth_newBlockFilter,eth_newPendingTransactionFilter,eth_getFilterChanges\"")
 
@@ -90,6 +103,14 @@ func RegisterAppFlag(cmd *cobra.Command) {
 
 	cmd.Flags().String(rpc.FlagKafkaAddr, "", "The address of kafka cluster to consume pending txs")
 	cmd.Flags().String(rpc.FlagKafkaTopic, "", "The topic that the kafka writer will produce messages to")
+	cmd.Flags().String(rpc.FlagPendingTxTCPAddr, "", "The address to fan pending txs out to tcp clients, as JSON lines")
+	cmd.Flags().Int(rpc.FlagPendingTxTCPBacklog, 10000, "The number of pending tx messages kept for the tcp clients resuming from an offset")
+	cmd.Flags().String(rpc.FlagPendingTxFileDir, "", "The directory to write pending txs to, as rolling JSON lines files")
+	cmd.Flags().Int64(rpc.FlagPendingTxFileMaxSize, 100*1024*1024, "The size in bytes of a pending txs file before rolling to a new one")
+	cmd.Flags().Int(rpc.FlagPendingTxFileMaxFiles, 10, "The number of pending txs files kept, 0 to keep all of them")
+	cmd.Flags().String(rpc.FlagPendingTxWebhookURL, "", "The url to post pending txs to")
+	cmd.Flags().Int(rpc.FlagPendingTxWebhookRetry, 10, "The number of retries of a failed pending tx post, 0 to retry forever")
+	cmd.Flags().String(rpc.FlagPendingTxOffsetFile, "", "The file persisting the offsets of pending txs, so that they keep increasing after a restart")
 
 	cmd.Flags().Bool(config.FlagEnableDynamic, false, "Enable dynamic configuration for nodes")
 	cmd.Flags().String(config.FlagApollo, "", "Apollo connection config(IP|AppID|NamespaceName) for dynamic configuration")
@@ -137,12 +158,20 @@ func RegisterAppFlag(cmd *cobra.Command) {
 	cmd.Flags().String(infura.FlagMysqlUser, "", "Mysql user of infura rpc service")
 	cmd.Flags().String(infura.FlagMysqlPass, "", "Mysql password of infura rpc service")
 	cmd.Flags().String(infura.FlagMysqlDB, "infura", "Mysql db name of infura rpc service")
+	cmd.Flags().String(infura.FlagEngineType, infura.EngineTypeMySQL, "Stream engine type of infura rpc service: mysql, postgres, sqlite (built with WITH_SQLITE=true) or file")
+	cmd.Flags().String(infura.FlagPostgresUrl, "", "PostgreSQL dsn of infura rpc service, e.g. \"host=localhost user=infura password=infura dbname=infura port=5432\"")
+	cmd.Flags().String(infura.FlagSQLitePath, "", "SQLite database file path of infura rpc service")
+	cmd.Flags().String(infura.FlagFilePath, "", "JSON-lines file path of infura rpc service")
+	cmd.Flags().String(infura.FlagLockType, infura.LockTypeRedis, "Distributed lock type of infura rpc service: redis, local or file")
+	cmd.Flags().String(infura.FlagLockDir, "", "Directory of the file lock of infura rpc service, shared by the nodes on the same box")
 	cmd.Flags().Int(infura.FlagCacheQueueSize, 0, "Cache queue size of infura rpc service")
 	cmd.Flags().Int(config.FlagDebugGcInterval, 0, "Force gc every n heights for debug")
 	cmd.Flags().String(rpc.FlagWebsocket, "8546", "websocket port to listen to")
 	cmd.Flags().Int(backend.FlagLogsLimit, 0, "Maximum number of logs returned when calling eth_getLogs")
 	cmd.Flags().Int(backend.FlagLogsTimeout, 60, "Maximum query duration when calling eth_getLogs")
+	cmd.Flags().Int(backend.FlagLogsMaxResults, 10000, "Maximum number of logs returned by eth_getLogs served by the log index, and page size of eth_getLogsPage")
 	cmd.Flags().Int(websockets.FlagSubscribeLimit, 15, "Maximum subscription on a websocket connection")
+	cmd.Flags().Bool(ordertypes.FlagEnableDepthBookEvent, false, "Enable to emit the depth book updates of order module for the websocket subscription")
 
 	// flags for tendermint rpc
 	cmd.Flags().Int(config.FlagMaxSubscriptionClients, 100, "Maximum number of unique clientIDs that Tendermint RPC server can /subscribe or /broadcast_tx_commit")
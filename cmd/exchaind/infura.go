@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/store"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	evm "github.com/okex/exchain/x/evm"
+	"github.com/okex/exchain/x/infura"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagBackfillStart      = "start-height"
+	flagBackfillEnd        = "end-height"
+	flagBackfillWorkers    = "workers"
+	flagBackfillCheckpoint = "checkpoint"
+)
+
+func infuraCmd(ctx *server.Context, codecProxy *codec.CodecProxy) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "infura",
+		Short: "Manage the data of infura rpc service",
+	}
+	cmd.AddCommand(
+		infuraBackfillCmd(ctx, codecProxy),
+	)
+	return cmd
+}
+
+func infuraBackfillCmd(ctx *server.Context, codecProxy *codec.CodecProxy) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Write the blocks, transactions and receipts of the committed blocks into the infura stream engine",
+		Long: `Replay the committed blocks from the block store and the state db, and write them into the stream engine
+configured by the infura flags. The blocks written before are replaced, so the heights can be backfilled repeatedly.
+The progress is saved in the checkpoint file, and the backfill of the same height range resumes from it.
+The node must be stopped while backfilling.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(flags.FlagHome))
+			if err := checkBackend(dbm.BackendType(config.DBBackend)); err != nil {
+				return err
+			}
+
+			engine, err := infura.NewBackfillStreamEngine(ctx.Logger)
+			if err != nil {
+				return err
+			}
+			blockStoreDB := initDB(config, blockDBName)
+			defer blockStoreDB.Close()
+			stateDB := initDB(config, stateDBName)
+			defer stateDB.Close()
+
+			checkpoint := viper.GetString(flagBackfillCheckpoint)
+			if checkpoint == "" {
+				checkpoint = filepath.Join(config.DBDir(), "infura_backfill.json")
+			}
+			backfiller := infura.NewBackfiller(store.NewBlockStore(blockStoreDB), stateDB,
+				evm.TxDecoder(codecProxy), engine, ctx.Logger)
+			start, end := viper.GetInt64(flagBackfillStart), viper.GetInt64(flagBackfillEnd)
+			if err := backfiller.Run(start, end, viper.GetInt(flagBackfillWorkers), checkpoint); err != nil {
+				return err
+			}
+			fmt.Printf("infura backfill [%d, %d] finished\n", start, end)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagBackfillStart, 1, "The first height to backfill")
+	cmd.Flags().Int64(flagBackfillEnd, 0, "The last height to backfill")
+	cmd.Flags().Int(flagBackfillWorkers, 4, "The number of workers writing the blocks in parallel")
+	cmd.Flags().String(flagBackfillCheckpoint, "", "The checkpoint file of the backfill progress, default <home>/data/infura_backfill.json")
+	cmd.Flags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb")
+	cmd.Flags().String(infura.FlagEngineType, infura.EngineTypeMySQL, "Stream engine type: mysql, postgres, sqlite or file")
+	cmd.Flags().String(infura.FlagMysqlUrl, "", "Mysql url(host:port) of infura rpc service")
+	cmd.Flags().String(infura.FlagMysqlUser, "", "Mysql user of infura rpc service")
+	cmd.Flags().String(infura.FlagMysqlPass, "", "Mysql password of infura rpc service")
+	cmd.Flags().String(infura.FlagMysqlDB, "infura", "Mysql db name of infura rpc service")
+	cmd.Flags().String(infura.FlagPostgresUrl, "", "PostgreSQL dsn of infura rpc service")
+	cmd.Flags().String(infura.FlagSQLitePath, "", "SQLite database file path of infura rpc service")
+	cmd.Flags().String(infura.FlagFilePath, "", "JSON-lines file path of infura rpc service")
+	cmd.MarkFlagRequired(flagBackfillEnd)
+	return cmd
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	"github.com/okex/exchain/libs/cosmos-sdk/server"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/evm/watcher"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagLogIndexFrom = "from-height"
+	flagRetainBlocks = "retain-blocks"
+)
+
+func watchDBCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watchdb",
+		Short: "Manage the watchDB of the fast query mode",
+	}
+	cmd.AddCommand(
+		rebuildLogIndexCmd(ctx),
+	)
+	return cmd
+}
+
+func rebuildLogIndexCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild-log-index",
+		Short: "Rebuild the log index of the watchDB from the transaction receipts it holds",
+		Long: `Index the logs of the transaction receipts of the watchDB, so that eth_getLogs is served by the log index
+for the heights indexed, then start the node with --fast-query-log-index to maintain the index.
+The logs indexed before are rewritten, so the index can be rebuilt repeatedly.
+The node must be stopped while rebuilding.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbDir := filepath.Join(viper.GetString(flags.FlagHome), watcher.WatchDbDir)
+			db, err := sdk.NewDB(watcher.WatchDBName, dbDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			count, err := watcher.RebuildLogIndex(db, viper.GetUint64(flagLogIndexFrom))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("log index rebuilt, %d logs indexed\n", count)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagLogIndexFrom, 0, "The first height to index, the receipts below it are skipped")
+	cmd.Flags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb")
+	return cmd
+}
+
+func pruneWatcherCmd(ctx *server.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-watcher",
+		Short: "Prune and Compact the watchDB of the fast query mode",
+		Long: `Delete the transactions, receipts, blocks and logs of the watchDB older than the last blocks retained,
+the accounts, the storage, the code and the params are kept, then compact the watchDB.
+The node must be stopped while pruning.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbType := dbm.BackendType(viper.GetString(sdk.FlagDBBackend))
+			if err := checkBackend(dbType); err != nil {
+				return err
+			}
+			dbDir := filepath.Join(viper.GetString(flags.FlagHome), watcher.WatchDbDir)
+			db, err := sdk.NewDB(watcher.WatchDBName, dbDir)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			retain := viper.GetUint64(flagRetainBlocks)
+			if retain > 0 {
+				if retain < watcher.MinRetainBlocks {
+					return fmt.Errorf("retain-blocks must be at least %d", watcher.MinRetainBlocks)
+				}
+				latest, ok := watcher.GetLatestHeight(db)
+				if ok && latest >= retain {
+					log.Println("--------- pruning start... ---------")
+					pruned, err := watcher.PruneBlocks(db, latest-retain+1)
+					if err != nil {
+						return err
+					}
+					log.Printf("Pruned %d heights of the watchDB, the heights from %d are kept\n", pruned, latest-retain+1)
+					log.Println("--------- pruning end!!!   ---------")
+				}
+			}
+
+			log.Println("--------- compact start... ---------")
+			wg.Add(1)
+			go compactDB(db, watcher.WatchDBName, dbType)
+			wg.Wait()
+			log.Println("--------- compact end!!!   ---------")
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagRetainBlocks, watcher.MinRetainBlocks, "Keep the last n blocks of the watchDB, 0 to compact without pruning")
+	cmd.Flags().String(sdk.FlagDBBackend, tmtypes.DBBackend, "Database backend: goleveldb | rocksdb")
+	return cmd
+}
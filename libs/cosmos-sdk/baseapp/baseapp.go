@@ -17,6 +17,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
 	"github.com/okex/exchain/libs/cosmos-sdk/store/mpt"
 	"github.com/okex/exchain/libs/cosmos-sdk/store/rootmulti"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
 	storetypes "github.com/okex/exchain/libs/cosmos-sdk/store/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
@@ -154,12 +155,20 @@ type BaseApp struct { // nolint: maligned
 	getFeeCollectorInfoHandler   sdk.GetFeeCollectorInfo
 	logFix                       sdk.LogFix
 	updateCosmosTxCount          sdk.UpdateCosmosTxCount
+	parallelTxsStatsHandler      sdk.ParallelTxsStatsHandler
 
-	getTxFeeAndFromHandler sdk.GetTxFeeAndFromHandler
-	getTxFeeHandler        sdk.GetTxFeeHandler
-	updateCMTxNonceHandler sdk.UpdateCMTxNonceHandler
-	getGasConfigHandler    sdk.GetGasConfigHandler
-	getBlockConfigHandler  sdk.GetBlockConfigHandler
+	getTxFeeAndFromHandler  sdk.GetTxFeeAndFromHandler
+	getTxAccessListHandler  sdk.GetTxAccessListHandler
+	decodeStorageKeyHandler sdk.DecodeStorageKeyHandler
+	getTxFeeHandler         sdk.GetTxFeeHandler
+	updateCMTxNonceHandler  sdk.UpdateCMTxNonceHandler
+	getGasConfigHandler     sdk.GetGasConfigHandler
+	getBlockConfigHandler   sdk.GetBlockConfigHandler
+
+	// manages snapshots, i.e. dumps of app state at certain intervals
+	snapshotManager    *snapshots.Manager
+	snapshotInterval   uint64 // block interval between state sync snapshots
+	snapshotKeepRecent uint32 // recent state sync snapshots to keep
 
 	// volatile states:
 	//
@@ -207,6 +216,7 @@ type BaseApp struct { // nolint: maligned
 
 	customizeModuleOnStop []sdk.CustomizeOnStop
 	mptCommitHandler      sdk.MptCommitHandler // handler for mpt trie commit
+	restoreHandler        sdk.RestoreHandler   // handler for the state restored from a snapshot
 	feeCollector          sdk.Coins
 	FeeSplitCollector     []*sdk.FeeSplitInfo
 
@@ -504,6 +514,17 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 	// nil, it will be saved later during InitChain.
 	//
 	// TODO: assert that InitChain hasn't yet been called.
+	app.loadConsensusParams(mainStore)
+
+	// needed for the export command which inits from store but never calls initchain
+	app.setCheckState(abci.Header{})
+	app.Seal()
+
+	return nil
+}
+
+// loadConsensusParams loads the consensus params from the main store, if any.
+func (app *BaseApp) loadConsensusParams(mainStore sdk.KVStore) {
 	consensusParamsBz := mainStore.Get(mainConsensusParamsKey)
 	if consensusParamsBz != nil {
 		var consensusParams = &abci.ConsensusParams{}
@@ -515,12 +536,6 @@ func (app *BaseApp) initFromMainStore(baseKey *sdk.KVStoreKey) error {
 
 		app.setConsensusParams(consensusParams)
 	}
-
-	// needed for the export command which inits from store but never calls initchain
-	app.setCheckState(abci.Header{})
-	app.Seal()
-
-	return nil
 }
 
 func (app *BaseApp) setMinGasPrices(gasPrices sdk.DecCoins) {
@@ -31,5 +31,9 @@ func (m *modeHandlerSimulate) handleStartHeight(info *runTxInfo, height int64) e
 	if info.overridesBytes != nil {
 		info.ctx.SetOverrideBytes(info.overridesBytes)
 	}
+	if info.traceTxLog {
+		info.ctx.SetIsTraceTxLog(true)
+		info.ctx.SetTraceTxLogConfig(info.traceTxLogConfig)
+	}
 	return err
 }
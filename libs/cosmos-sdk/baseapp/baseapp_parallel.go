@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -32,6 +33,7 @@ type extraDataForTx struct {
 	to                  string
 	stdTx               sdk.Tx
 	decodeErr           error
+	accesses            []sdk.TxAccess
 }
 
 type txWithIndex struct {
@@ -72,6 +74,10 @@ func (app *BaseApp) getExtraDataByTxs(txs [][]byte) {
 				}
 
 				coin, isEvm, needUpdateTXCounter, s, toAddr, _, supportPara := app.getTxFeeAndFromHandler(app.getContextForTx(runTxModeDeliver, txBytes), tx)
+				var accesses []sdk.TxAccess
+				if para.isConflictPrediction && isEvm && app.getTxAccessListHandler != nil {
+					accesses = app.getTxAccessListHandler(tx)
+				}
 				para.extraTxsInfo[index] = &extraDataForTx{
 					supportPara:         supportPara,
 					fee:                 coin,
@@ -80,6 +86,7 @@ func (app *BaseApp) getExtraDataByTxs(txs [][]byte) {
 					from:                s,
 					to:                  toAddr,
 					stdTx:               tx,
+					accesses:            accesses,
 				}
 				wg.Done()
 			}
@@ -135,7 +142,10 @@ func (app *BaseApp) calGroup() {
 	pm.cosmosTxIndexInBlock = 0
 	for index, tx := range pm.extraTxsInfo {
 		if tx.supportPara { //evmTx & wasmTx
-			Union(tx.from, tx.to)
+			Union(tx.from, "")
+			for _, key := range app.getConflictKeys(tx) {
+				Union(tx.from, key)
+			}
 		} else {
 			app.parallelTxManage.putResult(index, &executeResult{paraMsg: &sdk.ParaMsg{}, msIsNil: true})
 		}
@@ -148,6 +158,11 @@ func (app *BaseApp) calGroup() {
 
 	}
 
+	// the stm scheduler does not group the txs
+	if pm.isSTM {
+		return
+	}
+
 	addrToID := make(map[string]int, 0)
 
 	for index, txInfo := range pm.extraTxsInfo {
@@ -225,6 +240,10 @@ func (app *BaseApp) runTxs() []*abci.ResponseDeliverTx {
 	}
 	signal := make(chan int, 1)
 	rerunIdx := 0
+	conflictCnt := 0
+	execTime := time.Duration(0)
+	startTime := time.Now()
+	var stats sdk.ParallelTxsStats
 
 	pm := app.parallelTxManage
 
@@ -243,9 +262,13 @@ func (app *BaseApp) runTxs() []*abci.ResponseDeliverTx {
 				break
 			}
 			isReRun := false
-			if pm.isConflict(res) || overFlow(currentGas, res.resp.GasUsed, maxGas) || pm.haveAnteErrTx {
+			isConflict := pm.isConflict(res)
+			if isConflict || overFlow(currentGas, res.resp.GasUsed, maxGas) || pm.haveAnteErrTx {
 				rerunIdx++
 				isReRun = true
+				if isConflict && !res.msIsNil {
+					conflictCnt++
+				}
 				// conflict rerun tx
 				if !pm.extraTxsInfo[pm.upComingTxIndex].supportPara {
 					app.fixFeeCollector()
@@ -267,6 +290,10 @@ func (app *BaseApp) runTxs() []*abci.ResponseDeliverTx {
 			pm.blockGasMeterMu.Unlock()
 
 			pm.SetCurrentIndexRes(pm.upComingTxIndex, res)
+			if pm.isSTM {
+				pm.commitSTMResult(pm.upComingTxIndex, res, isReRun)
+			}
+			execTime += res.duration
 
 			if !res.msIsNil {
 				pm.currTxFee = pm.currTxFee.Add(pm.extraTxsInfo[pm.upComingTxIndex].fee.Sub(pm.finalResult[pm.upComingTxIndex].paraMsg.RefundFee)...)
@@ -279,11 +306,20 @@ func (app *BaseApp) runTxs() []*abci.ResponseDeliverTx {
 					pm.groupTasks[pm.txIndexWithGroup[pm.upComingTxIndex]].addRerun(pm.upComingTxIndex)
 				}
 			}
+			pm.upComingTxIndexMu.Lock()
 			pm.upComingTxIndex++
+			pm.upComingTxIndexMu.Unlock()
 
 			if pm.upComingTxIndex == pm.txSize {
+				stats = sdk.ParallelTxsStats{
+					Txs:       pm.txSize,
+					Conflicts: conflictCnt,
+					Reruns:    rerunIdx,
+					Speedup:   float64(execTime) / float64(time.Since(startTime)),
+				}
 				app.logger.Info("Paralleled-tx", "blockHeight", app.deliverState.ctx.BlockHeight(), "len(txs)", pm.txSize,
-					"Parallel run", pm.txSize-rerunIdx, "ReRun", rerunIdx, "len(group)", len(pm.groupList))
+					"Parallel run", pm.txSize-rerunIdx, "ReRun", rerunIdx, "Conflict", conflictCnt,
+					"len(group)", len(pm.groupList), "stm", pm.isSTM, "speedup", stats.Speedup)
 				signal <- 0
 				return
 			}
@@ -292,6 +328,9 @@ func (app *BaseApp) runTxs() []*abci.ResponseDeliverTx {
 
 	pm.resultCb = asyncCb
 	pm.StartResultHandle()
+	if pm.isSTM {
+		app.startSTMTasks()
+	}
 	for index := 0; index < len(pm.groupList); index++ {
 		pm.groupTasks = append(pm.groupTasks, newGroupTask(len(pm.groupList[index]), pm.addMultiCache, pm.nextTxInThisGroup, app.asyncDeliverTx, pm.putResult))
 		pm.groupTasks[index].addTask(pm.groupList[index][0])
@@ -306,12 +345,19 @@ func (app *BaseApp) runTxs() []*abci.ResponseDeliverTx {
 	for _, v := range pm.groupTasks {
 		v.stopChan <- struct{}{}
 	}
+	pm.stmWorkers.Wait()
 	pm.alreadyEnd = true
 	pm.stop <- struct{}{}
 
+	if app.parallelTxsStatsHandler != nil {
+		app.parallelTxsStatsHandler(app.deliverState.ctx.BlockHeight(), stats)
+	}
+
 	// update fee collector balance
 	app.feeCollector = app.parallelTxManage.currTxFee
 
+	app.learnContractProfiles()
+
 	// fix logs
 	receiptsLogs := app.endParallelTxs(pm.txSize)
 
@@ -371,6 +417,7 @@ func (app *BaseApp) endParallelTxs(txSize int) [][]byte {
 // we reuse the nonce that changed by the last async call
 // if last ante handler has been failed, we need rerun it ? or not?
 func (app *BaseApp) deliverTxWithCache(txIndex int) *executeResult {
+	start := time.Now()
 	app.parallelTxManage.currentRerunIndex = txIndex
 	defer func() {
 		app.parallelTxManage.currentRerunIndex = -1
@@ -402,6 +449,7 @@ func (app *BaseApp) deliverTxWithCache(txIndex int) *executeResult {
 
 	asyncExe := newExecuteResult(resp, info.msCacheAnte, uint32(txIndex), info.ctx.ParaMsg(),
 		0, info.runMsgCtx.GetWatcher(), info.tx.GetMsgs(), app.parallelTxManage, info.ctx.GetFeeSplitInfo())
+	asyncExe.duration = time.Since(start)
 	app.parallelTxManage.addMultiCache(info.msCacheAnte, info.msCache)
 	return asyncExe
 }
@@ -416,6 +464,7 @@ type executeResult struct {
 	watcher      sdk.IWatcher
 	msgs         []sdk.Msg
 	FeeSpiltInfo *sdk.FeeSplitInfo
+	duration     time.Duration
 
 	rwSet types.MsRWSet
 }
@@ -456,6 +505,7 @@ type parallelTxManager struct {
 	groupTasks       []*groupTask
 	blockGasMeterMu  sync.Mutex
 	isAsyncDeliverTx bool
+	isSTM            bool
 	txs              [][]byte
 	txSize           int
 	alreadyEnd       bool
@@ -477,6 +527,7 @@ type parallelTxManager struct {
 
 	currentRerunIndex int
 	upComingTxIndex   int
+	upComingTxIndexMu sync.RWMutex
 	currTxFee         sdk.Coins
 	cms               sdk.CacheMultiStore
 	conflictCheck     types.MsRWSet
@@ -486,6 +537,13 @@ type parallelTxManager struct {
 	blockMultiStores *cacheMultiStoreList
 	chainMultiStores *cacheMultiStoreList
 
+	mvStore    *mvStore
+	stmInvalid []int32
+	stmWorkers sync.WaitGroup
+
+	isConflictPrediction bool
+	contractProfiles     map[string]*contractProfile
+
 	extraTxsInfo []*extraDataForTx
 	txReps       []*executeResult
 	finalResult  []*executeResult
@@ -625,6 +683,7 @@ func newParallelTxManager() *parallelTxManager {
 	para := &parallelTxManager{
 		blockGasMeterMu:  sync.Mutex{},
 		isAsyncDeliverTx: isAsync,
+		isSTM:            viper.GetString(sm.FlagParallelScheduler) == sm.ParallelSchedulerSTM,
 		stop:             make(chan struct{}, 1),
 
 		conflictCheck: make(types.MsRWSet),
@@ -641,6 +700,11 @@ func newParallelTxManager() *parallelTxManager {
 		chainMpCache:     newCacheRWSetList(),
 		blockMultiStores: newCacheMultiStoreList(),
 		chainMultiStores: newCacheMultiStoreList(),
+
+		mvStore: newMVStore(),
+
+		isConflictPrediction: viper.GetBool(sm.FlagParallelConflictPrediction),
+		contractProfiles:     make(map[string]*contractProfile),
 	}
 	return para
 }
@@ -722,6 +786,10 @@ func (pm *parallelTxManager) isConflict(e *executeResult) bool {
 		return true
 	}
 
+	if pm.isSTM {
+		return pm.isSTMConflict(e)
+	}
+
 	for storeKey, rw := range e.rwSet {
 		delete(rw.Read, string(feeAccountKeyInStore))
 		delete(rw.Read, string(wasmTxCountKey))
@@ -787,6 +855,9 @@ func (pm *parallelTxManager) init(txs [][]byte, blockHeight int64, deliverStateM
 		<-pm.resultCh
 	}
 
+	pm.mvStore.reset()
+	pm.stmInvalid = make([]int32, txSize)
+
 	pm.txByteMpCosmosIndex = make(map[string]int, 0)
 	pm.nextTxInGroup = make(map[int]int)
 
@@ -798,15 +869,30 @@ func (pm *parallelTxManager) init(txs [][]byte, blockHeight int64, deliverStateM
 	pm.deliverTxs = make([]*abci.ResponseDeliverTx, txSize)
 }
 
+// isTxCommitted reports whether the tx is committed to the block already, it's called by the workers
+// while the txs are committed
+func (pm *parallelTxManager) isTxCommitted(txIndex int) bool {
+	pm.upComingTxIndexMu.RLock()
+	defer pm.upComingTxIndexMu.RUnlock()
+	return txIndex < pm.upComingTxIndex
+}
+
 func (pm *parallelTxManager) getParentMsByTxIndex(txIndex int) (sdk.CacheMultiStore, bool) {
 
-	if txIndex <= pm.upComingTxIndex-1 {
+	if pm.isTxCommitted(txIndex) {
 		return nil, false
 	}
 
+	if pm.isSTM {
+		if pm.currentRerunIndex != txIndex {
+			return pm.chainMultiStores.GetStoreWithParent(pm.getMVStoreByTxIndex(txIndex)), false
+		}
+		return pm.chainMultiStores.GetStoreWithParent(pm.cms), true
+	}
+
 	useCurrent := false
 	var ms types.CacheMultiStore
-	if pm.currentRerunIndex != txIndex && pm.preTxInGroup[txIndex] > pm.upComingTxIndex-1 {
+	if pm.currentRerunIndex != txIndex && !pm.isTxCommitted(pm.preTxInGroup[txIndex]) {
 		if groupMs := pm.groupTasks[pm.txIndexWithGroup[txIndex]].ms; groupMs != nil {
 			ms = pm.chainMultiStores.GetStoreWithParent(groupMs)
 		}
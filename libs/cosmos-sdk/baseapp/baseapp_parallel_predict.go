@@ -0,0 +1,122 @@
+package baseapp
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+var (
+	// a contract is profiled once this number of txs calling it were committed
+	minProfiledTxs = 10
+	// the counts of a profile are halved once this number of txs calling the contract were committed
+	profileDecayTxs = 1000
+	// the profiles are dropped once this number of contracts are profiled
+	maxProfiledContracts = 10000
+)
+
+// contractProfile counts the txs calling a contract and the storage slots they wrote.
+type contractProfile struct {
+	txs    int
+	writes map[string]int
+}
+
+// commonSlots returns the conflict keys of the storage slots written by at least half of the txs
+// calling the contract, ok is false while the contract is not profiled yet.
+func (p *contractProfile) commonSlots() (slots []string, ok bool) {
+	if p.txs < minProfiledTxs {
+		return nil, false
+	}
+	slots = make([]string, 0)
+	for slot, count := range p.writes {
+		if 2*count >= p.txs {
+			slots = append(slots, slot)
+		}
+	}
+	return slots, true
+}
+
+func (p *contractProfile) decay() {
+	p.txs /= 2
+	for slot, count := range p.writes {
+		if count/2 == 0 {
+			delete(p.writes, slot)
+		} else {
+			p.writes[slot] = count / 2
+		}
+	}
+}
+
+// conflictKey returns the key grouping the txs accessing the same state.
+func conflictKey(access sdk.TxAccess) string {
+	if access.Slot == "" {
+		return access.Address
+	}
+	return access.Address + "/" + access.Slot
+}
+
+// getConflictKeys returns the keys a tx is predicted to conflict on with the other txs, besides
+// its sender. An evm tx conflicts on the state of its access list, and on the storage slots of its
+// receiver it declares or, if it declares none, the storage slots written by most of the txs calling
+// the receiver before. A tx whose receiver is not profiled yet conflicts on its receiver.
+// A wrong prediction only makes the tx conflict with the txs of another group, then it's executed again.
+func (app *BaseApp) getConflictKeys(tx *extraDataForTx) []string {
+	pm := app.parallelTxManage
+	if !pm.isConflictPrediction || !tx.isEvm || tx.to == "" {
+		return []string{tx.to}
+	}
+
+	keys := make([]string, 0, len(tx.accesses)+1)
+	declared := false
+	for _, access := range tx.accesses {
+		keys = append(keys, conflictKey(access))
+		if access.Address == tx.to && access.Slot != "" {
+			declared = true
+		}
+	}
+	if declared {
+		return keys
+	}
+
+	if profile, ok := pm.contractProfiles[tx.to]; ok {
+		if slots, ok := profile.commonSlots(); ok {
+			return append(keys, slots...)
+		}
+	}
+	return append(keys, tx.to)
+}
+
+// learnContractProfiles counts the storage slots written by the evm txs of the block by the
+// contract they called, from the rw sets of their results.
+func (app *BaseApp) learnContractProfiles() {
+	pm := app.parallelTxManage
+	if !pm.isConflictPrediction || app.decodeStorageKeyHandler == nil {
+		return
+	}
+
+	for index, tx := range pm.extraTxsInfo {
+		res := pm.finalResult[index]
+		if !tx.isEvm || tx.to == "" || res == nil || res.msIsNil {
+			continue
+		}
+
+		profile, ok := pm.contractProfiles[tx.to]
+		if !ok {
+			if len(pm.contractProfiles) >= maxProfiledContracts {
+				pm.contractProfiles = make(map[string]*contractProfile)
+			}
+			profile = &contractProfile{writes: make(map[string]int)}
+			pm.contractProfiles[tx.to] = profile
+		}
+
+		profile.txs++
+		for storeKey, rw := range res.rwSet {
+			for key := range rw.Write {
+				if access, ok := app.decodeStorageKeyHandler(storeKey, []byte(key)); ok {
+					profile.writes[conflictKey(access)]++
+				}
+			}
+		}
+		if profile.txs >= profileDecayTxs {
+			profile.decay()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package baseapp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	store "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+func newPredictApp() *BaseApp {
+	app := &BaseApp{parallelTxManage: newParallelTxManager()}
+	app.parallelTxManage.isConflictPrediction = true
+	// the keys of capKey1 are the storage slots of the contract "c"
+	app.decodeStorageKeyHandler = func(storeKey sdk.StoreKey, key []byte) (sdk.TxAccess, bool) {
+		if storeKey != capKey1 {
+			return sdk.TxAccess{}, false
+		}
+		return sdk.TxAccess{Address: "c", Slot: string(key)}, true
+	}
+	return app
+}
+
+func TestGetConflictKeys(t *testing.T) {
+	app := newPredictApp()
+
+	// not profiled yet, the tx conflicts on its receiver
+	tx := &extraDataForTx{isEvm: true, from: "a", to: "c"}
+	require.Equal(t, []string{"c"}, app.getConflictKeys(tx))
+
+	// the declared slots of the receiver replace it
+	tx.accesses = []sdk.TxAccess{{Address: "c", Slot: "s1"}, {Address: "d"}}
+	require.Equal(t, []string{"c/s1", "d"}, app.getConflictKeys(tx))
+
+	// the cosmos txs conflict on their receiver
+	require.Equal(t, []string{"c"}, app.getConflictKeys(&extraDataForTx{from: "a", to: "c"}))
+
+	app.parallelTxManage.isConflictPrediction = false
+	require.Equal(t, []string{"c"}, app.getConflictKeys(tx))
+}
+
+func TestLearnContractProfiles(t *testing.T) {
+	app := newPredictApp()
+	pm := app.parallelTxManage
+
+	// every tx writes the slot "reserve" and a slot of its own
+	for i := 0; i < minProfiledTxs; i++ {
+		rw := store.NewCacheKvRWSet()
+		rw.Write["reserve"] = store.DirtyValue{Value: []byte{1}}
+		rw.Write[fmt.Sprintf("balance%d", i)] = store.DirtyValue{Value: []byte{1}}
+		other := store.NewCacheKvRWSet()
+		other.Write["account"] = store.DirtyValue{Value: []byte{1}}
+
+		pm.extraTxsInfo = []*extraDataForTx{{isEvm: true, from: fmt.Sprintf("a%d", i), to: "c"}}
+		pm.finalResult = []*executeResult{{rwSet: store.MsRWSet{capKey1: rw, capKey2: other}}}
+		app.learnContractProfiles()
+	}
+
+	slots, ok := pm.contractProfiles["c"].commonSlots()
+	require.True(t, ok)
+	require.Equal(t, []string{"c/reserve"}, slots)
+	require.Equal(t, []string{"c/reserve"}, app.getConflictKeys(&extraDataForTx{isEvm: true, from: "a", to: "c"}))
+
+	profile := pm.contractProfiles["c"]
+	profile.decay()
+	require.Equal(t, minProfiledTxs/2, profile.txs)
+	require.Equal(t, minProfiledTxs/2, profile.writes["c/reserve"])
+	require.NotContains(t, profile.writes, "c/balance0")
+}
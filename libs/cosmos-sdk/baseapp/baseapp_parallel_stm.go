@@ -0,0 +1,276 @@
+package baseapp
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/cachekv"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/cachemulti"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/types"
+)
+
+// The stm scheduler executes every tx of the block supporting the parallel execution speculatively,
+// against a view of the state made of the values written by the txs before it so far. Then the txs
+// are committed in the order of the block: a tx whose values read differ from the state committed
+// so far, or which iterated over a store, is executed again against the committed state. As a tx is
+// committed only if its execution read the state left by the txs before it, the results are the same
+// as the ones of the serial execution, whatever the order the txs were executed in.
+
+type mvVersion struct {
+	txIndex int
+	value   []byte
+	deleted bool
+}
+
+type mvKey struct {
+	storeKey types.StoreKey
+	key      string
+}
+
+// mvStore is a multi-version store: for each key, the values written by the txs of a block by tx index.
+type mvStore struct {
+	mtx      sync.RWMutex
+	versions map[types.StoreKey]map[string][]mvVersion // sorted by tx index
+	written  map[int][]mvKey
+}
+
+func newMVStore() *mvStore {
+	return &mvStore{
+		versions: make(map[types.StoreKey]map[string][]mvVersion),
+		written:  make(map[int][]mvKey),
+	}
+}
+
+func (mv *mvStore) reset() {
+	mv.mtx.Lock()
+	defer mv.mtx.Unlock()
+	mv.versions = make(map[types.StoreKey]map[string][]mvVersion)
+	mv.written = make(map[int][]mvKey)
+}
+
+// record replaces the values written by the tx txIndex with the writes of rwSet.
+func (mv *mvStore) record(txIndex int, rwSet types.MsRWSet) {
+	mv.mtx.Lock()
+	defer mv.mtx.Unlock()
+
+	for _, k := range mv.written[txIndex] {
+		versions := mv.versions[k.storeKey][k.key]
+		i := searchVersion(versions, txIndex)
+		if i < len(versions) && versions[i].txIndex == txIndex {
+			mv.versions[k.storeKey][k.key] = append(versions[:i], versions[i+1:]...)
+		}
+	}
+	written := mv.written[txIndex][:0]
+
+	for storeKey, rw := range rwSet {
+		if len(rw.Write) == 0 {
+			continue
+		}
+		keys, ok := mv.versions[storeKey]
+		if !ok {
+			keys = make(map[string][]mvVersion)
+			mv.versions[storeKey] = keys
+		}
+		for key, value := range rw.Write {
+			versions := keys[key]
+			i := searchVersion(versions, txIndex)
+			versions = append(versions, mvVersion{})
+			copy(versions[i+1:], versions[i:])
+			versions[i] = mvVersion{txIndex: txIndex, value: value.Value, deleted: value.Deleted}
+			keys[key] = versions
+			written = append(written, mvKey{storeKey: storeKey, key: key})
+		}
+	}
+	mv.written[txIndex] = written
+}
+
+// read returns the last value of key written by a tx before txIndex, ok is false if none wrote it.
+func (mv *mvStore) read(storeKey types.StoreKey, key []byte, txIndex int) (value []byte, deleted bool, ok bool) {
+	mv.mtx.RLock()
+	defer mv.mtx.RUnlock()
+
+	versions := mv.versions[storeKey][string(key)]
+	i := searchVersion(versions, txIndex)
+	if i == 0 {
+		return nil, false, false
+	}
+	return versions[i-1].value, versions[i-1].deleted, true
+}
+
+// searchVersion returns the index of the first version of a tx not before txIndex.
+func searchVersion(versions []mvVersion, txIndex int) int {
+	return sort.Search(len(versions), func(i int) bool {
+		return versions[i].txIndex >= txIndex
+	})
+}
+
+// mvKVStore is the view of a store for the tx txIndex: the values written by the txs before it,
+// or the value of the parent store. It is read only, the tx writes to its cache-wrapped stores.
+// An iteration or a write through the view can not be validated, so it marks the tx as invalid.
+type mvKVStore struct {
+	mv       *mvStore
+	storeKey types.StoreKey
+	txIndex  int
+	parent   types.KVStore
+	invalid  *int32
+}
+
+var _ types.KVStore = (*mvKVStore)(nil)
+var _ types.CacheWrap = (*mvKVStore)(nil)
+
+func (s *mvKVStore) GetStoreType() types.StoreType {
+	return s.parent.GetStoreType()
+}
+
+func (s *mvKVStore) Get(key []byte) []byte {
+	types.AssertValidKey(key)
+	if value, deleted, ok := s.mv.read(s.storeKey, key, s.txIndex); ok {
+		if deleted {
+			return nil
+		}
+		return value
+	}
+	return s.parent.Get(key)
+}
+
+func (s *mvKVStore) Has(key []byte) bool {
+	return s.Get(key) != nil
+}
+
+func (s *mvKVStore) Set(_, _ []byte) {
+	atomic.StoreInt32(s.invalid, 1)
+}
+
+func (s *mvKVStore) Delete(_ []byte) {
+	atomic.StoreInt32(s.invalid, 1)
+}
+
+func (s *mvKVStore) Iterator(start, end []byte) types.Iterator {
+	atomic.StoreInt32(s.invalid, 1)
+	return s.parent.Iterator(start, end)
+}
+
+func (s *mvKVStore) ReverseIterator(start, end []byte) types.Iterator {
+	atomic.StoreInt32(s.invalid, 1)
+	return s.parent.ReverseIterator(start, end)
+}
+
+func (s *mvKVStore) IteratorCache(_ bool, _ func(key string, value []byte, isDirty bool, isDelete bool, storeKey types.StoreKey) bool, _ types.StoreKey) bool {
+	return true
+}
+
+func (s *mvKVStore) Clear() {}
+
+func (s *mvKVStore) DisableCacheReadList() {}
+
+func (s *mvKVStore) GetRWSet(_ types.MsRWSet) {}
+
+func (s *mvKVStore) Write() {
+	atomic.StoreInt32(s.invalid, 1)
+}
+
+func (s *mvKVStore) WriteWithSnapshotWSet() types.SnapshotWSet {
+	panic("cannot write a multi-version view")
+}
+
+func (s *mvKVStore) RevertDBWithSnapshotRWSet(_ types.SnapshotWSet) {
+	panic("cannot write a multi-version view")
+}
+
+func (s *mvKVStore) CacheWrap() types.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+func (s *mvKVStore) CacheWrapWithTrace(_ io.Writer, _ types.TraceContext) types.CacheWrap {
+	return s.CacheWrap()
+}
+
+// getMVStoreByTxIndex returns the view of the state for the speculative execution of the tx txIndex.
+func (pm *parallelTxManager) getMVStoreByTxIndex(txIndex int) types.CacheMultiStore {
+	cms, ok := pm.cms.(cachemulti.Store)
+	if !ok {
+		return pm.cms
+	}
+	return cms.WrapStores(func(key types.StoreKey, parent types.KVStore) types.CacheWrap {
+		return &mvKVStore{
+			mv:       pm.mvStore,
+			storeKey: key,
+			txIndex:  txIndex,
+			parent:   parent,
+			invalid:  &pm.stmInvalid[txIndex],
+		}
+	})
+}
+
+// startSTMTasks executes the txs supporting the parallel execution speculatively, in the order of
+// the block so that the txs committed first are executed first.
+func (app *BaseApp) startSTMTasks() {
+	pm := app.parallelTxManage
+
+	jobChan := make(chan int, pm.txSize)
+	for index, tx := range pm.extraTxsInfo {
+		if tx.supportPara {
+			jobChan <- index
+		}
+	}
+	close(jobChan)
+
+	pm.stmWorkers.Add(maxGoroutineNumberInParaTx)
+	for index := 0; index < maxGoroutineNumberInParaTx; index++ {
+		go func(ch chan int) {
+			defer pm.stmWorkers.Done()
+			for txIndex := range ch {
+				// skip the txs committed already
+				if pm.isTxCommitted(txIndex) {
+					continue
+				}
+				res := app.asyncDeliverTx(txIndex)
+				if res == nil {
+					continue
+				}
+				if !res.msIsNil {
+					pm.mvStore.record(txIndex, res.rwSet)
+				}
+				pm.putResult(txIndex, res)
+			}
+		}(jobChan)
+	}
+}
+
+// isSTMConflict returns whether the speculative execution of a tx read values other than the ones
+// of the state committed so far, or iterated over a store.
+func (pm *parallelTxManager) isSTMConflict(e *executeResult) bool {
+	if atomic.LoadInt32(&pm.stmInvalid[e.counter]) != 0 {
+		return true
+	}
+
+	for storeKey, rw := range e.rwSet {
+		delete(rw.Read, string(feeAccountKeyInStore))
+		delete(rw.Read, string(wasmTxCountKey))
+
+		if len(rw.Read) == 0 {
+			continue
+		}
+		store := pm.cms.GetKVStore(storeKey)
+		for key, value := range rw.Read {
+			if !bytes.Equal(store.Get([]byte(key)), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// commitSTMResult replaces the values written by the speculative execution of the tx txIndex with
+// the ones of its result committed, if it was executed again or its writes were discarded.
+func (pm *parallelTxManager) commitSTMResult(txIndex int, res *executeResult, isReRun bool) {
+	switch {
+	case res.msIsNil:
+		pm.mvStore.record(txIndex, nil)
+	case isReRun:
+		pm.mvStore.record(txIndex, res.rwSet)
+	}
+}
@@ -0,0 +1,86 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/cachekv"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/dbadapter"
+	store "github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+func newWrites(writes map[string]store.DirtyValue) store.MsRWSet {
+	rw := store.NewCacheKvRWSet()
+	for key, value := range writes {
+		rw.Write[key] = value
+	}
+	return store.MsRWSet{capKey1: rw}
+}
+
+func TestMVStore(t *testing.T) {
+	mv := newMVStore()
+	mv.record(1, newWrites(map[string]store.DirtyValue{"a": {Value: []byte("1")}}))
+	mv.record(3, newWrites(map[string]store.DirtyValue{"a": {Value: []byte("3")}, "b": {Deleted: true}}))
+
+	// a tx reads the last value written by the txs before it
+	_, _, ok := mv.read(capKey1, []byte("a"), 1)
+	require.False(t, ok)
+	value, _, ok := mv.read(capKey1, []byte("a"), 2)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+	value, _, ok = mv.read(capKey1, []byte("a"), 5)
+	require.True(t, ok)
+	require.Equal(t, []byte("3"), value)
+	_, deleted, ok := mv.read(capKey1, []byte("b"), 4)
+	require.True(t, ok)
+	require.True(t, deleted)
+	_, _, ok = mv.read(capKey2, []byte("a"), 5)
+	require.False(t, ok)
+
+	// the writes of a tx executed again replace its previous ones
+	mv.record(3, newWrites(map[string]store.DirtyValue{"a": {Value: []byte("33")}}))
+	value, _, _ = mv.read(capKey1, []byte("a"), 5)
+	require.Equal(t, []byte("33"), value)
+	_, _, ok = mv.read(capKey1, []byte("b"), 4)
+	require.False(t, ok)
+
+	mv.record(3, nil)
+	value, _, _ = mv.read(capKey1, []byte("a"), 5)
+	require.Equal(t, []byte("1"), value)
+
+	mv.reset()
+	_, _, ok = mv.read(capKey1, []byte("a"), 5)
+	require.False(t, ok)
+}
+
+func TestMVKVStore(t *testing.T) {
+	parent := cachekv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()})
+	parent.Set([]byte("a"), []byte("0"))
+	parent.Set([]byte("c"), []byte("0"))
+
+	mv := newMVStore()
+	mv.record(0, newWrites(map[string]store.DirtyValue{"a": {Value: []byte("1")}, "c": {Deleted: true}}))
+
+	var invalid int32
+	view := &mvKVStore{mv: mv, storeKey: capKey1, txIndex: 1, parent: parent, invalid: &invalid}
+	require.Equal(t, []byte("1"), view.Get([]byte("a")))
+	require.False(t, view.Has([]byte("c")))
+	require.Nil(t, view.Get([]byte("b")))
+
+	// the tx writes to its cache, which records the values read from the view
+	cache := view.CacheWrap().(*cachekv.Store)
+	require.Equal(t, []byte("1"), cache.Get([]byte("a")))
+	cache.Set([]byte("b"), []byte("2"))
+	rw := store.NewCacheKvRWSet()
+	cache.CopyRWSet(rw)
+	require.Equal(t, []byte("1"), rw.Read["a"])
+	require.Equal(t, []byte("2"), rw.Write["b"].Value)
+	require.Zero(t, invalid)
+
+	// an iteration can not be validated
+	iter := cache.Iterator(nil, nil)
+	iter.Close()
+	require.NotZero(t, invalid)
+}
@@ -3,6 +3,7 @@ package baseapp
 import (
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -36,6 +37,9 @@ type runTxInfo struct {
 
 	outOfGas        bool
 	mempoolSimulate bool // for judge this sim is from mempool
+
+	traceTxLog       bool // for trace the simulated tx
+	traceTxLogConfig []byte
 }
 
 func (info *runTxInfo) GetCacheMultiStore() (sdk.CacheMultiStore, bool) {
@@ -414,6 +418,7 @@ func (app *BaseApp) runTx_defer_recover(r interface{}, info *runTxInfo) error {
 }
 
 func (app *BaseApp) asyncDeliverTx(txIndex int) *executeResult {
+	start := time.Now()
 	pm := app.parallelTxManage
 	if app.deliverState == nil { // runTxs already finish
 		return nil
@@ -451,6 +456,7 @@ func (app *BaseApp) asyncDeliverTx(txIndex int) *executeResult {
 
 	asyncExe := newExecuteResult(resp, info.msCacheAnte, uint32(txIndex), info.ctx.ParaMsg(),
 		blockHeight, info.runMsgCtx.GetWatcher(), info.tx.GetMsgs(), app.parallelTxManage, info.ctx.GetFeeSplitInfo())
+	asyncExe.duration = time.Since(start)
 	app.parallelTxManage.addMultiCache(info.msCacheAnte, info.msCache)
 	return asyncExe
 }
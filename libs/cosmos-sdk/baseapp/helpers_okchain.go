@@ -1,6 +1,7 @@
 package baseapp
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
@@ -58,6 +59,69 @@ func (app *BaseApp) TraceTx(queryTraceTx sdk.QueryTraceTx, targetTx sdk.Tx, txIn
 	}
 	return info.result, err
 }
+
+//TraceBlock returns the trace logs for all the evm txs in the block.
+//The txs are replayed in order on the state of the parent block, the cosmos txs and
+//evm2cm txs are run without tracing to get the right state for the following evm txs.
+func (app *BaseApp) TraceBlock(queryTraceBlock sdk.QueryTraceBlock, block *tmtypes.Block) ([]sdk.QueryTraceTxResult, error) {
+	traceState, err := app.beginBlockForTracing(nil, block)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "failed to beginblock for tracing")
+	}
+
+	results := make([]sdk.QueryTraceTxResult, 0, len(block.Txs))
+	for _, txBytes := range block.Txs {
+		tx, err := app.txDecoder(txBytes, block.Height)
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "invalid tx in block")
+		}
+		if !app.isTraceableEvmTx(traceState.ctx, tx) {
+			traceState.ctx.SetIsTraceTxLog(false)
+			app.tracetx(txBytes, tx, block.Height, traceState)
+			//ignore the err when run cosmos tx
+			continue
+		}
+
+		traceState.ctx.SetIsTraceTxLog(true)
+		traceState.ctx.SetTraceTxLogConfig(queryTraceBlock.ConfigBytes)
+		info, err := app.tracetx(txBytes, tx, block.Height, traceState)
+		result := sdk.QueryTraceTxResult{
+			TxHash: common.BytesToHash(tmtypes.Tx(txBytes).Hash(block.Height)),
+		}
+		if info != nil && info.result != nil {
+			result.Result = info.result.Data
+		} else if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+//TraceCall returns the trace log for the tx simulated on the state of the given height,
+//the state overrides are applied before the tx is run
+func (app *BaseApp) TraceCall(queryTraceCall sdk.QueryTraceCall, tx sdk.Tx, height int64) (*sdk.Result, error) {
+	info := &runTxInfo{
+		overridesBytes:   queryTraceCall.OverridesBytes,
+		traceTxLog:       true,
+		traceTxLogConfig: queryTraceCall.ConfigBytes,
+	}
+	err := app.runtxWithInfo(info, runTxModeSimulate, queryTraceCall.TxBytes, tx, height, queryTraceCall.From)
+	if info.result == nil {
+		return nil, err
+	}
+	return info.result, err
+}
+
+//isTraceableEvmTx returns true if the tx is executed by evm, evm2cm txs are executed as cosmos txs
+func (app *BaseApp) isTraceableEvmTx(ctx sdk.Context, tx sdk.Tx) bool {
+	if tx.GetType() != sdk.EvmTxType {
+		return false
+	}
+	msgs := tx.GetMsgs()
+	return len(msgs) == 0 || !app.JudgeEvmConvert(ctx, msgs[0])
+}
+
 func (app *BaseApp) tracetx(txBytes []byte, tx sdk.Tx, height int64, traceState *state) (info *runTxInfo, err error) {
 
 	mode := runTxModeTrace
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/rpc/client"
 	dbm "github.com/okex/exchain/libs/tm-db"
@@ -49,6 +50,21 @@ func SetTrace(trace bool) func(*BaseApp) {
 	return func(app *BaseApp) { app.setTrace(trace) }
 }
 
+// SetSnapshotInterval sets the snapshot interval.
+func SetSnapshotInterval(interval uint64) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotInterval(interval) }
+}
+
+// SetSnapshotKeepRecent sets the recent snapshots to keep.
+func SetSnapshotKeepRecent(keepRecent uint32) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotKeepRecent(keepRecent) }
+}
+
+// SetSnapshotStore sets the snapshot store.
+func SetSnapshotStore(snapshotStore *snapshots.Store) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetSnapshotStore(snapshotStore) }
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -184,6 +200,13 @@ func (app *BaseApp) SetParallelTxLogHandlers(fixLog sdk.LogFix) {
 	app.logFix = fixLog
 }
 
+func (app *BaseApp) SetParallelTxsStatsHandler(handler sdk.ParallelTxsStatsHandler) {
+	if app.sealed {
+		panic("SetParallelTxsStatsHandler() on sealed BaseApp")
+	}
+	app.parallelTxsStatsHandler = handler
+}
+
 func (app *BaseApp) SetUpdateWasmTxCount(d sdk.UpdateCosmosTxCount) {
 	app.updateCosmosTxCount = d
 }
@@ -209,6 +232,41 @@ func (app *BaseApp) SetMptCommitHandler(mch sdk.MptCommitHandler) {
 	app.mptCommitHandler = mch
 }
 
+func (app *BaseApp) SetRestoreHandler(rh sdk.RestoreHandler) {
+	if app.sealed {
+		panic("SetRestoreHandler() on sealed BaseApp")
+	}
+	app.restoreHandler = rh
+}
+
+// SetSnapshotStore sets the snapshot store.
+func (app *BaseApp) SetSnapshotStore(snapshotStore *snapshots.Store) {
+	if app.sealed {
+		panic("SetSnapshotStore() on sealed BaseApp")
+	}
+	if snapshotStore == nil {
+		app.snapshotManager = nil
+		return
+	}
+	app.snapshotManager = snapshots.NewManager(snapshotStore, app.cms)
+}
+
+// SetSnapshotInterval sets the snapshot interval.
+func (app *BaseApp) SetSnapshotInterval(snapshotInterval uint64) {
+	if app.sealed {
+		panic("SetSnapshotInterval() on sealed BaseApp")
+	}
+	app.snapshotInterval = snapshotInterval
+}
+
+// SetSnapshotKeepRecent sets the number of recent snapshots to keep.
+func (app *BaseApp) SetSnapshotKeepRecent(snapshotKeepRecent uint32) {
+	if app.sealed {
+		panic("SetSnapshotKeepRecent() on sealed BaseApp")
+	}
+	app.snapshotKeepRecent = snapshotKeepRecent
+}
+
 func (app *BaseApp) SetPreDeliverTxHandler(handler sdk.PreDeliverTxHandler) {
 	if app.sealed {
 		panic("SetPreDeliverTxHandler() on sealed BaseApp")
@@ -223,6 +281,14 @@ func (app *BaseApp) SetPartialConcurrentHandlers(etf sdk.GetTxFeeAndFromHandler)
 	app.getTxFeeAndFromHandler = etf
 }
 
+func (app *BaseApp) SetParallelTxConflictHandlers(al sdk.GetTxAccessListHandler, dsk sdk.DecodeStorageKeyHandler) {
+	if app.sealed {
+		panic("SetParallelTxConflictHandlers() on sealed BaseApp")
+	}
+	app.getTxAccessListHandler = al
+	app.decodeStorageKeyHandler = dsk
+}
+
 func (app *BaseApp) SetGetTxFeeHandler(handler sdk.GetTxFeeHandler) {
 	if app.sealed {
 		panic("SetGetTxFeeHandler() on sealed BaseApp")
@@ -235,3 +235,11 @@ func (ms multiStore) GetCommitVersion() (int64, error) {
 func (ms multiStore) CommitterCommitMap(inputDeltaMap iavl.TreeDeltaMap) (sdk.CommitID, iavl.TreeDeltaMap) {
 	panic("not implemented")
 }
+
+func (ms multiStore) Snapshot(height uint64, format uint32, w io.Writer) error {
+	panic("not implemented")
+}
+
+func (ms multiStore) Restore(height uint64, format uint32, r io.Reader) error {
+	panic("not implemented")
+}
@@ -74,6 +74,9 @@ const (
 
 	FlagEventBlockTime    = "event-block-time"
 	FlagStartFromSnapshot = "start-from-snapshot"
+
+	FlagStateSyncSnapshotInterval   = "state-sync.snapshot-interval"
+	FlagStateSyncSnapshotKeepRecent = "state-sync.snapshot-keep-recent"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -342,6 +345,7 @@ func SetExternalPackageValue(cmd *cobra.Command) {
 
 	tmtypes.DownloadDelta = viper.GetBool(tmtypes.FlagDownloadDDS)
 	tmtypes.UploadDelta = viper.GetBool(tmtypes.FlagUploadDDS)
+	tmtypes.DeltaBrokerType = viper.GetString(tmtypes.FlagDeltaBroker)
 	tmtypes.FastQuery = viper.GetBool(tmtypes.FlagFastQuery)
 	tmtypes.DeltaVersion = viper.GetInt(tmtypes.FlagDeltaVersion)
 	tmtypes.BlockCompressType = viper.GetInt(tmtypes.FlagBlockCompressType)
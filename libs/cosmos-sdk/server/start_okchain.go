@@ -181,6 +181,8 @@ func RegisterServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(FlagEvmImportMode, "default", "Select import mode for evm state (default|files|db)")
 	cmd.Flags().String(FlagEvmImportPath, "", "Evm contract & storage db or files used for InitGenesis")
 	cmd.Flags().Uint64(FlagGoroutineNum, 0, "Limit on the number of goroutines used to import evm data(ignored if evm-import-mode is 'default')")
+	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval in blocks, 0 disables snapshots")
+	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "State sync snapshots to keep, 0 keeps all of them")
 
 	cmd.Flags().Bool(tmtypes.FlagDownloadDDS, false, "Download delta")
 	cmd.Flags().Bool(tmtypes.FlagUploadDDS, false, "Upload delta")
@@ -189,6 +191,9 @@ func RegisterServerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(tmtypes.FlagRedisAuth, "", "redis auth")
 	cmd.Flags().Int(tmtypes.FlagRedisExpire, 300, "delta expiration time. unit is second")
 	cmd.Flags().Int(tmtypes.FlagRedisDB, 0, "delta db num")
+	cmd.Flags().String(tmtypes.FlagDeltaBroker, tmtypes.DeltaBrokerRedis, "delta broker. redis|file|p2p")
+	cmd.Flags().String(tmtypes.FlagDeltaBrokerDir, "", "shared directory of the deltas for the file delta broker")
+	cmd.Flags().String(tmtypes.FlagDeltaTrustedPeers, "", "comma separated IDs of the peers to download deltas from for the p2p delta broker")
 	cmd.Flags().Int(tmtypes.FlagDDSCompressType, 0, "delta compress type. 0|1|2|3")
 	cmd.Flags().Int(tmtypes.FlagDDSCompressFlag, 0, "delta compress flag. 0|1|2")
 	cmd.Flags().Int(tmtypes.FlagBufferSize, 10, "delta buffer size")
@@ -251,6 +256,8 @@ func RegisterServerFlags(cmd *cobra.Command) *cobra.Command {
 
 	cmd.Flags().Int(state.FlagDeliverTxsExecMode, 0, "Execution mode for deliver txs, (0:serial[default], 1:deprecated, 2:parallel)")
 	cmd.Flags().Bool(state.FlagEnableConcurrency, false, "Enable concurrency for deliver txs")
+	cmd.Flags().String(state.FlagParallelScheduler, state.ParallelSchedulerGroup, "Scheduler of the parallel execution mode, (group[default]:txs grouped by sender and receiver, stm:speculative execution with read set validation)")
+	cmd.Flags().Bool(state.FlagParallelConflictPrediction, false, "Group the evm txs of the group scheduler by their access lists and the storage slots written by the previous txs to the same contracts")
 
 	cmd.Flags().String(FlagListenAddr, "tcp://0.0.0.0:26659", "EVM RPC and cosmos-sdk REST API listen address.")
 	cmd.Flags().String(FlagUlockKey, "", "Select the keys to unlock on the RPC server")
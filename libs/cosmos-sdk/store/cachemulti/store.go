@@ -92,6 +92,23 @@ func NewStore(
 	return NewFromKVStore(dbadapter.Store{DB: db}, stores, keys, traceWriter, traceContext)
 }
 
+// WrapStores returns a Store sharing the database of cms whose substores are replaced by the
+// result of wrap, without cache-wrapping them. The Store returned is meant to be the parent of
+// the cache-wrapped stores of a tx, e.g. a view of the state at a given tx of a block.
+func (cms Store) WrapStores(wrap func(key types.StoreKey, parent types.KVStore) types.CacheWrap) Store {
+	stores := make(map[types.StoreKey]types.CacheWrap, len(cms.stores))
+	for key, store := range cms.stores {
+		stores[key] = wrap(key, store.(types.KVStore))
+	}
+	return Store{
+		db:           cms.db,
+		stores:       stores,
+		keys:         cms.keys,
+		traceWriter:  cms.traceWriter,
+		traceContext: cms.traceContext,
+	}
+}
+
 func newCacheMultiStoreFromCMS(cms Store) Store {
 	return newFromKVStore(cms.db, cms.stores, nil, cms.traceWriter, cms.traceContext)
 }
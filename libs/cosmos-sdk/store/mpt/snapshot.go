@@ -0,0 +1,240 @@
+package mpt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	ethstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// Kinds of the items of an mpt snapshot, see snapshottypes.SnapshotMptItem.
+const (
+	SnapshotAccRoot  uint32 = iota + 1 // root hash of the account trie
+	SnapshotEvmRoot                    // root hash of the trie holding the contract storage roots
+	SnapshotNode                       // rlp encoded trie node, keyed by its keccak hash
+	SnapshotCode                       // contract code, keyed by its keccak hash
+	SnapshotPreimage                   // preimage of a secure trie key, keyed by its keccak hash
+)
+
+// Export writes the account trie and the contract storage tries of the given height into the
+// snapshot stream. Only the roots are written as-is, every other item is content addressed so
+// the importer can verify it by hashing.
+func (ms *MptStore) Export(height int64, w io.Writer) error {
+	accRoot := ms.GetMptRootHash(uint64(height))
+	if accRoot == NilHash {
+		return fmt.Errorf("mpt export failed: no account root at height %d", height)
+	}
+	evmRoot := ms.getEvmRootHash(uint64(height))
+	if evmRoot == NilHash {
+		evmRoot = EmptyRootHash
+	}
+
+	// keep both tries alive in the trie database until the export is done, otherwise the
+	// garbage collection of the committing goroutine may drop their dirty nodes
+	triedb := ms.db.TrieDB()
+	triedb.Reference(accRoot, ethcmn.Hash{})
+	defer triedb.Dereference(accRoot)
+	triedb.Reference(evmRoot, ethcmn.Hash{})
+	defer triedb.Dereference(evmRoot)
+
+	if err := writeMptItem(w, SnapshotAccRoot, accRoot.Bytes()); err != nil {
+		return err
+	}
+	if err := writeMptItem(w, SnapshotEvmRoot, evmRoot.Bytes()); err != nil {
+		return err
+	}
+
+	if err := walkTrie(ms.db, accRoot, w, nil); err != nil {
+		return err
+	}
+	storageRoots := make(map[ethcmn.Hash]struct{})
+	err := walkTrie(ms.db, evmRoot, w, func(leaf []byte) error {
+		root := ethcmn.BytesToHash(leaf)
+		if root == EmptyRootHash {
+			return nil
+		}
+		if _, ok := storageRoots[root]; ok {
+			return nil
+		}
+		storageRoots[root] = struct{}{}
+		return walkTrie(ms.db, root, w, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	// contract code is never pruned from the database, so exporting all of it covers every
+	// code hash referenced by the accounts at this height
+	it := triedb.DiskDB().NewIterator(rawdb.CodePrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		if ok, _ := rawdb.IsCodeKey(it.Key()); !ok {
+			continue
+		}
+		if err := writeMptItem(w, SnapshotCode, it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// walkTrie visits every node of the trie with the given root, writing the hashed nodes and the
+// key preimages into w when it is not nil, and calling onLeaf for every leaf value. A missing
+// node aborts the walk with an error.
+func walkTrie(db ethstate.Database, root ethcmn.Hash, w io.Writer, onLeaf func(leaf []byte) error) error {
+	if root == EmptyRootHash || root == NilHash {
+		return nil
+	}
+	tr, err := db.OpenTrie(root)
+	if err != nil {
+		return err
+	}
+	triedb := db.TrieDB()
+	it := tr.NodeIterator(nil)
+	for it.Next(true) {
+		if hash := it.Hash(); w != nil && hash != NilHash {
+			blob, err := triedb.Node(hash)
+			if err != nil {
+				return fmt.Errorf("failed to read trie node %x: %w", hash, err)
+			}
+			if err := writeMptItem(w, SnapshotNode, blob); err != nil {
+				return err
+			}
+		}
+		if !it.Leaf() {
+			continue
+		}
+		if w != nil {
+			if preimage := tr.GetKey(it.LeafKey()); preimage != nil {
+				if err := writeMptItem(w, SnapshotPreimage, preimage); err != nil {
+					return err
+				}
+			}
+		}
+		if onLeaf != nil {
+			if err := onLeaf(it.LeafBlob()); err != nil {
+				return err
+			}
+		}
+	}
+	return it.Error()
+}
+
+func writeMptItem(w io.Writer, kind uint32, data []byte) error {
+	return snapshottypes.WriteItem(w, &snapshottypes.SnapshotItem{
+		MPT: &snapshottypes.SnapshotMptItem{Kind: kind, Data: data},
+	})
+}
+
+// Importer restores the tries exported by MptStore.Export into an empty mpt database. It is
+// created by MptStore.Import, items must be added in the exported order and Commit must be
+// called at the end.
+type Importer struct {
+	ms      *MptStore
+	height  int64
+	batch   ethdb.Batch
+	accRoot ethcmn.Hash
+	evmRoot ethcmn.Hash
+}
+
+// Import creates an Importer restoring the tries at the given height.
+func (ms *MptStore) Import(height int64) (*Importer, error) {
+	if latest := ms.GetLatestStoredBlockHeight(); latest > 0 {
+		return nil, fmt.Errorf("mpt import failed: found database at height %d, must be 0", latest)
+	}
+	return &Importer{
+		ms:     ms,
+		height: height,
+		batch:  ms.db.TrieDB().DiskDB().NewBatch(),
+	}, nil
+}
+
+// Add adds a snapshot item to the import, flushing the pending writes once the batch is large
+// enough.
+func (i *Importer) Add(item *snapshottypes.SnapshotMptItem) error {
+	switch item.Kind {
+	case SnapshotAccRoot, SnapshotEvmRoot:
+		if len(item.Data) != ethcmn.HashLength {
+			return fmt.Errorf("invalid mpt root length %d", len(item.Data))
+		}
+		if item.Kind == SnapshotAccRoot {
+			i.accRoot = ethcmn.BytesToHash(item.Data)
+		} else {
+			i.evmRoot = ethcmn.BytesToHash(item.Data)
+		}
+		return nil
+	case SnapshotNode:
+		rawdb.WriteTrieNode(i.batch, crypto.Keccak256Hash(item.Data), item.Data)
+	case SnapshotCode:
+		rawdb.WriteCode(i.batch, crypto.Keccak256Hash(item.Data), item.Data)
+	case SnapshotPreimage:
+		rawdb.WritePreimages(i.batch, map[ethcmn.Hash][]byte{crypto.Keccak256Hash(item.Data): item.Data})
+	default:
+		return fmt.Errorf("unknown mpt snapshot item kind %d", item.Kind)
+	}
+
+	if i.batch.ValueSize() >= ethdb.IdealBatchSize {
+		if err := i.batch.Write(); err != nil {
+			return err
+		}
+		i.batch.Reset()
+	}
+	return nil
+}
+
+// Commit flushes the imported items, checks that the tries are complete and records their
+// roots as the latest stored height.
+func (i *Importer) Commit() error {
+	if i.accRoot == NilHash || i.evmRoot == NilHash {
+		return fmt.Errorf("mpt import failed: missing trie roots")
+	}
+	if err := i.batch.Write(); err != nil {
+		return err
+	}
+	i.batch.Reset()
+
+	// the trie nodes are content addressed, so walking all the tries is enough to prove the
+	// import holds exactly the state committed by the roots
+	db := i.ms.db
+	if err := walkTrie(db, i.accRoot, nil, nil); err != nil {
+		return fmt.Errorf("incomplete account trie: %w", err)
+	}
+	storageRoots := make(map[ethcmn.Hash]struct{})
+	err := walkTrie(db, i.evmRoot, nil, func(leaf []byte) error {
+		root := ethcmn.BytesToHash(leaf)
+		if _, ok := storageRoots[root]; ok {
+			return nil
+		}
+		storageRoots[root] = struct{}{}
+		return walkTrie(db, root, nil, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("incomplete contract storage trie: %w", err)
+	}
+
+	height := uint64(i.height)
+	i.ms.SetMptRootHash(height, i.accRoot)
+	i.ms.SetLatestStoredBlockHeight(height)
+	diskdb := db.TrieDB().DiskDB()
+	if err := diskdb.Put(append(KeyPrefixEvmRootMptHash, sdk.Uint64ToBigEndian(height)...), i.evmRoot.Bytes()); err != nil {
+		return err
+	}
+	return diskdb.Put(KeyPrefixEvmLatestStoredHeight, sdk.Uint64ToBigEndian(height))
+}
+
+// getEvmRootHash gets the root hash of the contract storage root trie at the given height, it is
+// maintained by the evm keeper in the same database.
+func (ms *MptStore) getEvmRootHash(height uint64) ethcmn.Hash {
+	rst, err := ms.db.TrieDB().DiskDB().Get(append(KeyPrefixEvmRootMptHash, sdk.Uint64ToBigEndian(height)...))
+	if err != nil || len(rst) == 0 || bytes.Equal(rst, NilHash.Bytes()) {
+		return NilHash
+	}
+	return ethcmn.BytesToHash(rst)
+}
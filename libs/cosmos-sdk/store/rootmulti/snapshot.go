@@ -0,0 +1,289 @@
+package rootmulti
+
+import (
+	"bufio"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/iavl"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/mpt"
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	iavltree "github.com/okex/exchain/libs/iavl"
+)
+
+const (
+	// snapshotBufferSize is the buffer size of the snapshot stream
+	snapshotBufferSize = 4e6
+
+	// snapshotMaxItemSize is the max size of a single snapshot item, 64 MB
+	snapshotMaxItemSize = int64(64e6)
+)
+
+// Snapshot implements snapshottypes.Snapshotter. It writes every store that contributes to the
+// app hash of the given height as a zlib compressed stream of length-prefixed SnapshotItems: a
+// SnapshotStoreItem opens each store, followed by its IAVL nodes or mpt items. The output for a
+// given format must be identical across nodes such that chunks from different sources fit
+// together, so the format must be bumped whenever it changes.
+func (rs *Store) Snapshot(height uint64, format uint32, w io.Writer) error {
+	if format != snapshottypes.CurrentFormat {
+		return sdkerrors.Wrapf(snapshottypes.ErrUnknownFormat, "format %v", format)
+	}
+	if height == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrLogic, "cannot snapshot height 0")
+	}
+	if height > uint64(rs.LastCommitVersion()) {
+		return sdkerrors.Wrapf(sdkerrors.ErrLogic, "cannot snapshot future height %v", height)
+	}
+
+	// The stores taking part in the app hash change with the milestones, so the commit info of the
+	// height tells which ones to export.
+	cInfo, err := getCommitInfo(rs.db, int64(height))
+	if err != nil {
+		return err
+	}
+	infos := make([]storeInfo, len(cInfo.StoreInfos))
+	copy(infos, cInfo.StoreInfos)
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	zWriter, err := zlib.NewWriterLevel(w, 7)
+	if err != nil {
+		return sdkerrors.Wrap(err, "zlib failure")
+	}
+	bufWriter := bufio.NewWriterSize(zWriter, snapshotBufferSize)
+
+	for _, info := range infos {
+		store := rs.getStoreByName(info.Name)
+		if store == nil {
+			return fmt.Errorf("store %q of the commit info at height %v is not mounted", info.Name, height)
+		}
+		version := info.Core.CommitID.Version
+		err = snapshottypes.WriteItem(bufWriter, &snapshottypes.SnapshotItem{
+			Store: &snapshottypes.SnapshotStoreItem{Name: info.Name, Version: version},
+		})
+		if err != nil {
+			return err
+		}
+
+		switch store := store.(type) {
+		case *iavl.Store:
+			err = snapshotIAVL(store, version, bufWriter)
+		case *mpt.MptStore:
+			err = store.Export(version, bufWriter)
+		default:
+			err = fmt.Errorf("don't know how to snapshot store %q of type %T", info.Name, store)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = bufWriter.Flush(); err != nil {
+		return err
+	}
+	return zWriter.Close()
+}
+
+func snapshotIAVL(store *iavl.Store, version int64, w io.Writer) error {
+	exporter, err := store.Export(version)
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+
+	for {
+		node, err := exporter.Next()
+		if err == iavltree.ExportDone {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		err = snapshottypes.WriteItem(w, &snapshottypes.SnapshotItem{
+			IAVL: &snapshottypes.SnapshotIAVLItem{
+				Key:     node.Key,
+				Value:   node.Value,
+				Version: node.Version,
+				Height:  int32(node.Height),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// storeImporter is the importer of the store being restored.
+type storeImporter interface {
+	add(item *snapshottypes.SnapshotItem) error
+	commit() error
+	close()
+}
+
+type iavlImporter struct{ *iavltree.Importer }
+
+func (i iavlImporter) add(item *snapshottypes.SnapshotItem) error {
+	if item.IAVL == nil {
+		return sdkerrors.Wrap(snapshottypes.ErrInvalidMetadata, "unexpected item in IAVL store")
+	}
+	if item.IAVL.Height > 127 || item.IAVL.Height < 0 {
+		return sdkerrors.Wrapf(snapshottypes.ErrInvalidMetadata, "node height %v out of range", item.IAVL.Height)
+	}
+	node := &iavltree.ExportNode{
+		Key:     item.IAVL.Key,
+		Value:   item.IAVL.Value,
+		Height:  int8(item.IAVL.Height),
+		Version: item.IAVL.Version,
+	}
+	// Amino decodes empty byte slices as nil, but IAVL allows neither nil keys nor nil values
+	// for leaf nodes, so they can always be set back to empty.
+	if node.Key == nil {
+		node.Key = []byte{}
+	}
+	if node.Height == 0 && node.Value == nil {
+		node.Value = []byte{}
+	}
+	return i.Add(node)
+}
+
+func (i iavlImporter) commit() error { return i.Commit() }
+func (i iavlImporter) close()        { i.Close() }
+
+type mptImporter struct{ *mpt.Importer }
+
+func (i mptImporter) add(item *snapshottypes.SnapshotItem) error {
+	if item.MPT == nil {
+		return sdkerrors.Wrap(snapshottypes.ErrInvalidMetadata, "unexpected item in mpt store")
+	}
+	return i.Add(item.MPT)
+}
+
+func (i mptImporter) commit() error { return i.Commit() }
+func (i mptImporter) close()        {}
+
+// Restore implements snapshottypes.Snapshotter. It imports the stores of the snapshot into the
+// empty multistore, rebuilds the commit info of the height from the imported stores and loads
+// it as the latest version, so the app hash reported afterwards is computed from the restored
+// data and not taken from the snapshot.
+func (rs *Store) Restore(height uint64, format uint32, r io.Reader) error {
+	if format != snapshottypes.CurrentFormat {
+		return sdkerrors.Wrapf(snapshottypes.ErrUnknownFormat, "format %v", format)
+	}
+	if height == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrLogic, "cannot restore snapshot at height 0")
+	}
+	if len(rs.lastCommitInfo.StoreInfos) > 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrLogic, "cannot restore snapshot into store at version %v",
+			rs.lastCommitInfo.Version)
+	}
+
+	zReader, err := zlib.NewReader(r)
+	if err != nil {
+		return sdkerrors.Wrap(err, "zlib failure")
+	}
+	defer zReader.Close()
+	bufReader := bufio.NewReaderSize(zReader, snapshotBufferSize)
+
+	var (
+		importer   storeImporter
+		importName string
+		storeInfos []storeInfo
+	)
+	finish := func() error {
+		if importer == nil {
+			return nil
+		}
+		defer importer.close()
+		if err := importer.commit(); err != nil {
+			return sdkerrors.Wrapf(err, "failed to import store %q", importName)
+		}
+		importer = nil
+		return nil
+	}
+	defer func() {
+		if importer != nil {
+			importer.close()
+		}
+	}()
+
+	var item snapshottypes.SnapshotItem
+	for {
+		err = snapshottypes.ReadItem(bufReader, &item, snapshotMaxItemSize)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return sdkerrors.Wrap(err, "invalid snapshot item")
+		}
+
+		if item.Store == nil {
+			if importer == nil {
+				return sdkerrors.Wrap(snapshottypes.ErrInvalidMetadata, "received item before store")
+			}
+			if err = importer.add(&item); err != nil {
+				return sdkerrors.Wrapf(err, "failed to import item of store %q", importName)
+			}
+			continue
+		}
+
+		if err = finish(); err != nil {
+			return err
+		}
+		importName = item.Store.Name
+		version := item.Store.Version
+		if version <= 0 || version > int64(height) {
+			return sdkerrors.Wrapf(snapshottypes.ErrInvalidMetadata, "invalid version %v of store %q", version, importName)
+		}
+		switch store := rs.getStoreByName(importName).(type) {
+		case *iavl.Store:
+			i, err := store.Import(version)
+			if err != nil {
+				return sdkerrors.Wrapf(err, "failed to import store %q", importName)
+			}
+			importer = iavlImporter{i}
+		case *mpt.MptStore:
+			i, err := store.Import(version)
+			if err != nil {
+				return sdkerrors.Wrapf(err, "failed to import store %q", importName)
+			}
+			importer = mptImporter{i}
+		default:
+			return sdkerrors.Wrapf(snapshottypes.ErrInvalidMetadata, "cannot restore store %q of type %T", importName, store)
+		}
+		storeInfos = append(storeInfos, storeInfo{
+			Name: importName,
+			Core: storeCore{CommitID: types.CommitID{Version: version}},
+		})
+	}
+	if err = finish(); err != nil {
+		return err
+	}
+
+	// the old mpt stores must release their prefetchers before the stores are reloaded
+	for _, store := range rs.stores {
+		if ms, ok := store.(*mpt.MptStore); ok {
+			if err = ms.OnStop(); err != nil {
+				return err
+			}
+		}
+	}
+
+	cInfo := commitInfo{Version: int64(height), StoreInfos: storeInfos}
+	flushMetadata(rs.db, int64(height), cInfo, []int64{}, []int64{int64(height)})
+	if err = rs.LoadLatestVersion(); err != nil {
+		return err
+	}
+
+	// fill in the commit ids of the reloaded stores, which are what the app hash is built from
+	for i, info := range storeInfos {
+		store := rs.getStoreByName(info.Name).(types.CommitKVStore)
+		storeInfos[i].Core.CommitID = store.LastCommitID()
+	}
+	cInfo = commitInfo{Version: int64(height), StoreInfos: storeInfos}
+	flushMetadata(rs.db, int64(height), cInfo, []int64{}, []int64{int64(height)})
+	rs.lastCommitInfo = cInfo
+	return nil
+}
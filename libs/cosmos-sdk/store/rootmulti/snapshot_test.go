@@ -0,0 +1,75 @@
+package rootmulti
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/store/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+func newMultiStoreWithData(t *testing.T, db dbm.DB, versions int) *Store {
+	store := newMultiStoreWithMounts(db, types.PruneNothing)
+	require.NoError(t, store.LoadLatestVersion())
+
+	for v := 0; v < versions; v++ {
+		for _, name := range []string{"store1", "store2", "store3"} {
+			kv := store.getStoreByName(name).(types.KVStore)
+			for i := 0; i < 10; i++ {
+				kv.Set([]byte(fmt.Sprintf("%s-key%d", name, i)), []byte(fmt.Sprintf("value%d-%d", v, i)))
+			}
+		}
+		store.CommitterCommitMap(nil)
+	}
+	return store
+}
+
+func TestMultiStoreSnapshotRestore(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus1Height(-1)
+	source := newMultiStoreWithData(t, dbm.NewMemDB(), 3)
+	height := uint64(source.LastCommitID().Version)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, source.Snapshot(height, snapshottypes.CurrentFormat, buf))
+
+	target := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, target.LoadLatestVersion())
+	require.NoError(t, target.Restore(height, snapshottypes.CurrentFormat, bytes.NewReader(buf.Bytes())))
+
+	require.Equal(t, source.LastCommitID(), target.LastCommitID())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		expected := source.getStoreByName(name).(types.KVStore)
+		got := target.getStoreByName(name).(types.KVStore)
+		for i := 0; i < 10; i++ {
+			key := []byte(fmt.Sprintf("%s-key%d", name, i))
+			require.Equal(t, expected.Get(key), got.Get(key))
+		}
+	}
+
+	// a restored store is not empty anymore
+	err := target.Restore(height, snapshottypes.CurrentFormat, bytes.NewReader(buf.Bytes()))
+	require.Error(t, err)
+}
+
+func TestMultiStoreSnapshotErrors(t *testing.T) {
+	tmtypes.UnittestOnlySetMilestoneVenus1Height(-1)
+	store := newMultiStoreWithData(t, dbm.NewMemDB(), 2)
+
+	buf := new(bytes.Buffer)
+	err := store.Snapshot(2, snapshottypes.CurrentFormat+1, buf)
+	require.True(t, errors.Is(err, snapshottypes.ErrUnknownFormat))
+	require.Error(t, store.Snapshot(0, snapshottypes.CurrentFormat, buf))
+	require.Error(t, store.Snapshot(3, snapshottypes.CurrentFormat, buf))
+
+	target := newMultiStoreWithMounts(dbm.NewMemDB(), types.PruneNothing)
+	require.NoError(t, target.LoadLatestVersion())
+	err = target.Restore(2, snapshottypes.CurrentFormat+1, buf)
+	require.True(t, errors.Is(err, snapshottypes.ErrUnknownFormat))
+	require.Error(t, target.Restore(2, snapshottypes.CurrentFormat, bytes.NewReader([]byte("garbage"))))
+}
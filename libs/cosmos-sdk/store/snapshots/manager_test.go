@@ -0,0 +1,142 @@
+package snapshots
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+// mockSnapshotter writes its data as the snapshot and reads the restored data back.
+type mockSnapshotter struct {
+	data []byte
+}
+
+func (m *mockSnapshotter) Snapshot(height uint64, format uint32, w io.Writer) error {
+	if format != types.CurrentFormat {
+		return types.ErrUnknownFormat
+	}
+	_, err := w.Write(m.data)
+	return err
+}
+
+func (m *mockSnapshotter) Restore(height uint64, format uint32, r io.Reader) error {
+	if format != types.CurrentFormat {
+		return types.ErrUnknownFormat
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.data = data
+	return nil
+}
+
+func setupStore(t *testing.T) *Store {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	store, err := NewStore(dbm.NewMemDB(), dir)
+	require.NoError(t, err)
+	return store
+}
+
+func TestManagerCreateRestore(t *testing.T) {
+	data := bytes.Repeat([]byte{1, 2, 3}, int(snapshotChunkSize)/2)
+	source := NewManager(setupStore(t), &mockSnapshotter{data: data})
+
+	snapshot, err := source.Create(5)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, snapshot.Height)
+	require.EqualValues(t, types.CurrentFormat, snapshot.Format)
+	require.EqualValues(t, 2, snapshot.Chunks)
+	require.Len(t, snapshot.Metadata.ChunkHashes, 2)
+
+	// snapshots are only taken above the latest one
+	_, err = source.Create(5)
+	require.True(t, errors.Is(err, types.ErrConflict))
+
+	list, err := source.List()
+	require.NoError(t, err)
+	require.Equal(t, []*types.Snapshot{snapshot}, list)
+
+	target := &mockSnapshotter{}
+	manager := NewManager(setupStore(t), target)
+	require.NoError(t, manager.Restore(*snapshot))
+
+	// only one operation runs at a time
+	_, err = manager.Prune(1)
+	require.True(t, errors.Is(err, types.ErrConflict))
+
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := source.LoadChunk(snapshot.Height, snapshot.Format, i)
+		require.NoError(t, err)
+		done, err := manager.RestoreChunk(chunk)
+		require.NoError(t, err)
+		require.Equal(t, i == snapshot.Chunks-1, done)
+	}
+	require.Equal(t, data, target.data)
+
+	// the restore is over, another operation can start
+	_, err = manager.Prune(1)
+	require.NoError(t, err)
+}
+
+func TestManagerRestoreErrors(t *testing.T) {
+	source := NewManager(setupStore(t), &mockSnapshotter{data: []byte{1, 2, 3}})
+	snapshot, err := source.Create(1)
+	require.NoError(t, err)
+
+	manager := NewManager(setupStore(t), &mockSnapshotter{})
+	_, err = manager.RestoreChunk([]byte{1})
+	require.Error(t, err)
+
+	invalid := *snapshot
+	invalid.Format = types.CurrentFormat + 1
+	require.True(t, errors.Is(manager.Restore(invalid), types.ErrUnknownFormat))
+
+	invalid = *snapshot
+	invalid.Chunks = 2
+	require.True(t, errors.Is(manager.Restore(invalid), types.ErrInvalidMetadata))
+
+	require.NoError(t, manager.Restore(*snapshot))
+	_, err = manager.RestoreChunk([]byte{4, 5, 6})
+	require.True(t, errors.Is(err, types.ErrChunkHashMismatch))
+
+	chunk, err := source.LoadChunk(snapshot.Height, snapshot.Format, 0)
+	require.NoError(t, err)
+	done, err := manager.RestoreChunk(chunk)
+	require.NoError(t, err)
+	require.True(t, done)
+}
+
+func TestStorePrune(t *testing.T) {
+	store := setupStore(t)
+	manager := NewManager(store, &mockSnapshotter{data: []byte{1, 2, 3}})
+	for height := uint64(1); height <= 4; height++ {
+		_, err := manager.Create(height)
+		require.NoError(t, err)
+	}
+
+	pruned, err := manager.Prune(2)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, pruned)
+
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.EqualValues(t, 4, list[0].Height)
+	require.EqualValues(t, 3, list[1].Height)
+
+	chunk, err := manager.LoadChunk(1, types.CurrentFormat, 0)
+	require.NoError(t, err)
+	require.Nil(t, chunk)
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+)
+
+var cdc = codec.New()
+
+// Snapshot contains Tendermint state sync snapshot info.
+type Snapshot struct {
+	Height   uint64   `json:"height"`
+	Format   uint32   `json:"format"`
+	Chunks   uint32   `json:"chunks"`
+	Hash     []byte   `json:"hash"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Metadata contains SDK-specific snapshot metadata.
+type Metadata struct {
+	// SHA-256 chunk hashes
+	ChunkHashes [][]byte `json:"chunk_hashes"`
+}
+
+// SnapshotFromABCI converts an ABCI snapshot to a snapshot. Mainly to decode the SDK metadata.
+func SnapshotFromABCI(in *abci.Snapshot) (Snapshot, error) {
+	snapshot := Snapshot{
+		Height: in.Height,
+		Format: in.Format,
+		Chunks: in.Chunks,
+		Hash:   in.Hash,
+	}
+	err := cdc.UnmarshalBinaryBare(in.Metadata, &snapshot.Metadata)
+	if err != nil {
+		return Snapshot{}, sdkerrors.Wrap(err, "failed to unmarshal snapshot metadata")
+	}
+	return snapshot, nil
+}
+
+// ToABCI converts a Snapshot to its ABCI representation. Mainly to encode the SDK metadata.
+func (s Snapshot) ToABCI() (abci.Snapshot, error) {
+	out := abci.Snapshot{
+		Height: s.Height,
+		Format: s.Format,
+		Chunks: s.Chunks,
+		Hash:   s.Hash,
+	}
+	var err error
+	out.Metadata, err = cdc.MarshalBinaryBare(s.Metadata)
+	if err != nil {
+		return abci.Snapshot{}, sdkerrors.Wrap(err, "failed to marshal snapshot metadata")
+	}
+	return out, nil
+}
+
+// MarshalSnapshot encodes the snapshot into bytes
+func MarshalSnapshot(s *Snapshot) ([]byte, error) {
+	return cdc.MarshalBinaryBare(s)
+}
+
+// UnmarshalSnapshot decodes the snapshot from bytes
+func UnmarshalSnapshot(bz []byte) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := cdc.UnmarshalBinaryBare(bz, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
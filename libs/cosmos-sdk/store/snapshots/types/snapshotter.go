@@ -0,0 +1,63 @@
+package types
+
+import (
+	"io"
+)
+
+// CurrentFormat is the currently used format for snapshots. Snapshots using the same format
+// must be identical across all nodes for a given height, so this must be bumped when the binary
+// snapshot output changes.
+const CurrentFormat uint32 = 1
+
+// Snapshotter is something that can create and restore snapshots, consisting of streamed binary
+// chunks - all of which must be read from the channel and closed. If an unsupported format is
+// given, it must return ErrUnknownFormat (possibly wrapped with fmt.Errorf).
+type Snapshotter interface {
+	// Snapshot writes a snapshot of the state at the given height into the writer, using the given format.
+	Snapshot(height uint64, format uint32, w io.Writer) error
+
+	// Restore restores the state at the given height from the snapshot read from the reader,
+	// using the given format. It must only be called on an empty state.
+	Restore(height uint64, format uint32, r io.Reader) error
+}
+
+// SnapshotItem is an item contained in a rootmulti.Store snapshot, exactly one of the fields is set.
+type SnapshotItem struct {
+	Store *SnapshotStoreItem `json:"store,omitempty"`
+	IAVL  *SnapshotIAVLItem  `json:"iavl,omitempty"`
+	MPT   *SnapshotMptItem   `json:"mpt,omitempty"`
+}
+
+// SnapshotStoreItem contains metadata about a snapshotted store, the items of the store follow it.
+type SnapshotStoreItem struct {
+	Name    string `json:"name"`
+	Version int64  `json:"version"`
+}
+
+// SnapshotIAVLItem is an exported IAVL node.
+type SnapshotIAVLItem struct {
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value"`
+	Version int64  `json:"version"`
+	Height  int32  `json:"height"`
+}
+
+// SnapshotMptItem is an exported MPT item, the meaning of the data depends on the kind.
+type SnapshotMptItem struct {
+	Kind uint32 `json:"kind"`
+	Data []byte `json:"data"`
+}
+
+// WriteItem writes the length-prefixed snapshot item into the writer
+func WriteItem(w io.Writer, item *SnapshotItem) error {
+	_, err := cdc.MarshalBinaryLengthPrefixedWriter(w, item)
+	return err
+}
+
+// ReadItem reads a length-prefixed snapshot item from the reader, it returns io.EOF when there
+// are no more items.
+func ReadItem(r io.Reader, item *SnapshotItem, maxSize int64) error {
+	*item = SnapshotItem{}
+	_, err := cdc.UnmarshalBinaryLengthPrefixedReader(r, item, maxSize)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 
+	snapshottypes "github.com/okex/exchain/libs/cosmos-sdk/store/snapshots/types"
 	"github.com/okex/exchain/libs/iavl"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	tmkv "github.com/okex/exchain/libs/tendermint/libs/kv"
@@ -164,6 +165,7 @@ type CacheMultiStoreResetter interface {
 type CommitMultiStore interface {
 	Committer
 	MultiStore
+	snapshottypes.Snapshotter
 	CommitMultiStorePipeline
 	CommitterCommitMap(iavl.TreeDeltaMap) (CommitID, iavl.TreeDeltaMap) // CommitterCommit
 
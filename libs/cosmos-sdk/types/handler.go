@@ -42,8 +42,18 @@ type CustomizeOnStop func(ctx Context) error
 
 type MptCommitHandler func(ctx Context)
 
+type RestoreHandler func(ctx Context)
+
 type EvmWatcherCollector func(...IWatcher)
 
+type ParallelTxsStatsHandler func(height int64, stats ParallelTxsStats)
+
+// GetTxAccessListHandler returns the state a tx declares to access, e.g. the EIP-2930 access list of an evm tx.
+type GetTxAccessListHandler func(tx Tx) []TxAccess
+
+// DecodeStorageKeyHandler returns the storage slot of a contract stored under key, ok is false if key is not a storage slot.
+type DecodeStorageKeyHandler func(storeKey StoreKey, key []byte) (access TxAccess, ok bool)
+
 // AnteDecorator wraps the next AnteHandler to perform custom pre- and post-processing.
 type AnteDecorator interface {
 	AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (newCtx Context, err error)
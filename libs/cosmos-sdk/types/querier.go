@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/json"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -9,6 +11,25 @@ type QueryTraceTx struct {
 	ConfigBytes []byte      `json:"config"`
 }
 
+type QueryTraceBlock struct {
+	Height      int64  `json:"height"`
+	ConfigBytes []byte `json:"config"`
+}
+
+type QueryTraceCall struct {
+	TxBytes        []byte `json:"tx"`
+	From           string `json:"from"`
+	ConfigBytes    []byte `json:"config"`
+	OverridesBytes []byte `json:"overrides"`
+}
+
+// QueryTraceTxResult is the trace result of a tx in the block
+type QueryTraceTxResult struct {
+	TxHash common.Hash     `json:"txHash"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
 type SimulateData struct {
 	TxBytes        []byte `json:"tx"`
 	OverridesBytes []byte `json:"overrides"`
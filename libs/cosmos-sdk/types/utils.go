@@ -105,6 +105,23 @@ type ParaMsg struct {
 	InvalidExecute      bool
 }
 
+// ParallelTxsStats reports how the txs of a block were executed in parallel: the txs whose
+// parallel execution conflicted with the txs before them, the txs executed again, and the sum of
+// the execution times of the results kept divided by the wall time of the block.
+type ParallelTxsStats struct {
+	Txs       int
+	Conflicts int
+	Reruns    int
+	Speedup   float64
+}
+
+// TxAccess is a piece of the state a tx accesses: the account Address, or the storage Slot of the
+// contract Address if Slot is set.
+type TxAccess struct {
+	Address string
+	Slot    string
+}
+
 type FeeSplitInfo struct {
 	Addr   AccAddress
 	Fee    Coins
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -496,11 +497,20 @@ func EthereumTxEncoder(_ *codec.Codec) sdk.TxEncoder {
 	}
 }
 
+// EthereumTxEncode encodes the ethereum tx into its canonical bytes, the typed transactions of EIP-2718
+// implement encoding.BinaryMarshaler to be encoded as envelopes instead of RLP lists
 func EthereumTxEncode(tx sdk.Tx) ([]byte, error) {
+	if m, ok := tx.(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
 	return rlp.EncodeToBytes(tx)
 }
 
+// EthereumTxDecode decodes the canonical bytes of the ethereum tx, both the RLP list and the EIP-2718 envelope
 func EthereumTxDecode(b []byte, tx interface{}) error {
+	if u, ok := tx.(encoding.BinaryUnmarshaler); ok {
+		return u.UnmarshalBinary(b)
+	}
 	return rlp.DecodeBytes(b, tx)
 }
 
@@ -0,0 +1,105 @@
+package types
+
+// Snapshot is a state sync snapshot of the application state
+type Snapshot struct {
+	Height   uint64 `json:"height"`   // The height at which the snapshot was taken
+	Format   uint32 `json:"format"`   // The application-specific snapshot format
+	Chunks   uint32 `json:"chunks"`   // Number of chunks in the snapshot
+	Hash     []byte `json:"hash"`     // Arbitrary snapshot hash, equal only if identical
+	Metadata []byte `json:"metadata"` // Arbitrary application metadata
+}
+
+type RequestListSnapshots struct {
+}
+
+type ResponseListSnapshots struct {
+	Snapshots []*Snapshot `json:"snapshots"`
+}
+
+// RequestOfferSnapshot offers a snapshot to the application
+type RequestOfferSnapshot struct {
+	Snapshot *Snapshot `json:"snapshot"` // snapshot offered by peers
+	AppHash  []byte    `json:"app_hash"` // light client-verified app hash for snapshot height
+}
+
+type ResponseOfferSnapshot_Result int32
+
+const (
+	ResponseOfferSnapshot_UNKNOWN       ResponseOfferSnapshot_Result = 0 // Unknown result, abort all snapshot restoration
+	ResponseOfferSnapshot_ACCEPT        ResponseOfferSnapshot_Result = 1 // Snapshot accepted, apply chunks
+	ResponseOfferSnapshot_ABORT         ResponseOfferSnapshot_Result = 2 // Abort all snapshot restoration
+	ResponseOfferSnapshot_REJECT        ResponseOfferSnapshot_Result = 3 // Reject this specific snapshot, try others
+	ResponseOfferSnapshot_REJECT_FORMAT ResponseOfferSnapshot_Result = 4 // Reject all snapshots of this format, try others
+	ResponseOfferSnapshot_REJECT_SENDER ResponseOfferSnapshot_Result = 5 // Reject all snapshots from the sender(s), try others
+)
+
+var responseOfferSnapshotResultNames = map[ResponseOfferSnapshot_Result]string{
+	ResponseOfferSnapshot_UNKNOWN:       "UNKNOWN",
+	ResponseOfferSnapshot_ACCEPT:        "ACCEPT",
+	ResponseOfferSnapshot_ABORT:         "ABORT",
+	ResponseOfferSnapshot_REJECT:        "REJECT",
+	ResponseOfferSnapshot_REJECT_FORMAT: "REJECT_FORMAT",
+	ResponseOfferSnapshot_REJECT_SENDER: "REJECT_SENDER",
+}
+
+func (r ResponseOfferSnapshot_Result) String() string {
+	if name, ok := responseOfferSnapshotResultNames[r]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+type ResponseOfferSnapshot struct {
+	Result ResponseOfferSnapshot_Result `json:"result"`
+}
+
+// RequestLoadSnapshotChunk loads a snapshot chunk
+type RequestLoadSnapshotChunk struct {
+	Height uint64 `json:"height"`
+	Format uint32 `json:"format"`
+	Chunk  uint32 `json:"chunk"`
+}
+
+type ResponseLoadSnapshotChunk struct {
+	Chunk []byte `json:"chunk"`
+}
+
+// RequestApplySnapshotChunk applies a snapshot chunk
+type RequestApplySnapshotChunk struct {
+	Index  uint32 `json:"index"`
+	Chunk  []byte `json:"chunk"`
+	Sender string `json:"sender"`
+}
+
+type ResponseApplySnapshotChunk_Result int32
+
+const (
+	ResponseApplySnapshotChunk_UNKNOWN         ResponseApplySnapshotChunk_Result = 0 // Unknown result, abort all snapshot restoration
+	ResponseApplySnapshotChunk_ACCEPT          ResponseApplySnapshotChunk_Result = 1 // Chunk successfully accepted
+	ResponseApplySnapshotChunk_ABORT           ResponseApplySnapshotChunk_Result = 2 // Abort all snapshot restoration
+	ResponseApplySnapshotChunk_RETRY           ResponseApplySnapshotChunk_Result = 3 // Retry chunk (combine with refetch and reject)
+	ResponseApplySnapshotChunk_RETRY_SNAPSHOT  ResponseApplySnapshotChunk_Result = 4 // Retry snapshot (combine with refetch and reject)
+	ResponseApplySnapshotChunk_REJECT_SNAPSHOT ResponseApplySnapshotChunk_Result = 5 // Reject this snapshot, try others
+)
+
+var responseApplySnapshotChunkResultNames = map[ResponseApplySnapshotChunk_Result]string{
+	ResponseApplySnapshotChunk_UNKNOWN:         "UNKNOWN",
+	ResponseApplySnapshotChunk_ACCEPT:          "ACCEPT",
+	ResponseApplySnapshotChunk_ABORT:           "ABORT",
+	ResponseApplySnapshotChunk_RETRY:           "RETRY",
+	ResponseApplySnapshotChunk_RETRY_SNAPSHOT:  "RETRY_SNAPSHOT",
+	ResponseApplySnapshotChunk_REJECT_SNAPSHOT: "REJECT_SNAPSHOT",
+}
+
+func (r ResponseApplySnapshotChunk_Result) String() string {
+	if name, ok := responseApplySnapshotChunkResultNames[r]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+type ResponseApplySnapshotChunk struct {
+	Result        ResponseApplySnapshotChunk_Result `json:"result"`
+	RefetchChunks []uint32                          `json:"refetch_chunks"` // Chunks to refetch and reapply
+	RejectSenders []string                          `json:"reject_senders"` // Chunk senders to reject and ban
+}
@@ -74,6 +74,7 @@ type BlockchainReactor struct {
 	fastSync     bool
 	autoFastSync bool
 	isSyncing    bool
+	stateSyncing bool // the node is bootstrapped by the state sync, fast sync waits for SwitchToFastSync
 	mtx          sync.RWMutex
 
 	requestsCh <-chan BlockRequest
@@ -233,7 +234,7 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 		// Got a peer status. Unverified. TODO: should verify before SetPeerRange
 		shouldSync := bcR.pool.SetPeerRange(src.ID(), msg.Base, msg.Height, bcR.store.Height())
 		// should switch to fast-sync when more than XX peers' height is greater than store.Height
-		if shouldSync {
+		if shouldSync && !bcR.getStateSyncing() {
 			bcR.Logger.Info("ShouldSync.", "Status peer", msg.Height, "now", bcR.store.Height())
 			go bcR.poolRoutine()
 		}
@@ -412,6 +413,31 @@ FOR_LOOP:
 	}
 }
 
+// SetStateSyncing marks the reactor as waiting for the state sync to bootstrap the node,
+// the fast sync is not started until SwitchToFastSync is called.
+func (bcR *BlockchainReactor) SetStateSyncing(stateSyncing bool) {
+	bcR.mtx.Lock()
+	bcR.stateSyncing = stateSyncing
+	bcR.mtx.Unlock()
+}
+
+// SwitchToFastSync is called by the state sync reactor when switching to fast sync,
+// the blocks after the restored state are fetched from peers.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	bcR.mtx.Lock()
+	if bcR.isSyncing {
+		bcR.mtx.Unlock()
+		return errors.New("fast sync is already running")
+	}
+	bcR.stateSyncing = false
+	bcR.fastSync = true
+	bcR.curState = state
+	bcR.mtx.Unlock()
+
+	go bcR.poolRoutine()
+	return nil
+}
+
 func (bcR *BlockchainReactor) CheckFastSyncCondition() {
 	// ask for status updates
 	bcR.Logger.Info("CheckFastSyncCondition.")
@@ -463,6 +489,12 @@ func (bcR *BlockchainReactor) getIsSyncing() bool {
 	return bcR.isSyncing
 }
 
+func (bcR *BlockchainReactor) getStateSyncing() bool {
+	bcR.mtx.RLock()
+	defer bcR.mtx.RUnlock()
+	return bcR.stateSyncing
+}
+
 //-----------------------------------------------------------------------------
 // Messages
 
@@ -32,6 +32,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"priv_validator_laddr",
 		config.PrivValidatorListenAddr,
 		"Socket address to listen on for connections from external priv_validator process")
+	cmd.Flags().String(
+		"priv_validator_threshold_laddrs",
+		config.PrivValidatorThresholdListenAddrs,
+		"Comma separated socket addresses to listen on for connections from threshold signers")
 
 	// node flags
 	cmd.Flags().Bool("fast_sync", config.FastSyncMode, "Fast blockchain syncing")
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	tmos "github.com/okex/exchain/libs/tendermint/libs/os"
+	"github.com/okex/exchain/libs/tendermint/privval"
+)
+
+var (
+	splitThreshold uint32
+	splitShares    uint32
+	splitOutputDir string
+)
+
+func init() {
+	SplitValidatorCmd.Flags().Uint32Var(&splitThreshold, "threshold", 2,
+		"Number of signers required to sign, must be more than half of the shares")
+	SplitValidatorCmd.Flags().Uint32Var(&splitShares, "shares", 3,
+		"Number of key shares to split the validator key into")
+	SplitValidatorCmd.Flags().StringVar(&splitOutputDir, "o", "./threshold-shares",
+		"Directory to store the key shares of the threshold signers")
+}
+
+// SplitValidatorCmd splits the validator key into key shares for threshold signers.
+var SplitValidatorCmd = &cobra.Command{
+	Use:   "split_validator",
+	Short: "Split this node's validator key into key shares for threshold signers",
+	Long: `Split this node's ed25519 validator key into key shares, any threshold of which can sign
+for the validator. The threshold public key is saved to priv_validator_threshold_pubkey_file,
+and the key shares to the output directory, one for each threshold signer.
+
+The original validator key must be destroyed once the shares are distributed.`,
+	RunE: splitValidator,
+}
+
+func splitValidator(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidatorKeyFile()
+	if !tmos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+	pv := privval.LoadFilePVEmptyState(keyFilePath, "")
+	privKey, ok := pv.Key.PrivKey.(ed25519.PrivKeyEd25519)
+	if !ok {
+		return fmt.Errorf("only ed25519 validator keys can be split, got %T", pv.Key.PrivKey)
+	}
+
+	public, keyShares, err := privval.SplitThresholdKey(privKey, splitThreshold, splitShares)
+	if err != nil {
+		return errors.Wrap(err, "failed to split private validator key")
+	}
+
+	if err := os.MkdirAll(splitOutputDir, 0700); err != nil {
+		return err
+	}
+	for _, share := range keyShares {
+		share.SaveAs(filepath.Join(splitOutputDir, fmt.Sprintf("priv_validator_threshold_share_%d.json", share.ID)))
+	}
+	public.SaveAs(config.PrivValidatorThresholdPubKeyFile())
+
+	logger.Info("Split private validator key", "threshold", splitThreshold, "shares", splitShares,
+		"pubkey", config.PrivValidatorThresholdPubKeyFile(), "shares_dir", splitOutputDir)
+	return nil
+}
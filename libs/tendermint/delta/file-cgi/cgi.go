@@ -0,0 +1,204 @@
+package file_cgi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+const (
+	lockerExpire = 4 * time.Second
+)
+
+// FileClient shares the deltas through a directory, a shared volume or a NFS mount, with the same
+// semantics as the redis broker: the locker expires after lockerExpire, the deltas after ttl.
+type FileClient struct {
+	dir    string
+	ttl    time.Duration
+	logger log.Logger
+
+	mostRecentHeightFile string
+	deltaLockerFile      string
+
+	cleanMtx  sync.Mutex
+	lastClean time.Time
+}
+
+func NewFileClient(dir string, ttl time.Duration, l log.Logger) *FileClient {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		panic(fmt.Sprintf("failed to create delta broker dir %s: %s", dir, err))
+	}
+	return &FileClient{
+		dir:                  dir,
+		ttl:                  ttl,
+		logger:               l,
+		mostRecentHeightFile: filepath.Join(dir, fmt.Sprintf("dds-%d-MostRecentHeight", types.DeltaVersion)),
+		deltaLockerFile:      filepath.Join(dir, fmt.Sprintf("dds-%d-DeltaLocker", types.DeltaVersion)),
+	}
+}
+
+func (f *FileClient) GetLocker() bool {
+	for i := 0; i < 2; i++ {
+		file, err := os.OpenFile(f.deltaLockerFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return true
+		}
+		if !os.IsExist(err) {
+			f.logger.Error("GetLocker err", "err", err)
+			return false
+		}
+		// the locker of a crashed holder expires
+		info, err := os.Stat(f.deltaLockerFile)
+		if err != nil || time.Since(info.ModTime()) < lockerExpire {
+			return false
+		}
+		os.Remove(f.deltaLockerFile)
+	}
+	return false
+}
+
+func (f *FileClient) ReleaseLocker() {
+	if err := os.Remove(f.deltaLockerFile); err != nil && !os.IsNotExist(err) {
+		f.logger.Error("Failed to Release Locker", "err", err)
+	}
+}
+
+// return bool: if change the value of latest_height, need to upload
+func (f *FileClient) ResetMostRecentHeightAfterUpload(targetHeight int64, upload func(int64) bool) (bool, int64, error) {
+	var res bool
+	mrh, err := f.readMostRecentHeight()
+	if err != nil {
+		return res, mrh, err
+	}
+
+	if mrh < targetHeight && upload(mrh) {
+		err = f.writeFile(f.mostRecentHeightFile, []byte(strconv.FormatInt(targetHeight, 10)))
+		if err == nil {
+			res = true
+			f.logger.Info("Reset most recent height", "new-mrh", targetHeight, "old-mrh", mrh)
+		} else {
+			f.logger.Error("Failed to reset most recent height",
+				"target-mrh", targetHeight,
+				"existing-mrh", mrh, "err", err)
+		}
+	}
+	return res, mrh, err
+}
+
+func (f *FileClient) SetDeltas(height int64, bytes []byte) error {
+	if len(bytes) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	f.removeExpired()
+
+	// like SETNX, the deltas uploaded first are kept
+	path := f.deltaFile(height)
+	if _, ok := f.readDeltas(path); ok {
+		return nil
+	}
+	return f.writeFile(path, bytes)
+}
+
+func (f *FileClient) GetDeltas(height int64) ([]byte, error, int64) {
+	mrh := f.getMostRecentHeight()
+	bytes, ok := f.readDeltas(f.deltaFile(height))
+	if !ok {
+		return nil, fmt.Errorf("get empty delta"), mrh
+	}
+	return bytes, nil, mrh
+}
+
+func (f *FileClient) getMostRecentHeight() int64 {
+	mrh, err := f.readMostRecentHeight()
+	if err != nil {
+		return -1
+	}
+	return mrh
+}
+
+// readMostRecentHeight returns 0 if no delta was uploaded yet.
+func (f *FileClient) readMostRecentHeight() (int64, error) {
+	bz, err := ioutil.ReadFile(f.mostRecentHeightFile)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 64)
+}
+
+// readDeltas returns false if the deltas are missing or expired.
+func (f *FileClient) readDeltas(path string) ([]byte, bool) {
+	info, err := os.Stat(path)
+	if err != nil || f.expired(info) {
+		return nil, false
+	}
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil || len(bytes) == 0 {
+		return nil, false
+	}
+	return bytes, true
+}
+
+func (f *FileClient) expired(info os.FileInfo) bool {
+	return f.ttl > 0 && time.Since(info.ModTime()) > f.ttl
+}
+
+// writeFile writes the file through a rename, so that the readers never see a partial file.
+func (f *FileClient) writeFile(path string, bytes []byte) error {
+	tmp, err := ioutil.TempFile(f.dir, filepath.Base(path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(bytes); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// removeExpired removes the expired deltas of the directory, at most once per ttl.
+func (f *FileClient) removeExpired() {
+	if f.ttl <= 0 {
+		return
+	}
+	f.cleanMtx.Lock()
+	defer f.cleanMtx.Unlock()
+	if time.Since(f.lastClean) < f.ttl {
+		return
+	}
+	f.lastClean = time.Now()
+
+	infos, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		f.logger.Error("Failed to remove expired deltas", "err", err)
+		return
+	}
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), "DH-") && f.expired(info) {
+			os.Remove(filepath.Join(f.dir, info.Name()))
+		}
+	}
+}
+
+func (f *FileClient) deltaFile(height int64) string {
+	return filepath.Join(f.dir, fmt.Sprintf("DH-%d-%d", types.DeltaVersion, height))
+}
@@ -0,0 +1,122 @@
+package file_cgi
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ConstDeltaBytes = "delta-bytes"
+	ConstTestHeight = 1
+)
+
+func getFileClient(t *testing.T, ttl time.Duration) *FileClient {
+	return NewFileClient(t.TempDir(), ttl, log.TestingLogger())
+}
+
+func TestFileClient_SetGetDeltas(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+
+	height := int64(ConstTestHeight)
+	// delta is empty
+	re, err, mrh := f.GetDeltas(height)
+	require.Nil(t, re)
+	require.Error(t, err)
+	require.Equal(t, int64(0), mrh)
+
+	// set delta
+	require.Error(t, f.SetDeltas(height, nil))
+	require.NoError(t, f.SetDeltas(height, []byte(ConstDeltaBytes)))
+
+	// get delta
+	re, err, _ = f.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ConstDeltaBytes), re)
+
+	// the delta uploaded first is kept
+	require.NoError(t, f.SetDeltas(height, []byte("other")))
+	re, _, _ = f.GetDeltas(height)
+	require.Equal(t, []byte(ConstDeltaBytes), re)
+
+	// get wrong key
+	re, err, _ = f.GetDeltas(height + 1)
+	require.Nil(t, re)
+	require.Error(t, err)
+}
+
+func TestFileClient_Expire(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+	height := int64(ConstTestHeight)
+	require.NoError(t, f.SetDeltas(height, []byte(ConstDeltaBytes)))
+
+	past := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, os.Chtimes(f.deltaFile(height), past, past))
+	_, err, _ := f.GetDeltas(height)
+	require.Error(t, err)
+
+	// the expired delta is replaced
+	require.NoError(t, f.SetDeltas(height, []byte("other")))
+	re, err, _ := f.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), re)
+
+	// the expired deltas are removed
+	require.NoError(t, os.Chtimes(f.deltaFile(height), past, past))
+	f.lastClean = time.Time{}
+	require.NoError(t, f.SetDeltas(height+1, []byte(ConstDeltaBytes)))
+	_, err = os.Stat(f.deltaFile(height))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileClient_Locker(t *testing.T) {
+	dir := t.TempDir()
+	f1 := NewFileClient(dir, time.Minute, log.TestingLogger())
+	f2 := NewFileClient(dir, time.Minute, log.TestingLogger())
+
+	require.True(t, f1.GetLocker())
+	require.False(t, f2.GetLocker())
+	f1.ReleaseLocker()
+	require.True(t, f2.GetLocker())
+
+	// the locker of a crashed holder expires
+	past := time.Now().Add(-2 * lockerExpire)
+	require.NoError(t, os.Chtimes(f2.deltaLockerFile, past, past))
+	require.True(t, f1.GetLocker())
+	f1.ReleaseLocker()
+}
+
+func TestFileClient_ResetLatestHeightAfterUpload(t *testing.T) {
+	f := getFileClient(t, time.Minute)
+	uploadSuccess := func(int64) bool { return true }
+	uploadFailed := func(int64) bool { return false }
+	h := int64(ConstTestHeight)
+	type args struct {
+		height int64
+		upload func(int64) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"upload failed", args{h, uploadFailed}, false},
+		{"first time set", args{h, uploadSuccess}, true},
+		{"height<latestHeight", args{h - 1, uploadSuccess}, false},
+		{"height==latestHeight", args{h, uploadSuccess}, false},
+		{"height>latestHeight", args{h + 1, uploadSuccess}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _ := f.ResetMostRecentHeightAfterUpload(tt.args.height, tt.args.upload)
+			if got != tt.want {
+				t.Errorf("ResetLatestHeightAfterUpload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	_, _, mrh := f.GetDeltas(h)
+	require.Equal(t, h+1, mrh)
+}
@@ -0,0 +1,85 @@
+package p2p_cgi
+
+import (
+	"errors"
+	"fmt"
+
+	amino "github.com/tendermint/go-amino"
+)
+
+const (
+	// DeltaChannel exchanges the deltas of the heights
+	DeltaChannel = byte(0x70)
+
+	// deltaMsgSize is the maximum size of a deltaResponseMessage
+	deltaMsgSize = int(64e6)
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterMessages(cdc)
+}
+
+// Message is a message sent and received by the reactor.
+type Message interface {
+	ValidateBasic() error
+}
+
+// RegisterMessages registers the delta messages for amino encoding.
+func RegisterMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*Message)(nil), nil)
+	cdc.RegisterConcrete(&deltaRequestMessage{}, "tendermint/delta/DeltaRequest", nil)
+	cdc.RegisterConcrete(&deltaResponseMessage{}, "tendermint/delta/DeltaResponse", nil)
+}
+
+// decodeMsg decodes a message.
+func decodeMsg(bz []byte) (msg Message, err error) {
+	if len(bz) > deltaMsgSize {
+		return msg, fmt.Errorf("msg exceeds max size (%d > %d)", len(bz), deltaMsgSize)
+	}
+	err = cdc.UnmarshalBinaryBare(bz, &msg)
+	return
+}
+
+//-------------------------------------
+
+// deltaRequestMessage requests the deltas of a height from a peer.
+type deltaRequestMessage struct {
+	Height int64
+}
+
+// ValidateBasic implements Message.
+func (m *deltaRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("height must be positive")
+	}
+	return nil
+}
+
+func (m *deltaRequestMessage) String() string {
+	return fmt.Sprintf("[deltaRequestMessage %v]", m.Height)
+}
+
+// deltaResponseMessage contains the marshaled types.Deltas of a height, empty if the peer does not
+// have them, and the most recent height the peer uploaded.
+type deltaResponseMessage struct {
+	Height           int64
+	Deltas           []byte
+	MostRecentHeight int64
+}
+
+// ValidateBasic implements Message.
+func (m *deltaResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("height must be positive")
+	}
+	if m.MostRecentHeight < 0 {
+		return errors.New("negative most recent height")
+	}
+	return nil
+}
+
+func (m *deltaResponseMessage) String() string {
+	return fmt.Sprintf("[deltaResponseMessage %v %d bytes mrh %v]", m.Height, len(m.Deltas), m.MostRecentHeight)
+}
@@ -0,0 +1,246 @@
+package p2p_cgi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/p2p"
+)
+
+const (
+	// requestInterval is the time before the deltas of a height are requested again
+	requestInterval = time.Second
+)
+
+type cachedDeltas struct {
+	bytes []byte
+	at    time.Time
+	// received is true for the deltas received from the peers, false for the deltas uploaded
+	received bool
+}
+
+// Reactor is a delta broker sharing the deltas through the p2p network. The producers keep the
+// deltas they upload for ttl and serve them to the peers, the consumers request the deltas of a
+// height from their trusted peers and apply only the deltas received from them.
+type Reactor struct {
+	p2p.BaseReactor
+
+	ttl     time.Duration
+	trusted map[p2p.ID]bool
+	locked  int32
+
+	mtx sync.Mutex
+	// peers are the trusted peers connected
+	peers  map[p2p.ID]p2p.Peer
+	deltas map[int64]*cachedDeltas
+	// mostRecentHeight is the last height uploaded by the producer, or the highest one advertised
+	// by the trusted peers of the consumer
+	mostRecentHeight int64
+	requested        map[int64]time.Time
+	lastClean        time.Time
+}
+
+// NewReactor creates a new delta reactor downloading deltas from the trusted peers.
+func NewReactor(trustedPeers []string, ttl time.Duration) *Reactor {
+	r := &Reactor{
+		ttl:       ttl,
+		trusted:   make(map[p2p.ID]bool),
+		peers:     make(map[p2p.ID]p2p.Peer),
+		deltas:    make(map[int64]*cachedDeltas),
+		requested: make(map[int64]time.Time),
+	}
+	for _, id := range trustedPeers {
+		if id != "" {
+			r.trusted[p2p.ID(id)] = true
+		}
+	}
+	r.BaseReactor = *p2p.NewBaseReactor("Deltas", r)
+	return r
+}
+
+// GetChannels implements p2p.Reactor.
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  DeltaChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: deltaMsgSize,
+		},
+	}
+}
+
+// AddPeer implements p2p.Reactor.
+func (r *Reactor) AddPeer(peer p2p.Peer) {
+	if !r.trusted[peer.ID()] {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.peers[peer.ID()] = peer
+}
+
+// RemovePeer implements p2p.Reactor.
+func (r *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	delete(r.peers, peer.ID())
+}
+
+// Receive implements p2p.Reactor.
+func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	if !r.IsRunning() {
+		return
+	}
+
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+	if err = msg.ValidateBasic(); err != nil {
+		r.Logger.Error("Invalid message", "peer", src, "msg", msg, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	switch msg := msg.(type) {
+	case *deltaRequestMessage:
+		r.mtx.Lock()
+		deltas := r.getCached(msg.Height)
+		mrh := r.mostRecentHeight
+		r.mtx.Unlock()
+		src.TrySend(DeltaChannel, cdc.MustMarshalBinaryBare(&deltaResponseMessage{
+			Height:           msg.Height,
+			Deltas:           deltas,
+			MostRecentHeight: mrh,
+		}))
+
+	case *deltaResponseMessage:
+		if !r.trusted[src.ID()] {
+			r.Logger.Debug("Ignored deltas of an untrusted peer", "height", msg.Height, "peer", src.ID())
+			return
+		}
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		if msg.MostRecentHeight > r.mostRecentHeight {
+			r.mostRecentHeight = msg.MostRecentHeight
+		}
+		if len(msg.Deltas) == 0 {
+			return
+		}
+		delete(r.requested, msg.Height)
+		if r.getCached(msg.Height) == nil {
+			r.deltas[msg.Height] = &cachedDeltas{bytes: msg.Deltas, at: time.Now(), received: true}
+		}
+
+	default:
+		r.Logger.Error(fmt.Sprintf("Received unknown message %v", reflect.TypeOf(msg)))
+	}
+}
+
+// GetLocker implements delta.DeltaBroker, the producer uploads one height at a time.
+func (r *Reactor) GetLocker() bool {
+	return atomic.CompareAndSwapInt32(&r.locked, 0, 1)
+}
+
+// ReleaseLocker implements delta.DeltaBroker.
+func (r *Reactor) ReleaseLocker() {
+	atomic.StoreInt32(&r.locked, 0)
+}
+
+// ResetMostRecentHeightAfterUpload implements delta.DeltaBroker.
+// return bool: if change the value of latest_height, need to upload
+func (r *Reactor) ResetMostRecentHeightAfterUpload(targetHeight int64, upload func(int64) bool) (bool, int64, error) {
+	r.mtx.Lock()
+	mrh := r.mostRecentHeight
+	r.mtx.Unlock()
+
+	if mrh >= targetHeight || !upload(mrh) {
+		return false, mrh, nil
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.mostRecentHeight >= targetHeight {
+		return false, mrh, nil
+	}
+	r.mostRecentHeight = targetHeight
+	r.Logger.Info("Reset most recent height", "new-mrh", targetHeight, "old-mrh", mrh)
+	return true, mrh, nil
+}
+
+// SetDeltas implements delta.DeltaBroker, the deltas are served to the peers for ttl.
+func (r *Reactor) SetDeltas(height int64, bytes []byte) error {
+	if len(bytes) == 0 {
+		return fmt.Errorf("delta is empty")
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.removeExpired()
+	if r.getCached(height) == nil {
+		r.deltas[height] = &cachedDeltas{bytes: bytes, at: time.Now()}
+	}
+	return nil
+}
+
+// GetDeltas implements delta.DeltaBroker. It does not wait for the peers, the deltas missing are
+// requested from the trusted peers and returned by the next calls once received.
+func (r *Reactor) GetDeltas(height int64) ([]byte, error, int64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.removeExpired()
+
+	if bytes := r.getCached(height); bytes != nil {
+		// the deltas received are applied once, only the deltas uploaded are served to the peers
+		if r.deltas[height].received {
+			delete(r.deltas, height)
+		}
+		return bytes, nil, r.mostRecentHeight
+	}
+
+	if len(r.peers) == 0 {
+		return nil, fmt.Errorf("no trusted peer to download delta from"), r.mostRecentHeight
+	}
+	if at, ok := r.requested[height]; !ok || time.Since(at) >= requestInterval {
+		r.requested[height] = time.Now()
+		msg := cdc.MustMarshalBinaryBare(&deltaRequestMessage{Height: height})
+		for _, peer := range r.peers {
+			peer.TrySend(DeltaChannel, msg)
+		}
+	}
+	return nil, fmt.Errorf("get empty delta"), r.mostRecentHeight
+}
+
+func (r *Reactor) getCached(height int64) []byte {
+	cached, ok := r.deltas[height]
+	if !ok {
+		return nil
+	}
+	if r.ttl > 0 && time.Since(cached.at) > r.ttl {
+		delete(r.deltas, height)
+		return nil
+	}
+	return cached.bytes
+}
+
+// removeExpired removes the expired deltas and requests, at most once per requestInterval.
+func (r *Reactor) removeExpired() {
+	if time.Since(r.lastClean) < requestInterval {
+		return
+	}
+	r.lastClean = time.Now()
+	for height := range r.deltas {
+		r.getCached(height)
+	}
+	// the heights still downloaded are requested again
+	for height, at := range r.requested {
+		if time.Since(at) >= requestInterval {
+			delete(r.requested, height)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package p2p_cgi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/libs/tendermint/p2p"
+	"github.com/okex/exchain/libs/tendermint/p2p/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ConstDeltaBytes = "delta-bytes"
+	ConstTestHeight = 1
+)
+
+// recordingPeer is a peer recording the messages sent to it.
+type recordingPeer struct {
+	*mock.Peer
+	sent []Message
+}
+
+func newRecordingPeer() *recordingPeer {
+	return &recordingPeer{Peer: mock.NewPeer(nil)}
+}
+
+func (p *recordingPeer) TrySend(chID byte, msgBytes []byte) bool {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	p.sent = append(p.sent, msg)
+	return true
+}
+
+func (p *recordingPeer) Send(chID byte, msgBytes []byte) bool {
+	return p.TrySend(chID, msgBytes)
+}
+
+func newTestReactor(t *testing.T, trusted ...p2p.Peer) *Reactor {
+	var ids []string
+	for _, peer := range trusted {
+		ids = append(ids, string(peer.ID()))
+	}
+	r := NewReactor(ids, time.Minute)
+	r.SetLogger(log.TestingLogger())
+	require.NoError(t, r.Start())
+	t.Cleanup(func() { r.Stop() })
+	return r
+}
+
+func TestReactor_ProduceAndConsume(t *testing.T) {
+	producerPeer, consumerPeer := newRecordingPeer(), newRecordingPeer()
+	producer := newTestReactor(t)
+	consumer := newTestReactor(t, producerPeer)
+	consumer.AddPeer(producerPeer)
+
+	height := int64(ConstTestHeight)
+	require.True(t, producer.GetLocker())
+	reset, _, err := producer.ResetMostRecentHeightAfterUpload(height, func(int64) bool {
+		return producer.SetDeltas(height, []byte(ConstDeltaBytes)) == nil
+	})
+	require.NoError(t, err)
+	require.True(t, reset)
+	producer.ReleaseLocker()
+
+	// the consumer requests the delta from the trusted peer
+	_, err, mrh := consumer.GetDeltas(height)
+	require.Error(t, err)
+	require.Equal(t, int64(0), mrh)
+	require.Len(t, producerPeer.sent, 1)
+	request := producerPeer.sent[0]
+	require.Equal(t, &deltaRequestMessage{Height: height}, request)
+
+	// not requested again before requestInterval
+	_, err, _ = consumer.GetDeltas(height)
+	require.Error(t, err)
+	require.Len(t, producerPeer.sent, 1)
+
+	// the producer serves the delta
+	producer.Receive(DeltaChannel, consumerPeer, cdc.MustMarshalBinaryBare(request))
+	require.Len(t, consumerPeer.sent, 1)
+	response := consumerPeer.sent[0].(*deltaResponseMessage)
+	require.Equal(t, []byte(ConstDeltaBytes), response.Deltas)
+	require.Equal(t, height, response.MostRecentHeight)
+
+	consumer.Receive(DeltaChannel, producerPeer, cdc.MustMarshalBinaryBare(response))
+	bytes, err, mrh := consumer.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ConstDeltaBytes), bytes)
+	require.Equal(t, height, mrh)
+
+	// the delta received is applied once, the producer keeps serving its delta
+	_, err, _ = consumer.GetDeltas(height)
+	require.Error(t, err)
+	bytes, err, _ = producer.GetDeltas(height)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ConstDeltaBytes), bytes)
+}
+
+func TestReactor_UntrustedPeer(t *testing.T) {
+	trusted, untrusted := newRecordingPeer(), newRecordingPeer()
+	consumer := newTestReactor(t, trusted)
+	consumer.AddPeer(untrusted)
+
+	// no request to the untrusted peers
+	_, err, _ := consumer.GetDeltas(ConstTestHeight)
+	require.Error(t, err)
+	require.Empty(t, untrusted.sent)
+
+	// nor deltas accepted from them
+	consumer.Receive(DeltaChannel, untrusted, cdc.MustMarshalBinaryBare(&deltaResponseMessage{
+		Height:           ConstTestHeight,
+		Deltas:           []byte(ConstDeltaBytes),
+		MostRecentHeight: 10,
+	}))
+	_, err, mrh := consumer.GetDeltas(ConstTestHeight)
+	require.Error(t, err)
+	require.Equal(t, int64(0), mrh)
+}
+
+func TestReactor_ResetLatestHeightAfterUpload(t *testing.T) {
+	r := newTestReactor(t)
+	uploadSuccess := func(int64) bool { return true }
+	uploadFailed := func(int64) bool { return false }
+	h := int64(ConstTestHeight)
+	type args struct {
+		height int64
+		upload func(int64) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"upload failed", args{h, uploadFailed}, false},
+		{"first time set", args{h, uploadSuccess}, true},
+		{"height<latestHeight", args{h - 1, uploadSuccess}, false},
+		{"height==latestHeight", args{h, uploadSuccess}, false},
+		{"height>latestHeight", args{h + 1, uploadSuccess}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _ := r.ResetMostRecentHeightAfterUpload(tt.args.height, tt.args.upload)
+			if got != tt.want {
+				t.Errorf("ResetLatestHeightAfterUpload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	require.True(t, r.GetLocker())
+	require.False(t, r.GetLocker())
+	r.ReleaseLocker()
+	require.True(t, r.GetLocker())
+}
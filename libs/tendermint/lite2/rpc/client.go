@@ -167,6 +167,10 @@ func (c *Client) GetPendingNonce(address string) (*ctypes.ResultPendingNonce, bo
 	return c.next.GetPendingNonce(address)
 }
 
+func (c *Client) GetPendingPoolTxs() (*ctypes.ResultPendingTxs, error) {
+	return c.next.GetPendingPoolTxs()
+}
+
 func (c *Client) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return c.next.NetInfo()
 }
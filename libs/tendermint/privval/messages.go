@@ -21,6 +21,11 @@ func RegisterRemoteSignerMsg(cdc *amino.Codec) {
 
 	cdc.RegisterConcrete(&PingRequest{}, "tendermint/remotesigner/PingRequest", nil)
 	cdc.RegisterConcrete(&PingResponse{}, "tendermint/remotesigner/PingResponse", nil)
+
+	cdc.RegisterConcrete(&ThresholdCommitRequest{}, "tendermint/remotesigner/ThresholdCommitRequest", nil)
+	cdc.RegisterConcrete(&ThresholdCommitResponse{}, "tendermint/remotesigner/ThresholdCommitResponse", nil)
+	cdc.RegisterConcrete(&ThresholdSignRequest{}, "tendermint/remotesigner/ThresholdSignRequest", nil)
+	cdc.RegisterConcrete(&ThresholdSignResponse{}, "tendermint/remotesigner/ThresholdSignResponse", nil)
 }
 
 // TODO: Add ChainIDRequest
@@ -72,3 +77,33 @@ type PingRequest struct {
 // PingResponse is a response to confirm that the connection is alive.
 type PingResponse struct {
 }
+
+// ThresholdCommitRequest asks a threshold signer to commit to the nonces of a new signing round.
+type ThresholdCommitRequest struct {
+}
+
+// ThresholdCommitResponse contains the nonce commitment of a threshold signer, along with the
+// height/round/step it last signed at and the sign bytes it signed.
+type ThresholdCommitResponse struct {
+	Commitment *ThresholdCommitment
+	Height     int64
+	Round      int
+	Step       int8
+	SignBytes  []byte
+	Error      *RemoteSignerError
+}
+
+// ThresholdSignRequest asks a threshold signer for its signature share of either a vote or a
+// proposal, using the commitments of the signers taking part in the round.
+type ThresholdSignRequest struct {
+	Vote        *types.Vote
+	Proposal    *types.Proposal
+	Commitments []ThresholdCommitment
+}
+
+// ThresholdSignResponse contains the signature share of a threshold signer or an error.
+type ThresholdSignResponse struct {
+	ID    uint32
+	Share []byte
+	Error *RemoteSignerError
+}
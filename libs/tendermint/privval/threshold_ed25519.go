@@ -0,0 +1,297 @@
+package privval
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"filippo.io/edwards25519"
+)
+
+// This file implements the two-round FROST(Ed25519, SHA-512) threshold signature scheme of
+// RFC 9591. The group signature it produces is a plain ed25519 signature of the group public
+// key, so the rest of the node cannot tell it from the one of a FilePV.
+//
+// The scalar and group arithmetic uses the constant time Scalar and Point of edwards25519, the
+// secret shares and the nonces never go through variable time code.
+
+const (
+	frostContextString = "FROST-ED25519-SHA512-v1"
+
+	// thresholdScalarSize is the size of a serialized scalar or group element.
+	thresholdScalarSize = 32
+)
+
+var (
+	errInvalidPoint  = errors.New("invalid ed25519 point")
+	errInvalidScalar = errors.New("invalid ed25519 scalar")
+
+	// scalarMinusOne is l - 1, the largest canonical scalar.
+	scalarMinusOne = new(edwards25519.Scalar).Negate(scalarFromID(1))
+)
+
+// decodePoint decodes a canonically encoded point, it rejects the identity and the points outside
+// of the prime order subgroup as RFC 9591 requires for the elements received from other parties.
+func decodePoint(bz []byte) (*edwards25519.Point, error) {
+	if len(bz) != thresholdScalarSize {
+		return nil, errInvalidPoint
+	}
+	p, err := new(edwards25519.Point).SetBytes(bz)
+	if err != nil || !bytes.Equal(p.Bytes(), bz) {
+		return nil, errInvalidPoint
+	}
+	// p is in the prime order subgroup if and only if (l-1)*p == -p
+	if p.Equal(edwards25519.NewIdentityPoint()) == 1 ||
+		new(edwards25519.Point).ScalarMult(scalarMinusOne, p).Equal(new(edwards25519.Point).Negate(p)) != 1 {
+		return nil, errInvalidPoint
+	}
+	return p, nil
+}
+
+// decodeScalar decodes a canonical scalar.
+func decodeScalar(bz []byte) (*edwards25519.Scalar, error) {
+	k, err := new(edwards25519.Scalar).SetCanonicalBytes(bz)
+	if err != nil {
+		return nil, errInvalidScalar
+	}
+	return k, nil
+}
+
+// scalarFromID returns the signer id as a scalar.
+func scalarFromID(id uint32) *edwards25519.Scalar {
+	bz := make([]byte, thresholdScalarSize)
+	binary.LittleEndian.PutUint32(bz, id)
+	k, err := new(edwards25519.Scalar).SetCanonicalBytes(bz)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// hashToScalar reduces the SHA-512 hash of the inputs modulo the group order.
+func hashToScalar(inputs ...[]byte) *edwards25519.Scalar {
+	h := sha512.New()
+	for _, in := range inputs {
+		h.Write(in)
+	}
+	k, err := new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+func frostHash(tag string, inputs ...[]byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(frostContextString + tag))
+	for _, in := range inputs {
+		h.Write(in)
+	}
+	return h.Sum(nil)
+}
+
+func frostHashToScalar(tag string, inputs ...[]byte) *edwards25519.Scalar {
+	return hashToScalar(append([][]byte{[]byte(frostContextString + tag)}, inputs...)...)
+}
+
+// randomScalar returns a uniformly random non-zero scalar.
+func randomScalar() (*edwards25519.Scalar, error) {
+	for {
+		seed := make([]byte, 64)
+		if _, err := rand.Read(seed); err != nil {
+			return nil, err
+		}
+		k, err := new(edwards25519.Scalar).SetUniformBytes(seed)
+		if err != nil {
+			return nil, err
+		}
+		if k.Equal(edwards25519.NewScalar()) == 0 {
+			return k, nil
+		}
+	}
+}
+
+// ed25519SecretScalar derives the secret scalar of an ed25519 seed the way ed25519 signing does.
+func ed25519SecretScalar(seed []byte) *edwards25519.Scalar {
+	h := sha512.Sum512(seed)
+	k, err := new(edwards25519.Scalar).SetBytesWithClamping(h[:32])
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// basePointMul returns k * B.
+func basePointMul(k *edwards25519.Scalar) *edwards25519.Point {
+	return new(edwards25519.Point).ScalarBaseMult(k)
+}
+
+//-------------------------------------------------------------------------------
+// FROST rounds
+
+// ThresholdCommitment is the round one commitment of a signer to its hiding and binding nonces.
+type ThresholdCommitment struct {
+	ID      uint32 `json:"id"`
+	Hiding  []byte `json:"hiding"`
+	Binding []byte `json:"binding"`
+}
+
+// thresholdNonces are the secret nonces behind a ThresholdCommitment, they must be used once.
+type thresholdNonces struct {
+	hiding, binding *edwards25519.Scalar
+	commitment      ThresholdCommitment
+}
+
+// frostNonceGenerate generates a nonce from fresh randomness and the secret share, so a broken
+// random source alone does not reveal the share.
+func frostNonceGenerate(secret *edwards25519.Scalar) (*edwards25519.Scalar, error) {
+	random := make([]byte, thresholdScalarSize)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	return frostHashToScalar("nonce", random, secret.Bytes()), nil
+}
+
+// frostCommit runs round one for the signer with the given id and secret share.
+func frostCommit(id uint32, secret *edwards25519.Scalar) (*thresholdNonces, error) {
+	hiding, err := frostNonceGenerate(secret)
+	if err != nil {
+		return nil, err
+	}
+	binding, err := frostNonceGenerate(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &thresholdNonces{
+		hiding:  hiding,
+		binding: binding,
+		commitment: ThresholdCommitment{
+			ID:      id,
+			Hiding:  basePointMul(hiding).Bytes(),
+			Binding: basePointMul(binding).Bytes(),
+		},
+	}, nil
+}
+
+// frostSession holds what every participant derives from the commitment list of a signing
+// session: the binding factors, the group commitment and the challenge.
+type frostSession struct {
+	ids            []uint32
+	bindingFactors map[uint32]*edwards25519.Scalar
+	commitments    map[uint32][2]*edwards25519.Point
+	groupCommit    *edwards25519.Point
+	challenge      *edwards25519.Scalar
+}
+
+// newFrostSession validates the commitment list and derives the session values.
+func newFrostSession(groupPubKey []byte, commitments []ThresholdCommitment, msg []byte) (*frostSession, error) {
+	if len(commitments) == 0 {
+		return nil, errors.New("empty commitment list")
+	}
+	list := make([]ThresholdCommitment, len(commitments))
+	copy(list, commitments)
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	s := &frostSession{
+		bindingFactors: make(map[uint32]*edwards25519.Scalar, len(list)),
+		commitments:    make(map[uint32][2]*edwards25519.Point, len(list)),
+	}
+	var encoded []byte
+	for i, c := range list {
+		if c.ID == 0 || (i > 0 && list[i-1].ID == c.ID) {
+			return nil, fmt.Errorf("invalid or duplicate signer id %d in commitment list", c.ID)
+		}
+		hiding, err := decodePoint(c.Hiding)
+		if err != nil {
+			return nil, fmt.Errorf("hiding commitment of signer %d: %w", c.ID, err)
+		}
+		binding, err := decodePoint(c.Binding)
+		if err != nil {
+			return nil, fmt.Errorf("binding commitment of signer %d: %w", c.ID, err)
+		}
+		s.ids = append(s.ids, c.ID)
+		s.commitments[c.ID] = [2]*edwards25519.Point{hiding, binding}
+		encoded = append(encoded, scalarFromID(c.ID).Bytes()...)
+		encoded = append(encoded, c.Hiding...)
+		encoded = append(encoded, c.Binding...)
+	}
+
+	prefix := append(append(append([]byte{}, groupPubKey...), frostHash("msg", msg)...), frostHash("com", encoded)...)
+	s.groupCommit = edwards25519.NewIdentityPoint()
+	for _, id := range s.ids {
+		rho := frostHashToScalar("rho", prefix, scalarFromID(id).Bytes())
+		s.bindingFactors[id] = rho
+		c := s.commitments[id]
+		s.groupCommit.Add(s.groupCommit, c[0])
+		s.groupCommit.Add(s.groupCommit, new(edwards25519.Point).ScalarMult(rho, c[1]))
+	}
+	s.challenge = hashToScalar(s.groupCommit.Bytes(), groupPubKey, msg)
+	return s, nil
+}
+
+// lagrange returns the lagrange coefficient of the signer id for the signers of the session.
+func (s *frostSession) lagrange(id uint32) *edwards25519.Scalar {
+	num, den := scalarFromID(1), scalarFromID(1)
+	xi := scalarFromID(id)
+	for _, j := range s.ids {
+		if j == id {
+			continue
+		}
+		xj := scalarFromID(j)
+		num.Multiply(num, xj)
+		den.Multiply(den, new(edwards25519.Scalar).Subtract(xj, xi))
+	}
+	return num.Multiply(num, den.Invert(den))
+}
+
+// signShare runs round two for the signer holding the nonces and the secret share.
+func (s *frostSession) signShare(nonces *thresholdNonces, secret *edwards25519.Scalar) []byte {
+	id := nonces.commitment.ID
+	z := new(edwards25519.Scalar).MultiplyAdd(nonces.binding, s.bindingFactors[id], nonces.hiding)
+	lambdaSecret := new(edwards25519.Scalar).Multiply(s.lagrange(id), secret)
+	return z.MultiplyAdd(lambdaSecret, s.challenge, z).Bytes()
+}
+
+// verifyShare checks the signature share of the signer against its public verification share.
+func (s *frostSession) verifyShare(id uint32, share []byte, verificationShare []byte) error {
+	z, err := decodeScalar(share)
+	if err != nil {
+		return err
+	}
+	pk, err := decodePoint(verificationShare)
+	if err != nil {
+		return err
+	}
+	c, ok := s.commitments[id]
+	if !ok {
+		return fmt.Errorf("signer %d is not part of the session", id)
+	}
+	expected := new(edwards25519.Point).ScalarMult(s.bindingFactors[id], c[1])
+	expected.Add(expected, c[0])
+	expected.Add(expected, new(edwards25519.Point).ScalarMult(new(edwards25519.Scalar).Multiply(s.challenge, s.lagrange(id)), pk))
+	if basePointMul(z).Equal(expected) != 1 {
+		return fmt.Errorf("invalid signature share of signer %d", id)
+	}
+	return nil
+}
+
+// aggregate sums the signature shares into the ed25519 group signature.
+func (s *frostSession) aggregate(shares map[uint32][]byte) ([]byte, error) {
+	z := edwards25519.NewScalar()
+	for _, id := range s.ids {
+		share, ok := shares[id]
+		if !ok {
+			return nil, fmt.Errorf("missing signature share of signer %d", id)
+		}
+		zi, err := decodeScalar(share)
+		if err != nil {
+			return nil, err
+		}
+		z.Add(z, zi)
+	}
+	return append(s.groupCommit.Bytes(), z.Bytes()...), nil
+}
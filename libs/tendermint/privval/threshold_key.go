@@ -0,0 +1,187 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"filippo.io/edwards25519"
+
+	"github.com/okex/exchain/libs/tendermint/crypto"
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	"github.com/okex/exchain/libs/tendermint/libs/tempfile"
+)
+
+// ThresholdPubKey is the public part of a threshold validator key: the ed25519 group public key
+// the validator is known by, and the public verification share of every signer.
+type ThresholdPubKey struct {
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Threshold uint32        `json:"threshold"`
+	// VerificationShares holds the public key share of the signer with id i at index i-1
+	VerificationShares [][]byte `json:"verification_shares"`
+}
+
+// Total returns the number of signers holding a share of the key.
+func (pk ThresholdPubKey) Total() uint32 {
+	return uint32(len(pk.VerificationShares))
+}
+
+// VerificationShare returns the public key share of the signer, or nil for an unknown signer.
+func (pk ThresholdPubKey) VerificationShare(id uint32) []byte {
+	if id == 0 || id > pk.Total() {
+		return nil
+	}
+	return pk.VerificationShares[id-1]
+}
+
+// groupKeyBytes returns the encoded group public key, the key must have been validated.
+func (pk ThresholdPubKey) groupKeyBytes() []byte {
+	key := pk.PubKey.(ed25519.PubKeyEd25519)
+	return key[:]
+}
+
+// ValidateBasic performs basic validation.
+func (pk ThresholdPubKey) ValidateBasic() error {
+	if _, ok := pk.PubKey.(ed25519.PubKeyEd25519); !ok {
+		return fmt.Errorf("threshold public key must be ed25519, got %T", pk.PubKey)
+	}
+	if pk.Threshold == 0 || pk.Threshold > pk.Total() {
+		return fmt.Errorf("invalid threshold %d of %d signers", pk.Threshold, pk.Total())
+	}
+	// two quorums of signers must overlap in an honest one, otherwise a coordinator could get
+	// conflicting messages signed by disjoint sets of signers
+	if 2*pk.Threshold <= pk.Total() {
+		return fmt.Errorf("threshold %d must be more than half of the %d signers", pk.Threshold, pk.Total())
+	}
+	for i, share := range pk.VerificationShares {
+		if len(share) != thresholdScalarSize {
+			return fmt.Errorf("invalid verification share of signer %d", i+1)
+		}
+	}
+	return nil
+}
+
+// SaveAs persists the ThresholdPubKey to the file.
+func (pk ThresholdPubKey) SaveAs(filePath string) {
+	saveJSONFile(pk, filePath)
+}
+
+// LoadThresholdPubKey loads a ThresholdPubKey from the file.
+func LoadThresholdPubKey(filePath string) (ThresholdPubKey, error) {
+	var pk ThresholdPubKey
+	if err := loadJSONFile(&pk, filePath); err != nil {
+		return ThresholdPubKey{}, err
+	}
+	return pk, pk.ValidateBasic()
+}
+
+// ThresholdKeyShare is the secret key share of a single threshold signer.
+type ThresholdKeyShare struct {
+	ID     uint32          `json:"id"`
+	Share  []byte          `json:"share"`
+	Public ThresholdPubKey `json:"public"`
+}
+
+// secret returns the secret share as a scalar.
+func (key ThresholdKeyShare) secret() (*edwards25519.Scalar, error) {
+	return decodeScalar(key.Share)
+}
+
+// ValidateBasic performs basic validation, it checks that the share matches its public
+// verification share.
+func (key ThresholdKeyShare) ValidateBasic() error {
+	if err := key.Public.ValidateBasic(); err != nil {
+		return err
+	}
+	secret, err := key.secret()
+	if err != nil {
+		return fmt.Errorf("invalid key share: %w", err)
+	}
+	expected := key.Public.VerificationShare(key.ID)
+	if expected == nil {
+		return fmt.Errorf("unknown signer id %d", key.ID)
+	}
+	if !bytes.Equal(basePointMul(secret).Bytes(), expected) {
+		return errors.New("key share does not match its verification share")
+	}
+	return nil
+}
+
+// SaveAs persists the ThresholdKeyShare to the file.
+func (key ThresholdKeyShare) SaveAs(filePath string) {
+	saveJSONFile(key, filePath)
+}
+
+// LoadThresholdKeyShare loads a ThresholdKeyShare from the file.
+func LoadThresholdKeyShare(filePath string) (ThresholdKeyShare, error) {
+	var key ThresholdKeyShare
+	if err := loadJSONFile(&key, filePath); err != nil {
+		return ThresholdKeyShare{}, err
+	}
+	return key, key.ValidateBasic()
+}
+
+// SplitThresholdKey splits the ed25519 validator key into total shares, any threshold of which
+// can sign for the key. The threshold must be more than half of the shares. The caller acts as
+// the trusted dealer and must destroy the original key once the shares are distributed.
+func SplitThresholdKey(privKey ed25519.PrivKeyEd25519, threshold, total uint32) (ThresholdPubKey, []ThresholdKeyShare, error) {
+	if total == 0 || threshold == 0 || threshold > total || 2*threshold <= total {
+		return ThresholdPubKey{}, nil, fmt.Errorf("invalid threshold %d of %d signers", threshold, total)
+	}
+
+	// shamir secret sharing of the ed25519 secret scalar with a random polynomial of degree
+	// threshold-1, the share of the signer with id i is the polynomial at i
+	coefficients := []*edwards25519.Scalar{ed25519SecretScalar(privKey[:32])}
+	for i := uint32(1); i < threshold; i++ {
+		c, err := randomScalar()
+		if err != nil {
+			return ThresholdPubKey{}, nil, err
+		}
+		coefficients = append(coefficients, c)
+	}
+
+	public := ThresholdPubKey{
+		PubKey:    privKey.PubKey(),
+		Threshold: threshold,
+	}
+	shares := make([]ThresholdKeyShare, total)
+	for id := uint32(1); id <= total; id++ {
+		x := scalarFromID(id)
+		y := edwards25519.NewScalar()
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			y.MultiplyAdd(y, x, coefficients[i])
+		}
+		public.VerificationShares = append(public.VerificationShares, basePointMul(y).Bytes())
+		shares[id-1] = ThresholdKeyShare{ID: id, Share: y.Bytes()}
+	}
+	for i := range shares {
+		shares[i].Public = public
+	}
+	return public, shares, nil
+}
+
+func saveJSONFile(o interface{}, filePath string) {
+	if filePath == "" {
+		panic("cannot save threshold key: filePath not set")
+	}
+	jsonBytes, err := cdc.MarshalJSONIndent(o, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	err = tempfile.WriteFileAtomic(filePath, jsonBytes, 0600)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func loadJSONFile(o interface{}, filePath string) error {
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if err = cdc.UnmarshalJSON(jsonBytes, o); err != nil {
+		return fmt.Errorf("error reading threshold key from %v: %w", filePath, err)
+	}
+	return nil
+}
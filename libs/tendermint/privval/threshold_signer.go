@@ -0,0 +1,280 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/okex/exchain/libs/tendermint/crypto"
+	tmos "github.com/okex/exchain/libs/tendermint/libs/os"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+var errThresholdShareOnly = errors.New("threshold signer only signs shares on request of the coordinator")
+
+// ThresholdSigner holds a share of a threshold validator key and serves signature shares to a
+// ThresholdSignerClient through a SignerServer, see NewThresholdSignerServer.
+//
+// Like the FilePV, it persists the height/round/step of the last share it signed and never
+// signs a share below it, or a different message at it. As any quorum of signers contains
+// one that already signed, no coordinator can get two conflicting messages signed.
+type ThresholdSigner struct {
+	Key           ThresholdKeyShare
+	LastSignState FilePVLastSignState
+
+	mtx    sync.Mutex
+	nonces *thresholdNonces // nonces of the last commitment, used at most once
+}
+
+var _ types.PrivValidator = (*ThresholdSigner)(nil)
+
+// NewThresholdSigner returns a signer of the key share, it persists its sign state to the
+// stateFilePath.
+func NewThresholdSigner(key ThresholdKeyShare, stateFilePath string) *ThresholdSigner {
+	return &ThresholdSigner{
+		Key: key,
+		LastSignState: FilePVLastSignState{
+			Step:     stepNone,
+			filePath: stateFilePath,
+		},
+	}
+}
+
+// LoadOrGenThresholdSigner loads the key share from the keyFilePath and the sign state from the
+// stateFilePath, the state is created if it does not exist. The program exits if the key share
+// can't be loaded.
+func LoadOrGenThresholdSigner(keyFilePath, stateFilePath string) *ThresholdSigner {
+	key, err := LoadThresholdKeyShare(keyFilePath)
+	if err != nil {
+		tmos.Exit(fmt.Sprintf("Error reading threshold key share from %v: %v\n", keyFilePath, err))
+	}
+	signer := NewThresholdSigner(key, stateFilePath)
+	if !tmos.FileExists(stateFilePath) {
+		signer.LastSignState.Save()
+		return signer
+	}
+
+	stateJSONBytes, err := ioutil.ReadFile(stateFilePath)
+	if err != nil {
+		tmos.Exit(err.Error())
+	}
+	if err = cdc.UnmarshalJSON(stateJSONBytes, &signer.LastSignState); err != nil {
+		tmos.Exit(fmt.Sprintf("Error reading threshold signer state from %v: %v\n", stateFilePath, err))
+	}
+	signer.LastSignState.filePath = stateFilePath
+	return signer
+}
+
+// GetPubKey returns the group public key of the threshold validator.
+// Implements PrivValidator.
+func (ts *ThresholdSigner) GetPubKey() (crypto.PubKey, error) {
+	return ts.Key.Public.PubKey, nil
+}
+
+// SignVote implements PrivValidator, a threshold signer can't sign a vote on its own.
+func (ts *ThresholdSigner) SignVote(string, *types.Vote) error {
+	return errThresholdShareOnly
+}
+
+// SignProposal implements PrivValidator, a threshold signer can't sign a proposal on its own.
+func (ts *ThresholdSigner) SignProposal(string, *types.Proposal) error {
+	return errThresholdShareOnly
+}
+
+// SignBytes implements PrivValidator, a threshold signer never signs arbitrary bytes.
+func (ts *ThresholdSigner) SignBytes([]byte) ([]byte, error) {
+	return nil, errThresholdShareOnly
+}
+
+// Commit runs the first round of a signature: it draws fresh nonces, replacing the ones of any
+// previous commitment, and returns their commitment.
+func (ts *ThresholdSigner) Commit() (ThresholdCommitment, error) {
+	secret, err := ts.Key.secret()
+	if err != nil {
+		return ThresholdCommitment{}, err
+	}
+	nonces, err := frostCommit(ts.Key.ID, secret)
+	if err != nil {
+		return ThresholdCommitment{}, err
+	}
+
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	ts.nonces = nonces
+	return nonces.commitment, nil
+}
+
+// SignShare runs the second round of a signature: it returns the signature share of the vote or
+// the proposal, given the commitments of the signers taking part.
+func (ts *ThresholdSigner) SignShare(chainID string, req *ThresholdSignRequest) ([]byte, error) {
+	var (
+		height    int64
+		round     int
+		step      int8
+		avc       bool
+		signBytes []byte
+	)
+	switch {
+	case req.Vote != nil && req.Proposal == nil:
+		if req.Vote.Type != types.PrevoteType && req.Vote.Type != types.PrecommitType {
+			return nil, fmt.Errorf("unknown vote type %v", req.Vote.Type)
+		}
+		height, round, step, avc = req.Vote.Height, req.Vote.Round, voteToStep(req.Vote), req.Vote.HasVC
+		signBytes = req.Vote.SignBytes(chainID)
+	case req.Proposal != nil && req.Vote == nil:
+		height, round, step, avc = req.Proposal.Height, req.Proposal.Round, stepPropose, req.Proposal.HasVC
+		signBytes = req.Proposal.SignBytes(chainID)
+	default:
+		return nil, errors.New("threshold sign request must contain either a vote or a proposal")
+	}
+	if uint32(len(req.Commitments)) < ts.Key.Public.Threshold {
+		return nil, fmt.Errorf("got %d commitments, %d signers are required",
+			len(req.Commitments), ts.Key.Public.Threshold)
+	}
+
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	// the nonces are dropped whatever the outcome, so they are never used twice
+	nonces := ts.nonces
+	ts.nonces = nil
+	if nonces == nil || !containsCommitment(req.Commitments, nonces.commitment) {
+		return nil, errors.New("the commitments do not contain the last commitment of the signer")
+	}
+
+	sameHRS, err := ts.LastSignState.CheckHRS(height, round, step, avc)
+	if err != nil {
+		return nil, err
+	}
+	// signing the same message again is harmless, the coordinator may have lost the signature.
+	// Like the FilePV, a message differing only by its timestamp is signed with the last timestamp,
+	// the coordinator learns it from the commitment of the signer.
+	if sameHRS && !bytes.Equal(signBytes, ts.LastSignState.SignBytes) {
+		var ok bool
+		if req.Vote != nil {
+			_, ok = checkVotesOnlyDifferByTimestamp(height, ts.LastSignState.SignBytes, signBytes)
+		} else {
+			_, ok = checkProposalsOnlyDifferByTimestamp(ts.LastSignState.SignBytes, signBytes)
+		}
+		if !ok {
+			return nil, errors.New("conflicting data")
+		}
+		signBytes = ts.LastSignState.SignBytes
+	}
+
+	session, err := newFrostSession(ts.Key.Public.groupKeyBytes(), req.Commitments, signBytes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ts.Key.secret()
+	if err != nil {
+		return nil, err
+	}
+	share := session.signShare(nonces, secret)
+
+	// the share takes the place of the signature in the sign state
+	ts.LastSignState.Height = height
+	ts.LastSignState.Round = round
+	ts.LastSignState.Step = step
+	ts.LastSignState.Signature = share
+	ts.LastSignState.SignBytes = signBytes
+	ts.LastSignState.Save()
+	return share, nil
+}
+
+// lastHRS returns the height/round/step of the last share signed.
+func (ts *ThresholdSigner) lastHRS() (int64, int, int8) {
+	height, round, step, _ := ts.lastSigned()
+	return height, round, step
+}
+
+// lastSigned returns the height/round/step and the sign bytes of the last share signed.
+func (ts *ThresholdSigner) lastSigned() (int64, int, int8, []byte) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	return ts.LastSignState.Height, ts.LastSignState.Round, ts.LastSignState.Step, ts.LastSignState.SignBytes
+}
+
+// String returns a string representation of the ThresholdSigner.
+func (ts *ThresholdSigner) String() string {
+	height, round, step := ts.lastHRS()
+	return fmt.Sprintf("ThresholdSigner{%v #%d LH:%v, LR:%v, LS:%v}",
+		ts.Key.Public.PubKey.Address(), ts.Key.ID, height, round, step)
+}
+
+func containsCommitment(commitments []ThresholdCommitment, c ThresholdCommitment) bool {
+	for _, other := range commitments {
+		if other.ID == c.ID {
+			return bytes.Equal(other.Hiding, c.Hiding) && bytes.Equal(other.Binding, c.Binding)
+		}
+	}
+	return false
+}
+
+//-------------------------------------------------------------------------------
+
+// NewThresholdSignerServer returns a SignerServer serving the signature shares of the signer to
+// the ThresholdSignerClient at the other end of the endpoint.
+func NewThresholdSignerServer(endpoint *SignerDialerEndpoint, chainID string, signer *ThresholdSigner) *SignerServer {
+	ss := NewSignerServer(endpoint, chainID, signer)
+	ss.SetRequestHandler(ThresholdValidationRequestHandler)
+	return ss
+}
+
+// ThresholdValidationRequestHandler handles the requests of a ThresholdSignerClient, privVal
+// must be a *ThresholdSigner.
+func ThresholdValidationRequestHandler(
+	privVal types.PrivValidator,
+	req SignerMessage,
+	chainID string,
+) (SignerMessage, error) {
+	signer, ok := privVal.(*ThresholdSigner)
+	if !ok {
+		return nil, fmt.Errorf("threshold requests need a threshold signer, got %T", privVal)
+	}
+
+	var res SignerMessage
+	var err error
+
+	switch r := req.(type) {
+	case *PubKeyRequest:
+		var pubKey crypto.PubKey
+		pubKey, err = signer.GetPubKey()
+		if err != nil {
+			res = &PubKeyResponse{nil, &RemoteSignerError{0, err.Error()}}
+		} else {
+			res = &PubKeyResponse{pubKey, nil}
+		}
+
+	case *ThresholdCommitRequest:
+		var commitment ThresholdCommitment
+		commitment, err = signer.Commit()
+		height, round, step, signBytes := signer.lastSigned()
+		if err != nil {
+			res = &ThresholdCommitResponse{Height: height, Round: round, Step: step,
+				Error: &RemoteSignerError{0, err.Error()}}
+		} else {
+			res = &ThresholdCommitResponse{Commitment: &commitment, Height: height, Round: round, Step: step,
+				SignBytes: signBytes}
+		}
+
+	case *ThresholdSignRequest:
+		var share []byte
+		share, err = signer.SignShare(chainID, r)
+		if err != nil {
+			res = &ThresholdSignResponse{ID: signer.Key.ID, Error: &RemoteSignerError{0, err.Error()}}
+		} else {
+			res = &ThresholdSignResponse{ID: signer.Key.ID, Share: share}
+		}
+
+	case *PingRequest:
+		err, res = nil, &PingResponse{}
+
+	default:
+		err = fmt.Errorf("unknown msg: %v", r)
+	}
+
+	return res, err
+}
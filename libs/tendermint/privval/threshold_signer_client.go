@@ -0,0 +1,339 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/crypto"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+// ThresholdSignerClient implements PrivValidator.
+// It coordinates the threshold signers connected to its endpoints, each holding a share of the
+// validator key: a vote or a proposal is signed once the threshold of signers agreed to sign
+// their shares of it.
+//
+// The client keeps the high-water mark of the height/round/step the signers report, and
+// refuses to sign below it. It is only a first line of defence: each signer enforces its own
+// mark, so neither a compromised coordinator nor a compromised signer can sign on its own.
+type ThresholdSignerClient struct {
+	pubKey    ThresholdPubKey
+	endpoints []*SignerListenerEndpoint
+
+	mtx           sync.Mutex
+	lastSignState FilePVLastSignState // kept in memory only, the signers persist their own
+	mark          signHRS             // the highest height/round/step reported by the signers
+}
+
+// signHRS is a height/round/step.
+type signHRS struct {
+	height int64
+	round  int
+	step   int8
+}
+
+// raise sets the mark to other if other is higher.
+func (m *signHRS) raise(other signHRS) {
+	if other.height > m.height || other.height == m.height &&
+		(other.round > m.round || other.round == m.round && other.step > m.step) {
+		*m = other
+	}
+}
+
+var _ types.PrivValidator = (*ThresholdSignerClient)(nil)
+
+// NewThresholdSignerClient returns an instance of ThresholdSignerClient.
+// it will start the endpoints (if not already started)
+func NewThresholdSignerClient(pubKey ThresholdPubKey, endpoints []*SignerListenerEndpoint) (*ThresholdSignerClient, error) {
+	if err := pubKey.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if uint32(len(endpoints)) < pubKey.Threshold {
+		return nil, fmt.Errorf("%d endpoints for a threshold of %d signers", len(endpoints), pubKey.Threshold)
+	}
+	for _, endpoint := range endpoints {
+		if !endpoint.IsRunning() {
+			if err := endpoint.Start(); err != nil {
+				return nil, fmt.Errorf("failed to start listener endpoint: %w", err)
+			}
+		}
+	}
+
+	return &ThresholdSignerClient{pubKey: pubKey, endpoints: endpoints}, nil
+}
+
+// Close closes the underlying connections
+func (tc *ThresholdSignerClient) Close() error {
+	var firstErr error
+	for _, endpoint := range tc.endpoints {
+		if err := endpoint.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// WaitForConnection waits maxWait for the threshold of signers to connect, or returns a timeout
+// error
+func (tc *ThresholdSignerClient) WaitForConnection(maxWait time.Duration) error {
+	errs := tc.broadcast(tc.endpoints, func(endpoint *SignerListenerEndpoint) error {
+		return endpoint.WaitForConnection(maxWait)
+	})
+	if connected := uint32(len(tc.endpoints) - len(errs)); connected < tc.pubKey.Threshold {
+		return fmt.Errorf("%d of the %d required signers connected: %v", connected, tc.pubKey.Threshold, errs)
+	}
+	return nil
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// GetPubKey returns the group public key of the threshold validator.
+func (tc *ThresholdSignerClient) GetPubKey() (crypto.PubKey, error) {
+	return tc.pubKey.PubKey, nil
+}
+
+// SignVote requests the threshold signers to sign a vote
+func (tc *ThresholdSignerClient) SignVote(chainID string, vote *types.Vote) error {
+	height, round, step := vote.Height, vote.Round, voteToStep(vote)
+	return tc.sign(height, round, step, vote.HasVC, vote.SignBytes(chainID),
+		func(timestamp time.Time, sig []byte) {
+			if !timestamp.IsZero() {
+				vote.Timestamp = timestamp
+			}
+			vote.Signature = sig
+		},
+		func(timestamp time.Time) []byte {
+			vote.Timestamp = timestamp
+			return vote.SignBytes(chainID)
+		},
+		func(lastSignBytes, signBytes []byte) (time.Time, bool) {
+			return checkVotesOnlyDifferByTimestamp(height, lastSignBytes, signBytes)
+		},
+		&ThresholdSignRequest{Vote: vote},
+	)
+}
+
+// SignProposal requests the threshold signers to sign a proposal
+func (tc *ThresholdSignerClient) SignProposal(chainID string, proposal *types.Proposal) error {
+	return tc.sign(proposal.Height, proposal.Round, stepPropose, proposal.HasVC, proposal.SignBytes(chainID),
+		func(timestamp time.Time, sig []byte) {
+			if !timestamp.IsZero() {
+				proposal.Timestamp = timestamp
+			}
+			proposal.Signature = sig
+		},
+		func(timestamp time.Time) []byte {
+			proposal.Timestamp = timestamp
+			return proposal.SignBytes(chainID)
+		},
+		checkProposalsOnlyDifferByTimestamp,
+		&ThresholdSignRequest{Proposal: proposal},
+	)
+}
+
+// SignBytes is not supported, the threshold signers only sign votes and proposals they can
+// check against their sign state.
+func (tc *ThresholdSignerClient) SignBytes([]byte) ([]byte, error) {
+	return nil, errors.New("threshold signer client does not sign arbitrary bytes")
+}
+
+// sign runs the two rounds of a threshold signature of signBytes at the given height/round/step.
+// Like the FilePV, the last signature is reused for the same message, or for the same message
+// with another timestamp. A message the signers already signed with another timestamp, before
+// the coordinator restarted, is signed again with their timestamp.
+func (tc *ThresholdSignerClient) sign(
+	height int64, round int, step int8, avc bool, signBytes []byte,
+	setSignature func(timestamp time.Time, sig []byte),
+	withTimestamp func(timestamp time.Time) []byte,
+	onlyDifferByTimestamp func(lastSignBytes, signBytes []byte) (time.Time, bool),
+	req *ThresholdSignRequest,
+) error {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	lss := tc.lastSignState
+	sameHRS, err := lss.CheckHRS(height, round, step, avc)
+	if err != nil {
+		return err
+	}
+	if sameHRS {
+		if bytes.Equal(signBytes, lss.SignBytes) {
+			setSignature(time.Time{}, lss.Signature)
+		} else if timestamp, ok := onlyDifferByTimestamp(lss.SignBytes, signBytes); ok {
+			setSignature(timestamp, lss.Signature)
+		} else {
+			err = fmt.Errorf("conflicting data")
+		}
+		return err
+	}
+
+	// round one: collect the commitments of the signers available
+	commitments, signers, signed, err := tc.commit(height, round, step, avc)
+	if err != nil {
+		return err
+	}
+	var timestamp time.Time
+	for _, lastSignBytes := range signed {
+		if bytes.Equal(lastSignBytes, signBytes) {
+			continue
+		}
+		lastTimestamp, ok := onlyDifferByTimestamp(lastSignBytes, signBytes)
+		if !ok || !timestamp.IsZero() {
+			return fmt.Errorf("conflicting data")
+		}
+		timestamp = lastTimestamp
+		signBytes = withTimestamp(timestamp)
+	}
+
+	// round two: collect the signature shares and aggregate them
+	req.Commitments = commitments
+	shares := make(map[uint32][]byte, len(signers))
+	var sharesMtx sync.Mutex
+	errs := tc.broadcast(signers, func(endpoint *SignerListenerEndpoint) error {
+		response, err := endpoint.SendRequest(req)
+		if err != nil {
+			return err
+		}
+		resp, ok := response.(*ThresholdSignResponse)
+		if !ok {
+			return ErrUnexpectedResponse
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		sharesMtx.Lock()
+		shares[resp.ID] = resp.Share
+		sharesMtx.Unlock()
+		return nil
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("threshold signers failed to sign: %v", errs)
+	}
+
+	session, err := newFrostSession(tc.pubKey.groupKeyBytes(), commitments, signBytes)
+	if err != nil {
+		return err
+	}
+	sig, err := session.aggregate(shares)
+	if err != nil {
+		return err
+	}
+	if !tc.pubKey.PubKey.VerifyBytes(signBytes, sig) {
+		// name the culprits, the signature is useless anyway
+		for id, share := range shares {
+			if err := session.verifyShare(id, share, tc.pubKey.VerificationShare(id)); err != nil {
+				return fmt.Errorf("invalid threshold signature: %w", err)
+			}
+		}
+		return errors.New("invalid threshold signature")
+	}
+
+	tc.lastSignState.Height = height
+	tc.lastSignState.Round = round
+	tc.lastSignState.Step = step
+	tc.lastSignState.Signature = sig
+	tc.lastSignState.SignBytes = signBytes
+	setSignature(time.Time{}, sig)
+	return nil
+}
+
+// commit asks every signer for a nonce commitment, and returns the ones of the threshold of
+// signers with the lowest ids along with their endpoints, and the sign bytes of the ones which
+// already signed at the given height/round/step. It fails if a signer reports it already signed
+// past the given height/round/step.
+func (tc *ThresholdSignerClient) commit(height int64, round int, step int8, avc bool) (
+	[]ThresholdCommitment, []*SignerListenerEndpoint, [][]byte, error) {
+	type signerCommitment struct {
+		commitment ThresholdCommitment
+		endpoint   *SignerListenerEndpoint
+		signBytes  []byte
+	}
+	var (
+		mtx       sync.Mutex
+		collected []signerCommitment
+	)
+	errs := tc.broadcast(tc.endpoints, func(endpoint *SignerListenerEndpoint) error {
+		response, err := endpoint.SendRequest(&ThresholdCommitRequest{})
+		if err != nil {
+			return err
+		}
+		resp, ok := response.(*ThresholdCommitResponse)
+		if !ok {
+			return ErrUnexpectedResponse
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if resp.Commitment == nil || tc.pubKey.VerificationShare(resp.Commitment.ID) == nil {
+			return errors.New("invalid commitment")
+		}
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		tc.mark.raise(signHRS{resp.Height, resp.Round, resp.Step})
+		c := signerCommitment{commitment: *resp.Commitment, endpoint: endpoint}
+		if resp.Height == height && resp.Round == round && resp.Step == step && len(resp.SignBytes) != 0 {
+			c.signBytes = resp.SignBytes
+		}
+		collected = append(collected, c)
+		return nil
+	})
+
+	if tc.mark.height > height || tc.mark.height == height && (tc.mark.round > round ||
+		tc.mark.round == round && !avc && tc.mark.step > step) {
+		return nil, nil, nil, fmt.Errorf("a threshold signer already signed at %d/%d/%d, got %d/%d/%d",
+			tc.mark.height, tc.mark.round, tc.mark.step, height, round, step)
+	}
+
+	if uint32(len(collected)) < tc.pubKey.Threshold {
+		return nil, nil, nil, fmt.Errorf("%d of the %d required threshold signers are available: %v",
+			len(collected), tc.pubKey.Threshold, errs)
+	}
+	sort.Slice(collected, func(i, j int) bool {
+		return collected[i].commitment.ID < collected[j].commitment.ID
+	})
+	collected = collected[:tc.pubKey.Threshold]
+
+	commitments := make([]ThresholdCommitment, 0, len(collected))
+	signers := make([]*SignerListenerEndpoint, 0, len(collected))
+	var signed [][]byte
+	for i, c := range collected {
+		if i > 0 && collected[i-1].commitment.ID == c.commitment.ID {
+			return nil, nil, nil, fmt.Errorf("two threshold signers with the id %d", c.commitment.ID)
+		}
+		commitments = append(commitments, c.commitment)
+		signers = append(signers, c.endpoint)
+		if c.signBytes != nil {
+			signed = append(signed, c.signBytes)
+		}
+	}
+	return commitments, signers, signed, nil
+}
+
+// broadcast runs the function for all the endpoints concurrently, and returns the errors.
+func (tc *ThresholdSignerClient) broadcast(endpoints []*SignerListenerEndpoint,
+	f func(endpoint *SignerListenerEndpoint) error) []error {
+	var (
+		wg   sync.WaitGroup
+		mtx  sync.Mutex
+		errs []error
+	)
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func(endpoint *SignerListenerEndpoint) {
+			defer wg.Done()
+			if err := f(endpoint); err != nil {
+				mtx.Lock()
+				errs = append(errs, err)
+				mtx.Unlock()
+			}
+		}(endpoint)
+	}
+	wg.Wait()
+	return errs
+}
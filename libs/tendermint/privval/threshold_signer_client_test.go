@@ -0,0 +1,308 @@
+package privval
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/crypto/ed25519"
+	tmrand "github.com/okex/exchain/libs/tendermint/libs/rand"
+	"github.com/okex/exchain/libs/tendermint/types"
+)
+
+func TestSplitThresholdKey(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	_, _, err := SplitThresholdKey(privKey, 1, 3)
+	require.Error(t, err)
+	_, _, err = SplitThresholdKey(privKey, 4, 3)
+	require.Error(t, err)
+
+	public, shares, err := SplitThresholdKey(privKey, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, privKey.PubKey(), public.PubKey)
+	require.NoError(t, public.ValidateBasic())
+	require.Len(t, shares, 3)
+
+	dir, err := ioutil.TempDir("", "threshold")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, share := range shares {
+		require.NoError(t, share.ValidateBasic())
+		path := filepath.Join(dir, fmt.Sprintf("share%d.json", share.ID))
+		share.SaveAs(path)
+		loaded, err := LoadThresholdKeyShare(path)
+		require.NoError(t, err)
+		require.Equal(t, share, loaded)
+	}
+	public.SaveAs(filepath.Join(dir, "public.json"))
+	loaded, err := LoadThresholdPubKey(filepath.Join(dir, "public.json"))
+	require.NoError(t, err)
+	require.Equal(t, public, loaded)
+
+	// a share that does not match its verification share is rejected
+	shares[0].Share = shares[1].Share
+	require.Error(t, shares[0].ValidateBasic())
+}
+
+func TestFrostSignature(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	public, shares, err := SplitThresholdKey(privKey, 3, 5)
+	require.NoError(t, err)
+	msg := []byte("threshold")
+
+	for _, ids := range [][]uint32{{1, 2, 3}, {2, 4, 5}, {5, 1, 3}} {
+		nonces := make(map[uint32]*thresholdNonces)
+		var commitments []ThresholdCommitment
+		for _, id := range ids {
+			secret, err := shares[id-1].secret()
+			require.NoError(t, err)
+			nonces[id], err = frostCommit(id, secret)
+			require.NoError(t, err)
+			commitments = append(commitments, nonces[id].commitment)
+		}
+
+		session, err := newFrostSession(public.groupKeyBytes(), commitments, msg)
+		require.NoError(t, err)
+		sigShares := make(map[uint32][]byte)
+		for _, id := range ids {
+			secret, err := shares[id-1].secret()
+			require.NoError(t, err)
+			sigShares[id] = session.signShare(nonces[id], secret)
+			require.NoError(t, session.verifyShare(id, sigShares[id], public.VerificationShare(id)))
+		}
+		sig, err := session.aggregate(sigShares)
+		require.NoError(t, err)
+		require.True(t, public.PubKey.VerifyBytes(msg, sig), "signers %v", ids)
+
+		// a share of another signer does not verify
+		require.Error(t, session.verifyShare(ids[0], sigShares[ids[1]], public.VerificationShare(ids[0])))
+	}
+
+	_, err = decodePoint(edwards25519.NewIdentityPoint().Bytes())
+	require.Error(t, err)
+	// the point (0, -1) of order 2 and the generator plus it are outside of the prime order subgroup
+	order2 := append([]byte{0xec}, bytes.Repeat([]byte{0xff}, 30)...)
+	order2 = append(order2, 0x7f)
+	_, err = decodePoint(order2)
+	require.Error(t, err)
+	torsion, err := new(edwards25519.Point).SetBytes(order2)
+	require.NoError(t, err)
+	_, err = decodePoint(new(edwards25519.Point).Add(edwards25519.NewGeneratorPoint(), torsion).Bytes())
+	require.Error(t, err)
+	_, err = decodePoint(edwards25519.NewGeneratorPoint().Bytes())
+	require.NoError(t, err)
+}
+
+type thresholdTestCase struct {
+	chainID string
+	pubKey  ThresholdPubKey
+	client  *ThresholdSignerClient
+	signers []*ThresholdSigner
+	servers []*SignerServer
+}
+
+func (tc thresholdTestCase) stop() {
+	for _, ss := range tc.servers {
+		ss.Stop()
+	}
+	tc.client.Close()
+}
+
+func newThresholdTestCase(t *testing.T, threshold, total uint32) thresholdTestCase {
+	tc := thresholdTestCase{chainID: tmrand.Str(12)}
+	public, shares, err := SplitThresholdKey(ed25519.GenPrivKey(), threshold, total)
+	require.NoError(t, err)
+	tc.pubKey = public
+
+	dir, err := ioutil.TempDir("", "threshold")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	var listeners []*SignerListenerEndpoint
+	for _, share := range shares {
+		unixFilePath, err := testUnixAddr()
+		require.NoError(t, err)
+		sl, sd := getMockEndpoints(t, fmt.Sprintf("unix://%s", unixFilePath), DialUnixFn(unixFilePath))
+		listeners = append(listeners, sl)
+
+		signer := NewThresholdSigner(share, filepath.Join(dir, fmt.Sprintf("state%d.json", share.ID)))
+		ss := NewThresholdSignerServer(sd, tc.chainID, signer)
+		require.NoError(t, ss.Start())
+		tc.signers = append(tc.signers, signer)
+		tc.servers = append(tc.servers, ss)
+	}
+
+	tc.client, err = NewThresholdSignerClient(public, listeners)
+	require.NoError(t, err)
+	return tc
+}
+
+func newTestVote(height int64, round int, voteType types.SignedMsgType) *types.Vote {
+	return &types.Vote{
+		Type:             voteType,
+		Height:           height,
+		Round:            round,
+		Timestamp:        time.Now(),
+		ValidatorAddress: tmrand.Bytes(20),
+	}
+}
+
+func TestThresholdSignerClientSign(t *testing.T) {
+	tc := newThresholdTestCase(t, 2, 3)
+	defer tc.stop()
+
+	pubKey, err := tc.client.GetPubKey()
+	require.NoError(t, err)
+	require.Equal(t, tc.pubKey.PubKey, pubKey)
+
+	proposal := &types.Proposal{Height: 1, Round: 0, Timestamp: time.Now()}
+	require.NoError(t, tc.client.SignProposal(tc.chainID, proposal))
+	assert.True(t, pubKey.VerifyBytes(proposal.SignBytes(tc.chainID), proposal.Signature))
+
+	vote := newTestVote(1, 0, types.PrevoteType)
+	require.NoError(t, tc.client.SignVote(tc.chainID, vote))
+	assert.True(t, pubKey.VerifyBytes(vote.SignBytes(tc.chainID), vote.Signature))
+
+	// the same vote with another timestamp gets the same signature
+	again := *vote
+	again.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, tc.client.SignVote(tc.chainID, &again))
+	assert.Equal(t, vote.Signature, again.Signature)
+	assert.True(t, vote.Timestamp.Equal(again.Timestamp))
+
+	// a conflicting vote is refused
+	conflicting := newTestVote(1, 0, types.PrevoteType)
+	conflicting.BlockID = types.BlockID{Hash: tmrand.Bytes(32)}
+	require.Error(t, tc.client.SignVote(tc.chainID, conflicting))
+
+	// and so is a regression
+	require.Error(t, tc.client.SignProposal(tc.chainID, &types.Proposal{Height: 1, Round: 0, Timestamp: time.Now()}))
+
+	// the signers persisted the high-water mark
+	for _, signer := range tc.signers[:2] {
+		height, round, step := signer.lastHRS()
+		assert.Equal(t, int64(1), height)
+		assert.Equal(t, 0, round)
+		assert.Equal(t, stepPrevote, step)
+	}
+
+	// signing goes on as long as the threshold of signers is available
+	require.NoError(t, tc.servers[0].Stop())
+	vote = newTestVote(1, 0, types.PrecommitType)
+	require.NoError(t, tc.client.SignVote(tc.chainID, vote))
+	assert.True(t, pubKey.VerifyBytes(vote.SignBytes(tc.chainID), vote.Signature))
+
+	require.NoError(t, tc.servers[1].Stop())
+	require.Error(t, tc.client.SignVote(tc.chainID, newTestVote(2, 0, types.PrevoteType)))
+}
+
+func TestThresholdSignerHighWaterMark(t *testing.T) {
+	tc := newThresholdTestCase(t, 2, 3)
+	defer tc.stop()
+
+	vote := newTestVote(5, 1, types.PrecommitType)
+	require.NoError(t, tc.client.SignVote(tc.chainID, vote))
+
+	// a coordinator restarted without state learns the mark from the signers
+	var listeners []*SignerListenerEndpoint
+	listeners = append(listeners, tc.client.endpoints...)
+	client, err := NewThresholdSignerClient(tc.pubKey, listeners)
+	require.NoError(t, err)
+	err = client.SignVote(tc.chainID, newTestVote(5, 0, types.PrecommitType))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already signed")
+
+	// and signs the vote again with another timestamp the way the signers signed it
+	again := *vote
+	again.Signature = nil
+	again.Timestamp = vote.Timestamp.Add(time.Second)
+	require.NoError(t, client.SignVote(tc.chainID, &again))
+	assert.True(t, vote.Timestamp.Equal(again.Timestamp))
+	assert.True(t, tc.pubKey.PubKey.VerifyBytes(again.SignBytes(tc.chainID), again.Signature))
+
+	// a compromised coordinator skipping the check is refused by the signers that signed
+	for _, signer := range tc.signers[:2] {
+		commitment, err := signer.Commit()
+		require.NoError(t, err)
+		otherCommitment, err := tc.signers[2].Commit()
+		require.NoError(t, err)
+		_, err = signer.SignShare(tc.chainID, &ThresholdSignRequest{
+			Vote:        newTestVote(4, 0, types.PrevoteType),
+			Commitments: []ThresholdCommitment{commitment, otherCommitment},
+		})
+		require.Error(t, err)
+	}
+
+	// as are shares of arbitrary bytes
+	_, err = tc.client.SignBytes([]byte("bytes"))
+	require.Error(t, err)
+	require.Error(t, tc.signers[0].SignVote(tc.chainID, newTestVote(6, 0, types.PrevoteType)))
+}
+
+func TestThresholdSignerNonceReuse(t *testing.T) {
+	public, shares, err := SplitThresholdKey(ed25519.GenPrivKey(), 2, 3)
+	require.NoError(t, err)
+	chainID := tmrand.Str(12)
+
+	dir, err := ioutil.TempDir("", "threshold")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	signer := NewThresholdSigner(shares[0], filepath.Join(dir, "state.json"))
+	other := NewThresholdSigner(shares[1], filepath.Join(dir, "other.json"))
+	require.Equal(t, public, signer.Key.Public)
+
+	commitment, err := signer.Commit()
+	require.NoError(t, err)
+	otherCommitment, err := other.Commit()
+	require.NoError(t, err)
+	req := &ThresholdSignRequest{
+		Vote:        newTestVote(1, 0, types.PrevoteType),
+		Commitments: []ThresholdCommitment{commitment, otherCommitment},
+	}
+	_, err = signer.SignShare(chainID, req)
+	require.NoError(t, err)
+
+	// the nonces are gone once used, even for the very same request
+	_, err = signer.SignShare(chainID, req)
+	require.Error(t, err)
+
+	// a vote differing only by its timestamp is signed again as it was signed first
+	vote := *req.Vote
+	vote.Timestamp = vote.Timestamp.Add(time.Second)
+	commitment, err = signer.Commit()
+	require.NoError(t, err)
+	otherCommitment, err = other.Commit()
+	require.NoError(t, err)
+	_, err = signer.SignShare(chainID, &ThresholdSignRequest{
+		Vote:        &vote,
+		Commitments: []ThresholdCommitment{commitment, otherCommitment},
+	})
+	require.NoError(t, err)
+
+	// but not a conflicting one
+	vote.BlockID = types.BlockID{Hash: tmrand.Bytes(32)}
+	commitment, err = signer.Commit()
+	require.NoError(t, err)
+	otherCommitment, err = other.Commit()
+	require.NoError(t, err)
+	_, err = signer.SignShare(chainID, &ThresholdSignRequest{
+		Vote:        &vote,
+		Commitments: []ThresholdCommitment{commitment, otherCommitment},
+	})
+	require.Error(t, err)
+
+	// the state survives a restart
+	shares[0].SaveAs(filepath.Join(dir, "share.json"))
+	reloaded := LoadOrGenThresholdSigner(filepath.Join(dir, "share.json"), filepath.Join(dir, "state.json"))
+	height, _, step := reloaded.lastHRS()
+	assert.Equal(t, int64(1), height)
+	assert.Equal(t, stepPrevote, step)
+}
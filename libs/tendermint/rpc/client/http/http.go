@@ -327,6 +327,15 @@ func (c *baseRPCClient) GetPendingNonce(address string) (*ctypes.ResultPendingNo
 	return result, true
 }
 
+func (c *baseRPCClient) GetPendingPoolTxs() (*ctypes.ResultPendingTxs, error) {
+	result := new(ctypes.ResultPendingTxs)
+	_, err := c.caller.Call("pending_txs", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "pending_txs")
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NetInfo() (*ctypes.ResultNetInfo, error) {
 	result := new(ctypes.ResultNetInfo)
 	_, err := c.caller.Call("net_info", map[string]interface{}{}, result)
@@ -122,6 +122,7 @@ type MempoolClient interface {
 	GetUnconfirmedTxByHash(hash [sha256.Size]byte) (types.Tx, error)
 	GetAddressList() (*ctypes.ResultUnconfirmedAddresses, error)
 	GetPendingNonce(address string) (*ctypes.ResultPendingNonce, bool)
+	GetPendingPoolTxs() (*ctypes.ResultPendingTxs, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
@@ -126,6 +126,10 @@ func (c *Local) GetPendingNonce(address string) (*ctypes.ResultPendingNonce, boo
 	return core.GetPendingNonce(address)
 }
 
+func (c *Local) GetPendingPoolTxs() (*ctypes.ResultPendingTxs, error) {
+	return core.GetPendingTxs(c.ctx)
+}
+
 func (c *Local) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(c.ctx)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/okex/exchain/libs/system/trace"
 	abci "github.com/okex/exchain/libs/tendermint/abci/types"
 	cfg "github.com/okex/exchain/libs/tendermint/config"
+	"github.com/okex/exchain/libs/tendermint/delta"
 	"github.com/okex/exchain/libs/tendermint/global"
 	"github.com/okex/exchain/libs/tendermint/libs/automation"
 	"github.com/okex/exchain/libs/tendermint/libs/fail"
@@ -36,6 +37,18 @@ const (
 	// 2: execute [deliverTx,...] parallel
 	FlagDeliverTxsExecMode = "deliver-txs-mode"
 	FlagEnableConcurrency  = "enable-concurrency"
+
+	// There are two schedulers for the parallel mode.
+	// group: execute the txs grouped by sender and receiver, the groups in parallel (default)
+	// stm: execute every tx speculatively against a multi-version store, then validate its reads
+	FlagParallelScheduler  = "parallel-scheduler"
+	ParallelSchedulerGroup = "group"
+	ParallelSchedulerSTM   = "stm"
+
+	// FlagParallelConflictPrediction groups the evm txs of the group scheduler by the state they are
+	// predicted to access, from their access lists and the storage slots written by the txs calling the
+	// same contracts before, instead of by their receiver
+	FlagParallelConflictPrediction = "parallel-conflict-prediction"
 )
 
 // BlockExecutor handles block execution and state updates.
@@ -90,6 +103,13 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithDeltaBroker shares the deltas through the broker instead of the one set by the flags.
+func BlockExecutorWithDeltaBroker(broker delta.DeltaBroker) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.deltaContext.deltaBroker = broker
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -3,12 +3,15 @@ package state
 import (
 	"fmt"
 	"github.com/okex/exchain/libs/system/trace"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/okex/exchain/libs/iavl"
 	"github.com/okex/exchain/libs/system"
 	"github.com/okex/exchain/libs/tendermint/delta"
+	file_cgi "github.com/okex/exchain/libs/tendermint/delta/file-cgi"
+	p2p_cgi "github.com/okex/exchain/libs/tendermint/delta/p2p-cgi"
 	redis_cgi "github.com/okex/exchain/libs/tendermint/delta/redis-cgi"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	"github.com/spf13/viper"
@@ -110,15 +113,9 @@ func (dc *DeltaContext) init() {
 		if dc.bufferSize < 5 {
 			dc.bufferSize = 5
 		}
-		url := viper.GetString(types.FlagRedisUrl)
-		auth := viper.GetString(types.FlagRedisAuth)
-		expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
-		dbNum := viper.GetInt(types.FlagRedisDB)
-		if dbNum < 0 || dbNum > 15 {
-			panic("delta-redis-db only support 0~15")
+		if dc.deltaBroker == nil {
+			dc.deltaBroker = newDeltaBroker(dc.logger)
 		}
-		dc.deltaBroker = redis_cgi.NewRedisClient(url, auth, expire, dbNum, dc.logger)
-		dc.logger.Info("Init delta broker", "url", url)
 	}
 
 	// control if iavl produce delta or not
@@ -136,6 +133,60 @@ func (dc *DeltaContext) init() {
 
 }
 
+// newDeltaBroker returns the redis or the file delta broker, the p2p delta broker is a reactor of
+// the node passed with BlockExecutorWithDeltaBroker.
+func newDeltaBroker(logger log.Logger) delta.DeltaBroker {
+	expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
+	switch types.DeltaBrokerType {
+	case types.DeltaBrokerFile:
+		dir := viper.GetString(types.FlagDeltaBrokerDir)
+		if dir == "" {
+			panic("delta-broker-dir is required by the file delta broker")
+		}
+		logger.Info("Init delta broker", "dir", dir)
+		return file_cgi.NewFileClient(dir, expire, logger)
+	case types.DeltaBrokerP2P:
+		panic("the p2p delta broker is created by the node")
+	case types.DeltaBrokerRedis, "":
+		url := viper.GetString(types.FlagRedisUrl)
+		auth := viper.GetString(types.FlagRedisAuth)
+		dbNum := viper.GetInt(types.FlagRedisDB)
+		if dbNum < 0 || dbNum > 15 {
+			panic("delta-redis-db only support 0~15")
+		}
+		logger.Info("Init delta broker", "url", url)
+		return redis_cgi.NewRedisClient(url, auth, expire, dbNum, logger)
+	default:
+		panic(fmt.Sprintf("unknown delta broker %s, expected redis, file or p2p", types.DeltaBrokerType))
+	}
+}
+
+// IsP2PDeltaBrokerEnabled returns true if the deltas are shared through the p2p network.
+func IsP2PDeltaBrokerEnabled() bool {
+	return (types.UploadDelta || types.DownloadDelta) && types.DeltaBrokerType == types.DeltaBrokerP2P
+}
+
+// NewP2PDeltaBroker returns the p2p delta broker if it is enabled, the node adds it to its reactors.
+func NewP2PDeltaBroker(logger log.Logger) *p2p_cgi.Reactor {
+	if !IsP2PDeltaBrokerEnabled() {
+		return nil
+	}
+	var trusted []string
+	for _, id := range strings.Split(viper.GetString(types.FlagDeltaTrustedPeers), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			trusted = append(trusted, id)
+		}
+	}
+	if types.DownloadDelta && len(trusted) == 0 {
+		logger.Error("No trusted peer to download delta from, set delta-trusted-peers")
+	}
+	expire := time.Duration(viper.GetInt(types.FlagRedisExpire)) * time.Second
+	reactor := p2p_cgi.NewReactor(trusted, expire)
+	reactor.SetLogger(logger)
+	logger.Info("Init delta broker", "broker", types.DeltaBrokerP2P, "trusted-peers", trusted)
+	return reactor
+}
+
 func (dc *DeltaContext) setIdentity() {
 
 	var err error
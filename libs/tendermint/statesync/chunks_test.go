@@ -0,0 +1,150 @@
+package statesync
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupChunkQueue(t *testing.T) (*chunkQueue, func()) {
+	snapshot := &snapshot{
+		Height: 3,
+		Format: 1,
+		Chunks: 3,
+		Hash:   []byte{7},
+	}
+	queue, err := newChunkQueue(snapshot, "")
+	require.NoError(t, err)
+	return queue, func() { queue.Close() }
+}
+
+func TestNewChunkQueue(t *testing.T) {
+	_, err := newChunkQueue(&snapshot{Height: 1, Format: 1}, "")
+	require.Error(t, err)
+
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+	require.EqualValues(t, 3, queue.Size())
+
+	dir := queue.dir
+	_, err = os.Stat(dir)
+	require.NoError(t, err)
+	require.NoError(t, queue.Close())
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+	require.EqualValues(t, 0, queue.Size())
+}
+
+func TestChunkQueue(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	// chunks are allocated once each
+	for i := uint32(0); i < queue.Size(); i++ {
+		index, err := queue.Allocate()
+		require.NoError(t, err)
+		require.Equal(t, i, index)
+	}
+	_, err := queue.Allocate()
+	require.Equal(t, errDone, err)
+
+	// invalid chunks are rejected
+	_, err = queue.Add(&chunk{Height: 2, Format: 1, Index: 0, Chunk: []byte{1}})
+	require.Error(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 2, Index: 0, Chunk: []byte{1}})
+	require.Error(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 3, Chunk: []byte{1}})
+	require.Error(t, err)
+
+	// chunks are returned in order, regardless of the arrival order
+	for _, index := range []uint32{2, 0, 1} {
+		added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: index, Chunk: []byte{byte(index)}, Sender: "peer"})
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	added, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{9}})
+	require.NoError(t, err)
+	require.False(t, added)
+
+	for i := uint32(0); i < queue.Size(); i++ {
+		c, err := queue.Next()
+		require.NoError(t, err)
+		assert.Equal(t, i, c.Index)
+		assert.Equal(t, []byte{byte(i)}, c.Chunk)
+		assert.EqualValues(t, "peer", c.Sender)
+	}
+	_, err = queue.Next()
+	require.Equal(t, errDone, err)
+
+	// retried chunks are returned again, discarded ones must be fetched again
+	queue.Retry(1)
+	c, err := queue.Next()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, c.Index)
+
+	// returned chunks are kept when discarding the sender, unless discarded explicitly
+	require.NoError(t, queue.DiscardSender("peer"))
+	require.True(t, queue.Has(0))
+	require.NoError(t, queue.Discard(0))
+	require.False(t, queue.Has(0))
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	require.EqualValues(t, 0, index)
+}
+
+func TestChunkQueueWaitFor(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	waiter := queue.WaitFor(1)
+	_, err := queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{1}})
+	require.NoError(t, err)
+	index, ok := <-waiter
+	require.True(t, ok)
+	require.EqualValues(t, 1, index)
+
+	// invalid indexes and closed queues close the channel right away
+	_, ok = <-queue.WaitFor(5)
+	require.False(t, ok)
+	waiter = queue.WaitFor(2)
+	require.NoError(t, queue.Close())
+	_, ok = <-waiter
+	require.False(t, ok)
+}
+
+func TestMessagesValidateBasic(t *testing.T) {
+	testCases := []struct {
+		msg   Message
+		valid bool
+	}{
+		{&snapshotsRequestMessage{}, true},
+		{&snapshotsResponseMessage{Height: 1, Format: 1, Chunks: 2, Hash: []byte{1}}, true},
+		{&snapshotsResponseMessage{Height: 0, Format: 1, Chunks: 2, Hash: []byte{1}}, false},
+		{&snapshotsResponseMessage{Height: 1, Format: 1, Chunks: 0, Hash: []byte{1}}, false},
+		{&snapshotsResponseMessage{Height: 1, Format: 1, Chunks: 2}, false},
+		{&chunkRequestMessage{Height: 1, Format: 1, Index: 0}, true},
+		{&chunkRequestMessage{Height: 0, Format: 1, Index: 0}, false},
+		{&chunkResponseMessage{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}}, true},
+		{&chunkResponseMessage{Height: 1, Format: 1, Index: 0, Missing: true}, true},
+		{&chunkResponseMessage{Height: 1, Format: 1, Index: 0, Chunk: []byte{1}, Missing: true}, false},
+	}
+	for i, tc := range testCases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.NoError(t, err, i)
+		} else {
+			require.Error(t, err, i)
+		}
+
+		bz, err := cdc.MarshalBinaryBare(tc.msg)
+		require.NoError(t, err)
+		decoded, err := decodeMsg(bz)
+		require.NoError(t, err)
+		require.Equal(t, tc.msg, decoded)
+	}
+
+	_, err := decodeMsg(make([]byte, chunkMsgSize+1))
+	require.Error(t, err)
+}
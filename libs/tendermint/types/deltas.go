@@ -29,6 +29,17 @@ const (
 
 	// FlagDeltaVersion specify the DeltaVersion
 	FlagDeltaVersion = "delta-version"
+
+	// FlagDeltaBroker specify where the deltas are shared: redis, file or p2p
+	FlagDeltaBroker = "delta-broker"
+	// FlagDeltaBrokerDir is the shared directory of the file broker
+	FlagDeltaBrokerDir = "delta-broker-dir"
+	// FlagDeltaTrustedPeers are the comma separated IDs of the peers the p2p broker downloads deltas from
+	FlagDeltaTrustedPeers = "delta-trusted-peers"
+
+	DeltaBrokerRedis = "redis"
+	DeltaBrokerFile  = "file"
+	DeltaBrokerP2P   = "p2p"
 )
 
 var (
@@ -43,6 +54,9 @@ var (
 	DownloadDelta = false
 	UploadDelta   = false
 	WasmStoreCode = false
+
+	// DeltaBrokerType is where the deltas are shared, redis, file or p2p
+	DeltaBrokerType = DeltaBrokerRedis
 )
 
 type DeltasMessage struct {
@@ -48,6 +48,30 @@ var (
 	MILESTONE_VENUS7_NAME       = "venus7"
 	milestoneVenus7Height int64 = 0
 
+	MILESTONE_VENUS8_NAME       = "venus8"
+	milestoneVenus8Height int64 = 0
+
+	MILESTONE_VENUS9_NAME       = "venus9"
+	milestoneVenus9Height int64 = 0
+
+	MILESTONE_VENUS10_NAME       = "venus10"
+	milestoneVenus10Height int64 = 0
+
+	MILESTONE_VENUS11_NAME       = "venus11"
+	milestoneVenus11Height int64 = 0
+
+	MILESTONE_VENUS12_NAME       = "venus12"
+	milestoneVenus12Height int64 = 0
+
+	MILESTONE_VENUS13_NAME       = "venus13"
+	milestoneVenus13Height int64 = 0
+
+	MILESTONE_VENUS14_NAME       = "venus14"
+	milestoneVenus14Height int64 = 0
+
+	MILESTONE_VENUS15_NAME       = "venus15"
+	milestoneVenus15Height int64 = 0
+
 	// note: it stores the earlies height of the node,and it is used by cli
 	nodePruneHeight int64
 
@@ -354,3 +378,163 @@ func GetVenus7Height() int64 {
 
 // =========== Venus7 ===============
 // ==================================
+
+// ==================================
+// =========== Venus8 ===============
+func HigherThanVenus8(h int64) bool {
+	if milestoneVenus8Height == 0 {
+		return false
+	}
+	return h > milestoneVenus8Height
+}
+
+func InitMilestoneVenus8Height(h int64) {
+	milestoneVenus8Height = h
+}
+
+func GetVenus8Height() int64 {
+	return milestoneVenus8Height
+}
+
+// =========== Venus8 ===============
+// ==================================
+
+// ==================================
+// =========== Venus9 ===============
+func HigherThanVenus9(h int64) bool {
+	if milestoneVenus9Height == 0 {
+		return false
+	}
+	return h > milestoneVenus9Height
+}
+
+func InitMilestoneVenus9Height(h int64) {
+	milestoneVenus9Height = h
+}
+
+func GetVenus9Height() int64 {
+	return milestoneVenus9Height
+}
+
+// =========== Venus9 ===============
+// ==================================
+
+// ==================================
+// =========== Venus10 ===============
+func HigherThanVenus10(h int64) bool {
+	if milestoneVenus10Height == 0 {
+		return false
+	}
+	return h > milestoneVenus10Height
+}
+
+func InitMilestoneVenus10Height(h int64) {
+	milestoneVenus10Height = h
+}
+
+func GetVenus10Height() int64 {
+	return milestoneVenus10Height
+}
+
+// =========== Venus10 ===============
+// ==================================
+
+// ==================================
+// =========== Venus11 ===============
+func HigherThanVenus11(h int64) bool {
+	if milestoneVenus11Height == 0 {
+		return false
+	}
+	return h > milestoneVenus11Height
+}
+
+func InitMilestoneVenus11Height(h int64) {
+	milestoneVenus11Height = h
+}
+
+func GetVenus11Height() int64 {
+	return milestoneVenus11Height
+}
+
+// =========== Venus11 ===============
+// ==================================
+
+// ==================================
+// =========== Venus12 ===============
+func HigherThanVenus12(h int64) bool {
+	if milestoneVenus12Height == 0 {
+		return false
+	}
+	return h > milestoneVenus12Height
+}
+
+func InitMilestoneVenus12Height(h int64) {
+	milestoneVenus12Height = h
+}
+
+func GetVenus12Height() int64 {
+	return milestoneVenus12Height
+}
+
+// =========== Venus12 ===============
+// ==================================
+
+// ==================================
+// =========== Venus13 ===============
+func HigherThanVenus13(h int64) bool {
+	if milestoneVenus13Height == 0 {
+		return false
+	}
+	return h > milestoneVenus13Height
+}
+
+func InitMilestoneVenus13Height(h int64) {
+	milestoneVenus13Height = h
+}
+
+func GetVenus13Height() int64 {
+	return milestoneVenus13Height
+}
+
+// =========== Venus13 ===============
+// ==================================
+
+// ==================================
+// =========== Venus14 ===============
+func HigherThanVenus14(h int64) bool {
+	if milestoneVenus14Height == 0 {
+		return false
+	}
+	return h > milestoneVenus14Height
+}
+
+func InitMilestoneVenus14Height(h int64) {
+	milestoneVenus14Height = h
+}
+
+func GetVenus14Height() int64 {
+	return milestoneVenus14Height
+}
+
+// =========== Venus14 ===============
+// ==================================
+
+// ==================================
+// =========== Venus15 ===============
+func HigherThanVenus15(h int64) bool {
+	if milestoneVenus15Height == 0 {
+		return false
+	}
+	return h > milestoneVenus15Height
+}
+
+func InitMilestoneVenus15Height(h int64) {
+	milestoneVenus15Height = h
+}
+
+func GetVenus15Height() int64 {
+	return milestoneVenus15Height
+}
+
+// =========== Venus15 ===============
+// ==================================
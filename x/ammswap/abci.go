@@ -7,6 +7,9 @@ import (
 // BeginBlocker check for infraction evidence or downtime of validators
 // on every begin block
 func BeginBlocker(ctx sdk.Context, k Keeper) {
+	if k.GetParams(ctx).EnableTWAP {
+		k.UpdatePriceAccumulators(ctx)
+	}
 }
 
 // EndBlocker called every block, process inflation, update validator set.
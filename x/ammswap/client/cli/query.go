@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/version"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/spf13/cobra"
+	"strconv"
 	"strings"
 )
 
@@ -32,6 +33,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			GetCmdAllSwapTokenPairs(queryRoute, cdc),
 			GetCmdRedeemableAssets(queryRoute, cdc),
 			GetCmdQueryBuyAmount(queryRoute, cdc),
+			GetCmdQueryBestRoute(queryRoute, cdc),
+			GetCmdQueryTWAP(queryRoute, cdc),
 		)...,
 	)
 
@@ -109,6 +112,74 @@ $ %s query swap amount 100eth-245 xxb`, version.ClientName,
 	}
 }
 
+// GetCmdQueryBestRoute queries the route of pools through which the most token is bought
+func GetCmdQueryBestRoute(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "route [token-to-sell] [token-name-to-buy]",
+		Short: "Query the route of pools through which the most token is bought",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the route of at most %d pools through which the most token is bought by the given amount of token to sell.
+
+Example:
+$ %s query swap route 100eth-245 xxb`, types.MaxSwapRouteHops, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := types.NewQueryBestSwapRouteParams(args[0], args[1])
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryBestSwapRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryTWAP queries the time-weighted average prices of the token pair
+func GetCmdQueryTWAP(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "twap [base-token] [quote-token] [window]",
+		Short: "Query the time-weighted average prices of the token pair",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(
+				`Query the time-weighted average prices of the token pair in the last window seconds, at most %d seconds.
+
+Example:
+$ %s query swap twap eth-355 btc-366 3600`, types.MaxTWAPWindow, version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			window, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+			params := types.NewQueryTWAPParams(types.GetSwapTokenPairName(args[0], args[1]), window)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTWAP), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
 // GetCmdQueryParams queries the parameters of the AMM swap system
 func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -30,6 +30,8 @@ const (
 	flagRecipient        = "recipient"
 	flagToken0           = "token0"
 	flagToken1           = "token1"
+	flagPoolType         = "pool-type"
+	flagPools            = "pools"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -44,6 +46,7 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		getCmdRemoveLiquidity(cdc),
 		getCmdCreateExchange(cdc),
 		getCmdTokenSwap(cdc),
+		getCmdRouteSwap(cdc),
 	)...)
 
 	return txCmd
@@ -163,6 +166,7 @@ func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 	// flags
 	var token0 string
 	var token1 string
+	var poolType string
 	cmd := &cobra.Command{
 		Use:   "create-pair",
 		Short: "create token pair",
@@ -171,6 +175,7 @@ func getCmdCreateExchange(cdc *codec.Codec) *cobra.Command {
 
 Example:
 $ exchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01okt 
+$ exchaincli tx swap create-pair --token0 usdt-355 --token1 usdc-366 --pool-type stable_swap --fees 0.01okt
 
 `),
 		),
@@ -179,7 +184,7 @@ $ exchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01ok
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			inBuf := bufio.NewReader(cmd.InOrStdin())
 			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
-			msg := types.NewMsgCreateExchange(token0, token1, cliCtx.FromAddress)
+			msg := types.NewMsgCreateExchangeWithPoolType(token0, token1, poolType, cliCtx.FromAddress)
 
 			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
 		},
@@ -187,6 +192,7 @@ $ exchaincli tx swap create-pair --token0 eth-355 --token1 btc-366 --fees 0.01ok
 
 	cmd.Flags().StringVar(&token0, flagToken0, "", "the base token name is required to create an AMM swap pair")
 	cmd.Flags().StringVar(&token1, flagToken1, "", "the quote token name is required to create an AMM swap pair")
+	cmd.Flags().StringVar(&poolType, flagPoolType, types.PoolTypeConstantProduct, "the curve type of the AMM swap pair: constant_product | stable_swap")
 	cmd.MarkFlagRequired(flagToken0)
 	cmd.MarkFlagRequired(flagToken1)
 	return cmd
@@ -257,3 +263,73 @@ $ exchaincli tx swap token --sell-amount 1eth-355 --min-buy-amount 60btc-366
 
 	return cmd
 }
+
+func getCmdRouteSwap(cdc *codec.Codec) *cobra.Command {
+	// flags
+	var soldTokenAmount string
+	var minBoughtTokenAmount string
+	var pools string
+	var deadline string
+	var recipient string
+	cmd := &cobra.Command{
+		Use:   "route",
+		Short: "swap token through the pools one by one",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`swap token through the pools one by one, the route can be queried by "exchaincli query swap route".
+
+Example:
+$ exchaincli tx swap route --sell-amount 1eth-355 --pools eth-355_okt,btc-366_okt --min-buy-amount 60btc-366
+
+`),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			soldTokenAmount, err := sdk.ParseDecCoin(soldTokenAmount)
+			if err != nil {
+				return err
+			}
+			minBoughtTokenAmount, err := sdk.ParseDecCoin(minBoughtTokenAmount)
+			if err != nil {
+				return err
+			}
+			dur, err := time.ParseDuration(deadline)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(dur).Unix()
+			var recip sdk.AccAddress
+			if recipient == "" {
+				recip = cliCtx.FromAddress
+			} else {
+				recip, err = sdk.AccAddressFromBech32(recipient)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgSwapExactInRoute(soldTokenAmount, strings.Split(pools, ","), minBoughtTokenAmount,
+				deadline, recip, cliCtx.FromAddress)
+
+			return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().StringVarP(&soldTokenAmount, flagSellAmount, "", "",
+		"Amount expected to sell")
+	cmd.Flags().StringVarP(&pools, flagPools, "", "",
+		"Comma separated names of the pools to swap through in order")
+	cmd.Flags().StringVarP(&minBoughtTokenAmount, flagMinBuyAmount, "", "",
+		"Minimum amount expected to buy from the last pool")
+	cmd.Flags().StringVarP(&recipient, flagRecipient, "", "",
+		"The address to receive the amount bought")
+	cmd.Flags().StringVarP(&deadline, flagDeadlineDuration, "", "100s",
+		"Duration after which this transaction can no longer be executed. such as \"300ms\", \"1.5h\" or \"2h45m\". Valid time units are \"ns\", \"us\" (or \"µs\"), \"ms\", \"s\", \"m\", \"h\".")
+	cmd.MarkFlagRequired(flagSellAmount)
+	cmd.MarkFlagRequired(flagPools)
+	cmd.MarkFlagRequired(flagMinBuyAmount)
+
+	return cmd
+}
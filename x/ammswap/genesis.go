@@ -31,6 +31,9 @@ func ValidateGenesis(data GenesisState) error {
 		if !tokentypes.NotAllowedOriginSymbol(record.PoolTokenName) {
 			return fmt.Errorf("invalid SwapTokenPairRecord: PoolToken: %s. Error: invalid PoolToken", record.PoolTokenName)
 		}
+		if err := types.ValidatePoolType(record.PoolType); err != nil {
+			return fmt.Errorf("invalid SwapTokenPairRecord: PoolType: %s", record.PoolType)
+		}
 	}
 	return nil
 }
@@ -45,6 +48,10 @@ func DefaultGenesisState() GenesisState {
 
 // InitGenesis init genesis data to keeper
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	// the genesis exported before the StableSwap pools has no amplification
+	if data.Params.StableSwapAmplification == 0 {
+		data.Params.StableSwapAmplification = types.DefaultStableSwapAmplification
+	}
 	keeper.SetParams(ctx, data.Params)
 	for _, record := range data.SwapTokenPairRecords {
 		keeper.SetSwapTokenPair(ctx, record.TokenPairName(), record)
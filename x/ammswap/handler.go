@@ -1,12 +1,16 @@
 package ammswap
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/okex/exchain/x/ammswap/keeper"
 	"github.com/okex/exchain/x/ammswap/types"
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 // NewHandler creates an sdk.Handler for all the ammswap type messages
@@ -36,6 +40,11 @@ func NewHandler(k Keeper) sdk.Handler {
 			handlerFun = func() (*sdk.Result, error) {
 				return handleMsgTokenToToken(ctx, k, msg)
 			}
+		case types.MsgSwapExactInRoute:
+			name = "handleMsgSwapExactInRoute"
+			handlerFun = func() (*sdk.Result, error) {
+				return handleMsgSwapExactInRoute(ctx, k, msg)
+			}
 		default:
 			return nil, types.ErrSwapUnknownMsgType()
 		}
@@ -60,6 +69,11 @@ func handleMsgTokenToToken(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken)
 func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExchange) (*sdk.Result, error) {
 	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
 
+	// the pool types other than the constant product one are supported since Venus14
+	if !types.IsDefaultPoolType(msg.PoolType) && !tmtypes.HigherThanVenus14(ctx.BlockHeight()) {
+		return types.ErrNotSupportedHeight(fmt.Sprintf("pool type %s", msg.PoolType), ctx.BlockHeight()).Result()
+	}
+
 	// 0. check if 2 tokens exist
 	err := k.IsTokenExist(ctx, msg.Token0Name)
 	if err != nil {
@@ -89,7 +103,7 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 	k.NewPoolToken(ctx, poolTokenName)
 
 	// 4. create the token pair
-	swapTokenPair := types.NewSwapPair(msg.Token0Name, msg.Token1Name)
+	swapTokenPair := types.NewSwapPairWithPoolType(msg.Token0Name, msg.Token1Name, msg.PoolType)
 	k.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
 
 	// 5. notify backend module
@@ -97,6 +111,7 @@ func handleMsgCreateExchange(ctx sdk.Context, k Keeper, msg types.MsgCreateExcha
 
 	event = event.AppendAttributes(sdk.NewAttribute("pool-token-name", poolTokenName))
 	event = event.AppendAttributes(sdk.NewAttribute("token-pair", tokenPairName))
+	event = event.AppendAttributes(sdk.NewAttribute("pool-type", swapTokenPair.GetPoolType()))
 	ctx.EventManager().EmitEvent(event)
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
@@ -328,6 +343,68 @@ func swapTokenByRouter(ctx sdk.Context, k Keeper, msg types.MsgTokenToToken) (*s
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+func handleMsgSwapExactInRoute(ctx sdk.Context, k Keeper, msg types.MsgSwapExactInRoute) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus13(ctx.BlockHeight()) {
+		return types.ErrNotSupportedHeight("route swap", ctx.BlockHeight()).Result()
+	}
+
+	event := sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName))
+
+	if msg.Deadline < ctx.BlockTime().Unix() {
+		return types.ErrBlockTimeBigThanDeadline().Result()
+	}
+	if err := common.HasSufficientCoins(msg.Sender, k.GetTokenKeeper().GetCoins(ctx, msg.Sender),
+		sdk.SysCoins{msg.SoldTokenAmount}); err != nil {
+		return common.ErrInsufficientCoins(DefaultParamspace, err.Error()).Result()
+	}
+
+	params := k.GetParams(ctx)
+	tokenSell := msg.SoldTokenAmount
+	for i, tokenPairName := range msg.Pools {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return nil, err
+		}
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			return types.ErrIsZeroValue("base pooled coin or quote pooled coin").Result()
+		}
+		buyDenom, ok := keeper.GetSwapTokenPairOtherDenom(swapTokenPair, tokenSell.Denom)
+		if !ok {
+			return types.ErrInvalidSwapRoute(fmt.Sprintf("%s is not in %s", tokenSell.Denom, tokenPairName)).Result()
+		}
+		tokenBuy := keeper.CalculateTokenToBuy(swapTokenPair, tokenSell, buyDenom, params)
+		if tokenBuy.IsZero() {
+			return types.ErrIsZeroValue("token buy").Result()
+		}
+
+		// the tokens bought from the middle pools are kept by sender and sold to the next pool
+		recipient := msg.Sender
+		if i == len(msg.Pools)-1 {
+			if buyDenom != msg.MinBoughtTokenAmount.Denom {
+				return types.ErrInvalidSwapRoute(fmt.Sprintf("the last pool %s doesn't buy %s",
+					tokenPairName, msg.MinBoughtTokenAmount.Denom)).Result()
+			}
+			if tokenBuy.Amount.LT(msg.MinBoughtTokenAmount.Amount) {
+				return types.ErrLessThan("token buy amount", "min bought token amount").Result()
+			}
+			recipient = msg.Recipient
+		}
+		hopMsg := types.NewMsgTokenToToken(tokenSell, sdk.NewDecCoinFromDec(buyDenom, sdk.ZeroDec()),
+			msg.Deadline, recipient, msg.Sender)
+		res, err := swapTokenNativeToken(ctx, k, swapTokenPair, tokenBuy, hopMsg)
+		if err != nil {
+			return res, err
+		}
+		tokenSell = tokenBuy
+	}
+
+	event = event.AppendAttributes(sdk.NewAttribute("bought_token_amount", tokenSell.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("recipient", msg.Recipient.String()))
+	event = event.AppendAttributes(sdk.NewAttribute("route", strings.Join(msg.Pools, ",")))
+	ctx.EventManager().EmitEvent(event)
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
 func swapTokenNativeToken(
 	ctx sdk.Context, k Keeper, swapTokenPair SwapTokenPair, tokenBuy sdk.SysCoin,
 	msg types.MsgTokenToToken,
@@ -0,0 +1,36 @@
+package ammswap
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleMsgCreateExchangeWithPoolType(t *testing.T) {
+	ctx, keeper, _ := newRouteTestInput(t)
+	handler := NewHandler(keeper)
+	addr := sdk.AccAddress([]byte("pool-type-sender----"))
+	msg := types.NewMsgCreateExchangeWithPoolType(types.TestBasePooledToken, types.TestQuotePooledToken, types.PoolTypeStableSwap, addr)
+
+	// the stable swap pools are supported since Venus14
+	_, err := handler(ctx, msg)
+	require.Error(t, err)
+	_, err = keeper.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
+	require.Error(t, err)
+
+	// the constant product pools are always supported
+	_, err = handler(ctx, types.NewMsgCreateExchange(types.TestBasePooledToken2, types.TestQuotePooledToken, addr))
+	require.NoError(t, err)
+
+	tmtypes.InitMilestoneVenus14Height(1)
+	defer tmtypes.InitMilestoneVenus14Height(0)
+	ctx.SetBlockHeight(2)
+	_, err = handler(ctx, msg)
+	require.NoError(t, err)
+	swapTokenPair, err := keeper.GetSwapTokenPair(ctx, msg.GetSwapTokenPairName())
+	require.NoError(t, err)
+	require.Equal(t, types.PoolTypeStableSwap, swapTokenPair.GetPoolType())
+}
@@ -0,0 +1,217 @@
+package ammswap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkerrors "github.com/okex/exchain/libs/cosmos-sdk/types/errors"
+	sdkparams "github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/supply"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/ammswap/types"
+	token "github.com/okex/exchain/x/token/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testBankKeeper keeps the coins of the accounts and the module accounts in its store,
+// so the transfers are discarded with the cache context like the real bank
+type testBankKeeper struct {
+	key sdk.StoreKey
+	cdc *codec.Codec
+}
+
+func (k testBankKeeper) GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.SysCoins {
+	var coins sdk.SysCoins
+	if bz := ctx.KVStore(k.key).Get(addr); bz != nil {
+		k.cdc.MustUnmarshalBinaryBare(bz, &coins)
+	}
+	return coins
+}
+
+func (k testBankKeeper) setCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.SysCoins) {
+	if coins.IsZero() {
+		ctx.KVStore(k.key).Delete(addr)
+		return
+	}
+	ctx.KVStore(k.key).Set(addr, k.cdc.MustMarshalBinaryBare(coins))
+}
+
+func (k testBankKeeper) sendCoins(ctx sdk.Context, from, to sdk.AccAddress, amt sdk.Coins) error {
+	fromCoins, hasNeg := k.GetCoins(ctx, from).SafeSub(amt)
+	if hasNeg {
+		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "%s < %s", k.GetCoins(ctx, from), amt)
+	}
+	k.setCoins(ctx, from, fromCoins)
+	k.setCoins(ctx, to, k.GetCoins(ctx, to).Add(amt...))
+	return nil
+}
+
+func (k testBankKeeper) GetSupplyByDenom(ctx sdk.Context, denom string) sdk.Dec {
+	return sdk.ZeroDec()
+}
+
+func (k testBankKeeper) SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) sdk.Error {
+	return k.sendCoins(ctx, supply.NewModuleAddress(senderModule), recipientAddr, amt)
+}
+
+func (k testBankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) sdk.Error {
+	return k.sendCoins(ctx, senderAddr, supply.NewModuleAddress(recipientModule), amt)
+}
+
+func (k testBankKeeper) MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error {
+	addr := supply.NewModuleAddress(moduleName)
+	k.setCoins(ctx, addr, k.GetCoins(ctx, addr).Add(amt...))
+	return nil
+}
+
+func (k testBankKeeper) BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) sdk.Error {
+	addr := supply.NewModuleAddress(moduleName)
+	coins, hasNeg := k.GetCoins(ctx, addr).SafeSub(amt)
+	if hasNeg {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	k.setCoins(ctx, addr, coins)
+	return nil
+}
+
+func (k testBankKeeper) GetTokenInfo(ctx sdk.Context, symbol string) token.Token {
+	return token.Token{Symbol: symbol}
+}
+
+func (k testBankKeeper) NewToken(ctx sdk.Context, token token.Token) {}
+
+func (k testBankKeeper) UpdateToken(ctx sdk.Context, token token.Token) {}
+
+func (k testBankKeeper) TokenExist(ctx sdk.Context, symbol string) bool {
+	return true
+}
+
+func (k testBankKeeper) GetTokensInfo(ctx sdk.Context) (tokens []token.Token) {
+	return nil
+}
+
+func newRouteTestInput(t *testing.T) (sdk.Context, Keeper, testBankKeeper) {
+	keySwap := sdk.NewKVStoreKey(types.StoreKey)
+	keyBank := sdk.NewKVStoreKey("testbank")
+	keyParams := sdk.NewKVStoreKey(sdkparams.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(sdkparams.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keySwap, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyBank, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	cdc := codec.New()
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	bankKeeper := testBankKeeper{key: keyBank, cdc: cdc}
+	paramsKeeper := sdkparams.NewKeeper(cdc, keyParams, tkeyParams)
+	keeper := NewKeeper(bankKeeper, bankKeeper, cdc, keySwap, paramsKeeper.Subspace(types.DefaultParamspace))
+	keeper.SetParams(ctx, types.DefaultParams())
+	return ctx, keeper, bankKeeper
+}
+
+// setRoutePool sets the pool with the same amount of both tokens and puts the tokens in the module account
+func setRoutePool(t *testing.T, ctx sdk.Context, keeper Keeper, bankKeeper testBankKeeper, token0, token1 string, amount int64) string {
+	swapTokenPair := types.NewSwapPair(token0, token1)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(amount)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(amount)
+	keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	require.NoError(t, bankKeeper.MintCoins(ctx, types.ModuleName,
+		sdk.SysCoins{swapTokenPair.BasePooledCoin, swapTokenPair.QuotePooledCoin}.Sort()))
+	return swapTokenPair.TokenPairName()
+}
+
+func TestHandleMsgSwapExactInRoute(t *testing.T) {
+	ctx, keeper, bankKeeper := newRouteTestInput(t)
+	handler := NewHandler(keeper)
+	addr := sdk.AccAddress([]byte("route-swap-sender---"))
+	recipient := sdk.AccAddress([]byte("route-swap-recipient"))
+	pool1 := setRoutePool(t, ctx, keeper, bankKeeper, types.TestBasePooledToken, types.TestQuotePooledToken, 1000)
+	pool2 := setRoutePool(t, ctx, keeper, bankKeeper, types.TestBasePooledToken2, types.TestQuotePooledToken, 1000)
+	sellToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+	bankKeeper.setCoins(ctx, addr, sdk.SysCoins{sellToken})
+
+	expected, err := keeper.CalculateRouteTokenToBuy(ctx, []string{pool1, pool2}, sellToken, keeper.GetParams(ctx))
+	require.NoError(t, err)
+	msg := types.NewMsgSwapExactInRoute(sellToken, []string{pool1, pool2},
+		sdk.NewDecCoinFromDec(types.TestBasePooledToken2, expected.Amount), ctx.BlockTime().Unix()+60, recipient, addr)
+	// the routed swaps are supported since Venus13
+	_, err = handler(ctx, msg)
+	require.Error(t, err)
+
+	tmtypes.InitMilestoneVenus13Height(1)
+	defer tmtypes.InitMilestoneVenus13Height(0)
+	ctx.SetBlockHeight(2)
+	_, err = handler(ctx, msg)
+	require.NoError(t, err)
+
+	// the recipient gets the token of the last pool, the sender keeps nothing of the middle pool
+	require.Equal(t, sdk.SysCoins{expected}, bankKeeper.GetCoins(ctx, recipient))
+	require.True(t, bankKeeper.GetCoins(ctx, addr).IsZero())
+	swapTokenPair1, err := keeper.GetSwapTokenPair(ctx, pool1)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(1010), swapTokenPair1.BasePooledCoin.Amount)
+	swapTokenPair2, err := keeper.GetSwapTokenPair(ctx, pool2)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(1000).Sub(expected.Amount), swapTokenPair2.BasePooledCoin.Amount)
+}
+
+func TestHandleMsgSwapExactInRouteRevert(t *testing.T) {
+	ctx, keeper, bankKeeper := newRouteTestInput(t)
+	tmtypes.InitMilestoneVenus13Height(1)
+	defer tmtypes.InitMilestoneVenus13Height(0)
+	ctx.SetBlockHeight(2)
+	handler := NewHandler(keeper)
+	addr := sdk.AccAddress([]byte("route-swap-sender---"))
+	pool1 := setRoutePool(t, ctx, keeper, bankKeeper, types.TestBasePooledToken, types.TestQuotePooledToken, 1000)
+	pool2 := setRoutePool(t, ctx, keeper, bankKeeper, types.TestBasePooledToken2, types.TestQuotePooledToken, 1000)
+	emptyPool := setRoutePool(t, ctx, keeper, bankKeeper, types.TestBasePooledToken3, types.TestQuotePooledToken, 0)
+	sellToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+	bankKeeper.setCoins(ctx, addr, sdk.SysCoins{sellToken})
+	deadline := ctx.BlockTime().Unix() + 60
+
+	expected, err := keeper.CalculateRouteTokenToBuy(ctx, []string{pool1, pool2}, sellToken, keeper.GetParams(ctx))
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		msg  types.MsgSwapExactInRoute
+	}{
+		{"less than the min bought amount", types.NewMsgSwapExactInRoute(sellToken, []string{pool1, pool2},
+			sdk.NewDecCoinFromDec(types.TestBasePooledToken2, expected.Amount.Add(sdk.OneDec())), deadline, addr, addr)},
+		{"the last pool doesn't buy the token", types.NewMsgSwapExactInRoute(sellToken, []string{pool1, pool2},
+			sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.OneDec()), deadline, addr, addr)},
+		{"the last pool is empty", types.NewMsgSwapExactInRoute(sellToken, []string{pool1, emptyPool},
+			sdk.NewDecCoinFromDec(types.TestBasePooledToken3, sdk.OneDec()), deadline, addr, addr)},
+		{"the last pool doesn't exist", types.NewMsgSwapExactInRoute(sellToken, []string{pool1, "unknown_okt"},
+			sdk.NewDecCoinFromDec("unknown", sdk.OneDec()), deadline, addr, addr)},
+	}
+	for _, test := range tests {
+		// the msg runs on the cache context of the tx, which is discarded when the msg fails
+		cacheCtx, _ := ctx.CacheContext()
+		_, err := handler(cacheCtx, test.msg)
+		require.Error(t, err, test.name)
+		// the first hop was done in the cache context before the failed hop
+		swapTokenPair1, err := keeper.GetSwapTokenPair(cacheCtx, pool1)
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewDec(1010), swapTokenPair1.BasePooledCoin.Amount, test.name)
+
+		require.Equal(t, sdk.SysCoins{sellToken}, bankKeeper.GetCoins(ctx, addr), test.name)
+		for _, pool := range []string{pool1, pool2} {
+			swapTokenPair, err := keeper.GetSwapTokenPair(ctx, pool)
+			require.NoError(t, err)
+			require.Equal(t, sdk.NewDec(1000), swapTokenPair.BasePooledCoin.Amount, test.name)
+			require.Equal(t, sdk.NewDec(1000), swapTokenPair.QuotePooledCoin.Amount, test.name)
+		}
+	}
+}
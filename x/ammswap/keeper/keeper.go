@@ -143,7 +143,10 @@ func (k Keeper) GetTokenKeeper() types.TokenKeeper {
 
 // GetParams gets inflation params from the global param store
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSpace.GetParamSet(ctx, &params)
+	k.paramSpace.GetParamSetForInitGenesis(ctx, &params, [][]byte{types.KeyStableSwapAmplification, types.KeyEnableTWAP})
+	params.StableSwapAmplification = types.DefaultStableSwapAmplification
+	k.paramSpace.GetIfExists(ctx, types.KeyStableSwapAmplification, &params.StableSwapAmplification)
+	k.paramSpace.GetIfExists(ctx, types.KeyEnableTWAP, &params.EnableTWAP)
 	return params
 }
 
@@ -184,7 +187,12 @@ func CalculateTokenToBuy(swapTokenPair types.SwapTokenPair, sellToken sdk.SysCoi
 		inputReserve = swapTokenPair.BasePooledCoin.Amount
 		outputReserve = swapTokenPair.QuotePooledCoin.Amount
 	}
-	tokenBuyAmt := GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	var tokenBuyAmt sdk.Dec
+	if swapTokenPair.IsStableSwap() {
+		tokenBuyAmt = GetStableSwapInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate, params.StableSwapAmplification)
+	} else {
+		tokenBuyAmt = GetInputPrice(sellToken.Amount, inputReserve, outputReserve, params.FeeRate)
+	}
 	tokenBuy := sdk.NewDecCoinFromDec(buyTokenDenom, tokenBuyAmt)
 
 	return tokenBuy
@@ -28,6 +28,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			res, err = querySwapQuoteInfo(ctx, req, k)
 		case types.QuerySwapAddLiquidityQuote:
 			res, err = querySwapAddLiquidityQuote(ctx, req, k)
+		case types.QueryBestSwapRoute:
+			res, err = queryBestSwapRoute(ctx, req, k)
+		case types.QueryTWAP:
+			res, err = queryTWAP(ctx, req, k)
 
 		default:
 			return nil, types.ErrSwapUnknownQueryType()
@@ -57,6 +61,7 @@ func querySwapTokenPair(
 	if err != nil {
 		response = common.GetBaseResponse(nil)
 	} else {
+		tokenPair.PoolType = tokenPair.GetPoolType()
 		response = common.GetBaseResponse(tokenPair)
 	}
 
@@ -126,7 +131,11 @@ func queryParams(ctx sdk.Context, path []string, req abci.RequestQuery, keeper K
 // nolint
 func querySwapTokenPairs(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte,
 	err sdk.Error) {
-	return keeper.cdc.MustMarshalJSON(keeper.GetSwapTokenPairs(ctx)), nil
+	swapTokenPairs := keeper.GetSwapTokenPairs(ctx)
+	for i := range swapTokenPairs {
+		swapTokenPairs[i].PoolType = swapTokenPairs[i].GetPoolType()
+	}
+	return keeper.cdc.MustMarshalJSON(swapTokenPairs), nil
 }
 
 // nolinte
@@ -328,3 +337,54 @@ func querySwapAddLiquidityQuote(ctx sdk.Context, req abci.RequestQuery, keeper K
 	return bz, nil
 
 }
+
+// queryBestSwapRoute returns the route of pools through which the most token is bought
+func queryBestSwapRoute(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryBestSwapRouteParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+	if queryParams.SellTokenAmount == "" || queryParams.BuyToken == "" {
+		return nil, types.ErrSellAmountOrBuyTokenIsEmpty()
+	}
+
+	sellAmount, err := sdk.ParseDecCoin(queryParams.SellTokenAmount)
+	if err != nil {
+		return nil, types.ErrConvertSellTokenAmount(queryParams.SellTokenAmount, err)
+	}
+	if sellAmount.Denom == queryParams.BuyToken {
+		return nil, types.ErrSellAmountEqualBuyToken()
+	}
+
+	route, err := keeper.GetBestSwapRoute(ctx, sellAmount, queryParams.BuyToken)
+	if err != nil {
+		return nil, err
+	}
+	response := common.GetBaseResponse(route)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
+
+// queryTWAP returns the time-weighted average prices of the token pair
+func queryTWAP(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	var queryParams types.QueryTWAPParams
+	err := keeper.cdc.UnmarshalJSON(req.Data, &queryParams)
+	if err != nil {
+		return nil, common.ErrUnMarshalJSONFailed(err.Error())
+	}
+
+	twap, err := keeper.GetTWAP(ctx, queryParams.TokenPairName, queryParams.Window)
+	if err != nil {
+		return nil, err
+	}
+	response := common.GetBaseResponse(twap)
+	bz, err := json.Marshal(response)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// GetSwapTokenPairOtherDenom returns the denom of the other token of the swap token pair
+func GetSwapTokenPairOtherDenom(swapTokenPair types.SwapTokenPair, denom string) (string, bool) {
+	switch denom {
+	case swapTokenPair.BasePooledCoin.Denom:
+		return swapTokenPair.QuotePooledCoin.Denom, true
+	case swapTokenPair.QuotePooledCoin.Denom:
+		return swapTokenPair.BasePooledCoin.Denom, true
+	default:
+		return "", false
+	}
+}
+
+// CalculateRouteTokenToBuy calculates the amount bought by selling the token through the pools of route one by one
+func (k Keeper) CalculateRouteTokenToBuy(ctx sdk.Context, route []string, sellToken sdk.SysCoin, params types.Params) (sdk.SysCoin, error) {
+	tokenBuy := sellToken
+	for _, tokenPairName := range route {
+		swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+		if err != nil {
+			return sdk.SysCoin{}, err
+		}
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			return sdk.SysCoin{}, types.ErrIsZeroValue("base pooled coin or quote pooled coin")
+		}
+		buyDenom, ok := GetSwapTokenPairOtherDenom(swapTokenPair, tokenBuy.Denom)
+		if !ok {
+			return sdk.SysCoin{}, types.ErrInvalidSwapRoute(tokenBuy.Denom + " is not in " + tokenPairName)
+		}
+		tokenBuy = CalculateTokenToBuy(swapTokenPair, tokenBuy, buyDenom, params)
+	}
+	return tokenBuy, nil
+}
+
+// GetBestSwapRoute finds the route of at most MaxSwapRouteHops pools through which the most token is bought.
+// A pool or a token appears at most once in the route, and the empty pools are skipped.
+func (k Keeper) GetBestSwapRoute(ctx sdk.Context, sellToken sdk.SysCoin, buyToken string) (types.SwapRoute, error) {
+	params := k.GetParams(ctx)
+	swapTokenPairs := k.GetSwapTokenPairs(ctx)
+	pairsOfDenom := make(map[string][]int)
+	for i, swapTokenPair := range swapTokenPairs {
+		if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+			continue
+		}
+		pairsOfDenom[swapTokenPair.BasePooledCoin.Denom] = append(pairsOfDenom[swapTokenPair.BasePooledCoin.Denom], i)
+		pairsOfDenom[swapTokenPair.QuotePooledCoin.Denom] = append(pairsOfDenom[swapTokenPair.QuotePooledCoin.Denom], i)
+	}
+
+	var best types.SwapRoute
+	visited := map[string]bool{sellToken.Denom: true}
+	var route []string
+	var search func(tokenIn sdk.SysCoin)
+	search = func(tokenIn sdk.SysCoin) {
+		for _, i := range pairsOfDenom[tokenIn.Denom] {
+			buyDenom, _ := GetSwapTokenPairOtherDenom(swapTokenPairs[i], tokenIn.Denom)
+			if visited[buyDenom] {
+				continue
+			}
+			tokenBuy := CalculateTokenToBuy(swapTokenPairs[i], tokenIn, buyDenom, params)
+			if !tokenBuy.IsPositive() {
+				continue
+			}
+			route = append(route, swapTokenPairs[i].TokenPairName())
+			if buyDenom == buyToken {
+				if best.Route == nil || tokenBuy.Amount.GT(best.BuyAmount.Amount) {
+					best = types.SwapRoute{
+						Route:     append([]string{}, route...),
+						BuyAmount: tokenBuy,
+					}
+				}
+			} else if len(route) < types.MaxSwapRouteHops {
+				visited[buyDenom] = true
+				search(tokenBuy)
+				visited[buyDenom] = false
+			}
+			route = route[:len(route)-1]
+		}
+	}
+	search(sellToken)
+
+	if best.Route == nil {
+		return best, types.ErrNoSwapRoute(sellToken.Denom, buyToken)
+	}
+	return best, nil
+}
@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+// setTestPool sets the pool of token0 and token1 with the same amount of both tokens
+func setTestPool(ctx sdk.Context, keeper Keeper, token0, token1 string, amount int64) string {
+	swapTokenPair := types.NewSwapPair(token0, token1)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(amount)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(amount)
+	keeper.SetSwapTokenPair(ctx, swapTokenPair.TokenPairName(), swapTokenPair)
+	return swapTokenPair.TokenPairName()
+}
+
+func TestCalculateRouteTokenToBuy(t *testing.T) {
+	ctx, keeper := newTWAPTestInput(t)
+	params := keeper.GetParams(ctx)
+	pool1 := setTestPool(ctx, keeper, types.TestBasePooledToken, types.TestQuotePooledToken, 1000)
+	pool2 := setTestPool(ctx, keeper, types.TestBasePooledToken2, types.TestQuotePooledToken, 1000)
+	sellToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+
+	// every hop sells the token bought from the previous pool
+	swapTokenPair1, err := keeper.GetSwapTokenPair(ctx, pool1)
+	require.NoError(t, err)
+	swapTokenPair2, err := keeper.GetSwapTokenPair(ctx, pool2)
+	require.NoError(t, err)
+	quoteBuy := CalculateTokenToBuy(swapTokenPair1, sellToken, types.TestQuotePooledToken, params)
+	expected := CalculateTokenToBuy(swapTokenPair2, quoteBuy, types.TestBasePooledToken2, params)
+	tokenBuy, err := keeper.CalculateRouteTokenToBuy(ctx, []string{pool1, pool2}, sellToken, params)
+	require.NoError(t, err)
+	require.Equal(t, expected, tokenBuy)
+	require.Equal(t, types.TestBasePooledToken2, tokenBuy.Denom)
+
+	// the single pool route
+	tokenBuy, err = keeper.CalculateRouteTokenToBuy(ctx, []string{pool1}, sellToken, params)
+	require.NoError(t, err)
+	require.Equal(t, quoteBuy, tokenBuy)
+
+	// the sold token is not in the first pool
+	_, err = keeper.CalculateRouteTokenToBuy(ctx, []string{pool2, pool1}, sellToken, params)
+	require.Error(t, err)
+
+	// the pool doesn't exist
+	_, err = keeper.CalculateRouteTokenToBuy(ctx, []string{pool1, "unknown_okt"}, sellToken, params)
+	require.Error(t, err)
+
+	// the pool is empty
+	emptyPool := setTestPool(ctx, keeper, types.TestBasePooledToken3, types.TestQuotePooledToken, 0)
+	_, err = keeper.CalculateRouteTokenToBuy(ctx, []string{pool1, emptyPool}, sellToken, params)
+	require.Error(t, err)
+}
+
+func TestGetBestSwapRoute(t *testing.T) {
+	ctx, keeper := newTWAPTestInput(t)
+	params := keeper.GetParams(ctx)
+	pool1 := setTestPool(ctx, keeper, types.TestBasePooledToken, types.TestQuotePooledToken, 1000)
+	pool2 := setTestPool(ctx, keeper, types.TestBasePooledToken2, types.TestQuotePooledToken, 1000)
+	sellToken := sdk.NewDecCoinFromDec(types.TestBasePooledToken, sdk.NewDec(10))
+
+	// the only route goes through the native token
+	route, err := keeper.GetBestSwapRoute(ctx, sellToken, types.TestBasePooledToken2)
+	require.NoError(t, err)
+	require.Equal(t, []string{pool1, pool2}, route.Route)
+	tokenBuy, err := keeper.CalculateRouteTokenToBuy(ctx, route.Route, sellToken, params)
+	require.NoError(t, err)
+	require.Equal(t, tokenBuy, route.BuyAmount)
+
+	// the shallow direct pool buys less than the deep two hop route
+	directPool := setTestPool(ctx, keeper, types.TestBasePooledToken, types.TestBasePooledToken2, 100)
+	route, err = keeper.GetBestSwapRoute(ctx, sellToken, types.TestBasePooledToken2)
+	require.NoError(t, err)
+	require.Equal(t, []string{pool1, pool2}, route.Route)
+	directBuy, err := keeper.CalculateRouteTokenToBuy(ctx, []string{directPool}, sellToken, params)
+	require.NoError(t, err)
+	require.True(t, route.BuyAmount.Amount.GT(directBuy.Amount))
+
+	// the deep direct pool buys more
+	setTestPool(ctx, keeper, types.TestBasePooledToken, types.TestBasePooledToken2, 100000)
+	route, err = keeper.GetBestSwapRoute(ctx, sellToken, types.TestBasePooledToken2)
+	require.NoError(t, err)
+	require.Equal(t, []string{directPool}, route.Route)
+
+	// the empty pools are skipped
+	emptyPool := setTestPool(ctx, keeper, types.TestBasePooledToken3, types.TestQuotePooledToken, 0)
+	_, err = keeper.GetBestSwapRoute(ctx, sellToken, types.TestBasePooledToken3)
+	require.Error(t, err)
+	setTestPool(ctx, keeper, types.TestBasePooledToken3, types.TestQuotePooledToken, 1000)
+	route, err = keeper.GetBestSwapRoute(ctx, sellToken, types.TestBasePooledToken3)
+	require.NoError(t, err)
+	require.Equal(t, types.TestBasePooledToken3, route.BuyAmount.Denom)
+	require.Equal(t, emptyPool, route.Route[len(route.Route)-1])
+	require.True(t, len(route.Route) <= types.MaxSwapRouteHops)
+
+	// no pool has the token
+	_, err = keeper.GetBestSwapRoute(ctx, sellToken, "unknown")
+	require.Error(t, err)
+}
+
+func TestGetBestSwapRouteMaxHops(t *testing.T) {
+	ctx, keeper := newTWAPTestInput(t)
+	// the chain of pools tta_ttb, ttb_ttc, ..., tte_ttf
+	tokens := []string{"tta", "ttb", "ttc", "ttd", "tte", "ttf"}
+	for i := 0; i < len(tokens)-1; i++ {
+		setTestPool(ctx, keeper, tokens[i], tokens[i+1], 1000)
+	}
+	sellToken := sdk.NewDecCoinFromDec(tokens[0], sdk.NewDec(10))
+
+	route, err := keeper.GetBestSwapRoute(ctx, sellToken, tokens[types.MaxSwapRouteHops])
+	require.NoError(t, err)
+	require.Equal(t, types.MaxSwapRouteHops, len(route.Route))
+
+	_, err = keeper.GetBestSwapRoute(ctx, sellToken, tokens[types.MaxSwapRouteHops+1])
+	require.Error(t, err)
+}
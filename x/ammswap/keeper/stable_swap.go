@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// stableSwapIterations limits the Newton's iterations solving the StableSwap invariant
+const stableSwapIterations = 255
+
+var (
+	bigOne   = big.NewInt(1)
+	bigTwo   = big.NewInt(2)
+	bigThree = big.NewInt(3)
+)
+
+// GetStableSwapInputPrice calculates the amount bought from the StableSwap pool, charging the same fee as GetInputPrice.
+// The pool of two tokens keeps the invariant A*4*(x+y) + D = A*4*D + D^3/(4*x*y).
+func GetStableSwapInputPrice(inputAmount, inputReserve, outputReserve, feeRate sdk.Dec, amplification int64) sdk.Dec {
+	inputAmountWithFee := inputAmount.MulTruncate(sdk.OneDec().Sub(feeRate))
+	if amplification <= 0 || !inputAmountWithFee.IsPositive() || !inputReserve.IsPositive() || !outputReserve.IsPositive() {
+		return sdk.ZeroDec()
+	}
+
+	// the calculation works on the integers of the smallest unit of sdk.Dec
+	ann := big.NewInt(amplification * 4)
+	x, y := inputReserve.BigInt(), outputReserve.BigInt()
+	d := getStableSwapD(x, y, ann)
+	newX := new(big.Int).Add(x, inputAmountWithFee.BigInt())
+	newY := getStableSwapY(newX, d, ann)
+
+	// round down by one unit against the error of the iterations
+	output := new(big.Int).Sub(y, newY)
+	output.Sub(output, bigOne)
+	if output.Sign() <= 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDecFromBigIntWithPrec(output, sdk.Precision)
+}
+
+// getStableSwapD solves the invariant D of the reserves x and y by Newton's method
+func getStableSwapD(x, y, ann *big.Int) *big.Int {
+	sum := new(big.Int).Add(x, y)
+	annSum := new(big.Int).Mul(ann, sum)
+	annMinusOne := new(big.Int).Sub(ann, bigOne)
+	twoX := new(big.Int).Mul(x, bigTwo)
+	twoY := new(big.Int).Mul(y, bigTwo)
+
+	d := new(big.Int).Set(sum)
+	for i := 0; i < stableSwapIterations; i++ {
+		// dP = D^3 / (4*x*y)
+		dP := new(big.Int).Mul(d, d)
+		dP.Quo(dP, twoX)
+		dP.Mul(dP, d)
+		dP.Quo(dP, twoY)
+
+		// D = (Ann*S + 2*dP) * D / ((Ann-1)*D + 3*dP)
+		numerator := new(big.Int).Mul(dP, bigTwo)
+		numerator.Add(numerator, annSum)
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Add(denominator, new(big.Int).Mul(dP, bigThree))
+
+		prev := d
+		d = numerator.Quo(numerator, denominator)
+		if convergent(d, prev) {
+			break
+		}
+	}
+	return d
+}
+
+// getStableSwapY solves the reserve y keeping the invariant D with the reserve x by Newton's method
+func getStableSwapY(x, d, ann *big.Int) *big.Int {
+	// c = D^3 / (4*x*Ann), b = x + D/Ann
+	c := new(big.Int).Mul(d, d)
+	c.Quo(c, new(big.Int).Mul(x, bigTwo))
+	c.Mul(c, d)
+	c.Quo(c, new(big.Int).Mul(ann, bigTwo))
+	b := new(big.Int).Quo(d, ann)
+	b.Add(b, x)
+
+	y := new(big.Int).Set(d)
+	for i := 0; i < stableSwapIterations; i++ {
+		// y = (y^2 + c) / (2*y + b - D)
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Mul(y, bigTwo)
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+
+		prev := y
+		y = numerator.Quo(numerator, denominator)
+		if convergent(y, prev) {
+			break
+		}
+	}
+	return y
+}
+
+func convergent(a, b *big.Int) bool {
+	diff := new(big.Int).Sub(a, b)
+	return diff.CmpAbs(bigOne) <= 0
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStableSwapInputPrice(t *testing.T) {
+	reserve := sdk.NewDec(1000000)
+	feeRate := sdk.NewDecWithPrec(3, 3)
+	input := sdk.NewDec(10000)
+
+	// the balanced StableSwap pool trades almost at 1:1 and beats the constant product pool
+	stableOutput := GetStableSwapInputPrice(input, reserve, reserve, feeRate, 100)
+	constantProductOutput := GetInputPrice(input, reserve, reserve, feeRate)
+	require.True(t, stableOutput.GT(constantProductOutput), stableOutput.String())
+	require.True(t, stableOutput.LT(input.MulTruncate(sdk.OneDec().Sub(feeRate))), stableOutput.String())
+	require.True(t, stableOutput.GT(sdk.NewDec(9960)), stableOutput.String())
+
+	// the larger amplification, the less slippage
+	require.True(t, GetStableSwapInputPrice(input, reserve, reserve, feeRate, 1000).GT(stableOutput))
+
+	// the output never drains the pool
+	output := GetStableSwapInputPrice(sdk.NewDec(100000000), reserve, reserve, feeRate, 100)
+	require.True(t, output.IsPositive())
+	require.True(t, output.LT(reserve), output.String())
+
+	require.True(t, GetStableSwapInputPrice(input, reserve, reserve, feeRate, 0).IsZero())
+	require.True(t, GetStableSwapInputPrice(input, sdk.ZeroDec(), reserve, feeRate, 100).IsZero())
+}
+
+func TestCalculateTokenToBuyWithPoolType(t *testing.T) {
+	params := types.DefaultParams()
+	swapTokenPair := types.NewSwapPairWithPoolType("usdc", "usdt", types.PoolTypeStableSwap)
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(1000000)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(1000000)
+	sellToken := sdk.NewDecCoinFromDec("usdc", sdk.NewDec(10000))
+
+	stableBuy := CalculateTokenToBuy(swapTokenPair, sellToken, "usdt", params)
+	require.Equal(t, "usdt", stableBuy.Denom)
+	require.True(t, stableBuy.Amount.Equal(GetStableSwapInputPrice(sellToken.Amount,
+		swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount, params.FeeRate, params.StableSwapAmplification)))
+
+	swapTokenPair.PoolType = ""
+	require.Equal(t, types.PoolTypeConstantProduct, swapTokenPair.GetPoolType())
+	constantProductBuy := CalculateTokenToBuy(swapTokenPair, sellToken, "usdt", params)
+	require.True(t, stableBuy.Amount.GT(constantProductBuy.Amount))
+}
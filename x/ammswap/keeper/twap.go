@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/ammswap/types"
+)
+
+// GetPriceAccumulator gets the latest price accumulator of the token pair
+func (k Keeper) GetPriceAccumulator(ctx sdk.Context, tokenPairName string) (types.PriceAccumulator, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetPriceAccumulatorKey(tokenPairName))
+	if bz == nil {
+		return types.PriceAccumulator{}, false
+	}
+	var accumulator types.PriceAccumulator
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &accumulator)
+	return accumulator, true
+}
+
+// SetPriceAccumulator sets the latest price accumulator of the token pair
+func (k Keeper) SetPriceAccumulator(ctx sdk.Context, tokenPairName string, accumulator types.PriceAccumulator) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(accumulator)
+	ctx.KVStore(k.storeKey).Set(types.GetPriceAccumulatorKey(tokenPairName), bz)
+}
+
+// SetPriceObservation records the price accumulator in the history of the token pair
+func (k Keeper) SetPriceObservation(ctx sdk.Context, tokenPairName string, accumulator types.PriceAccumulator) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(accumulator)
+	ctx.KVStore(k.storeKey).Set(types.GetPriceObservationKey(tokenPairName, accumulator.Timestamp), bz)
+}
+
+// getLastPriceObservation gets the latest price observation at or before the timestamp
+func (k Keeper) getLastPriceObservation(ctx sdk.Context, tokenPairName string, timestamp int64) (types.PriceAccumulator, bool) {
+	if timestamp < 0 {
+		return types.PriceAccumulator{}, false
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(types.GetPriceObservationsKey(tokenPairName), types.GetPriceObservationKey(tokenPairName, timestamp+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.PriceAccumulator{}, false
+	}
+	var accumulator types.PriceAccumulator
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &accumulator)
+	return accumulator, true
+}
+
+// getNextPriceObservation gets the earliest price observation after the timestamp
+func (k Keeper) getNextPriceObservation(ctx sdk.Context, tokenPairName string, timestamp int64) (types.PriceAccumulator, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetPriceObservationKey(tokenPairName, timestamp+1), sdk.PrefixEndBytes(types.GetPriceObservationsKey(tokenPairName)))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return types.PriceAccumulator{}, false
+	}
+	var accumulator types.PriceAccumulator
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &accumulator)
+	return accumulator, true
+}
+
+// prunePriceObservations deletes the observations too old for any twap window,
+// the latest one before the longest window is kept to interpolate the start of it
+func (k Keeper) prunePriceObservations(ctx sdk.Context, tokenPairName string, now int64) {
+	keep, found := k.getLastPriceObservation(ctx, tokenPairName, now-types.MaxTWAPWindow)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.GetPriceObservationsKey(tokenPairName), types.GetPriceObservationKey(tokenPairName, keep.Timestamp))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// UpdatePriceAccumulators accumulates the prices of all the token pairs since the last block.
+// It's called at the beginning of the block, so the prices are the ones at the end of the last block,
+// which can't be moved within the block by the transactions of a single block.
+func (k Keeper) UpdatePriceAccumulators(ctx sdk.Context) {
+	now := ctx.BlockTime().Unix()
+	for _, swapTokenPair := range k.GetSwapTokenPairs(ctx) {
+		tokenPairName := swapTokenPair.TokenPairName()
+		accumulator, found := k.GetPriceAccumulator(ctx, tokenPairName)
+		if !found {
+			if swapTokenPair.BasePooledCoin.IsZero() || swapTokenPair.QuotePooledCoin.IsZero() {
+				continue
+			}
+			accumulator = types.NewPriceAccumulator(now)
+			k.SetPriceAccumulator(ctx, tokenPairName, accumulator)
+			k.SetPriceObservation(ctx, tokenPairName, accumulator)
+			continue
+		}
+		if now <= accumulator.Timestamp {
+			continue
+		}
+
+		accumulator = accumulator.Accumulate(swapTokenPair, now)
+		k.SetPriceAccumulator(ctx, tokenPairName, accumulator)
+		last, found := k.getLastPriceObservation(ctx, tokenPairName, now)
+		if !found || now-last.Timestamp >= types.TWAPObservationInterval {
+			k.SetPriceObservation(ctx, tokenPairName, accumulator)
+			k.prunePriceObservations(ctx, tokenPairName, now)
+		}
+	}
+}
+
+// GetTWAP returns the time-weighted average prices of the token pair in the last window seconds
+func (k Keeper) GetTWAP(ctx sdk.Context, tokenPairName string, window int64) (types.TWAP, error) {
+	if window <= 0 || window > types.MaxTWAPWindow {
+		return types.TWAP{}, types.ErrTWAPUnavailable(tokenPairName, fmt.Sprintf("window should be in (0, %d]", types.MaxTWAPWindow))
+	}
+	swapTokenPair, err := k.GetSwapTokenPair(ctx, tokenPairName)
+	if err != nil {
+		return types.TWAP{}, err
+	}
+	end, found := k.GetPriceAccumulator(ctx, tokenPairName)
+	if !found {
+		return types.TWAP{}, types.ErrTWAPUnavailable(tokenPairName, "no price accumulated")
+	}
+	// the prices last to now since the last accumulation
+	end = end.Accumulate(swapTokenPair, ctx.BlockTime().Unix())
+
+	startTime := end.Timestamp - window
+	start, found := k.getLastPriceObservation(ctx, tokenPairName, startTime)
+	if !found {
+		return types.TWAP{}, types.ErrTWAPUnavailable(tokenPairName, fmt.Sprintf("no price observed before %d", startTime))
+	}
+	next, found := k.getNextPriceObservation(ctx, tokenPairName, startTime)
+	if !found || next.Timestamp > end.Timestamp {
+		next = end
+	}
+	start = start.Interpolate(next, startTime)
+	return types.NewTWAP(tokenPairName, start, end), nil
+}
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/codec"
+	"github.com/okex/exchain/libs/cosmos-sdk/store"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	sdkparams "github.com/okex/exchain/libs/cosmos-sdk/x/params"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/ammswap/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTWAPTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keySwap := sdk.NewKVStoreKey(types.StoreKey)
+	keyParams := sdk.NewKVStoreKey(sdkparams.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(sdkparams.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keySwap, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+
+	cdc := codec.New()
+	types.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	paramsKeeper := sdkparams.NewKeeper(cdc, keyParams, tkeyParams)
+	keeper := NewKeeper(nil, nil, cdc, keySwap, paramsKeeper.Subspace(types.DefaultParamspace))
+	keeper.SetParams(ctx, types.DefaultParams())
+	return ctx, keeper
+}
+
+func TestGetTWAP(t *testing.T) {
+	ctx, keeper := newTWAPTestInput(t)
+	swapTokenPair := types.NewSwapPair(types.TestBasePooledToken, types.TestQuotePooledToken)
+	tokenPairName := swapTokenPair.TokenPairName()
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(100)
+	keeper.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+
+	_, err := keeper.GetTWAP(ctx, tokenPairName, 60)
+	require.Error(t, err)
+
+	// 1 base = 1 quote in [1000, 1600), 1 base = 2 quote in [1600, 1900)
+	setBlockTime := func(seconds int64) {
+		ctx.SetBlockTime(time.Unix(seconds, 0))
+	}
+	for now := int64(1000); now <= 1900; now += 5 {
+		setBlockTime(now)
+		keeper.UpdatePriceAccumulators(ctx)
+		// the swap in the block changes the price
+		if now == 1600 {
+			swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(200)
+			keeper.SetSwapTokenPair(ctx, tokenPairName, swapTokenPair)
+		}
+	}
+
+	twap, err := keeper.GetTWAP(ctx, tokenPairName, 300)
+	require.NoError(t, err)
+	require.Equal(t, int64(1600), twap.StartTime)
+	require.Equal(t, int64(1900), twap.EndTime)
+	require.True(t, sdk.NewDec(2).Equal(twap.BasePrice), twap.BasePrice.String())
+
+	// the start is interpolated between the observations per TWAPObservationInterval
+	twap, err = keeper.GetTWAP(ctx, tokenPairName, 630)
+	require.NoError(t, err)
+	require.Equal(t, int64(1270), twap.StartTime)
+	require.True(t, sdk.MustNewDecFromStr("1.476190476190476190").Equal(twap.BasePrice), twap.BasePrice.String())
+
+	_, err = keeper.GetTWAP(ctx, tokenPairName, 1000)
+	require.Error(t, err)
+	_, err = keeper.GetTWAP(ctx, tokenPairName, types.MaxTWAPWindow+1)
+	require.Error(t, err)
+
+	// the observations older than the longest window are pruned
+	setBlockTime(1900 + types.MaxTWAPWindow + types.TWAPObservationInterval)
+	keeper.UpdatePriceAccumulators(ctx)
+	_, found := keeper.getLastPriceObservation(ctx, tokenPairName, 1800)
+	require.False(t, found)
+	twap, err = keeper.GetTWAP(ctx, tokenPairName, types.MaxTWAPWindow)
+	require.NoError(t, err)
+	require.True(t, sdk.NewDec(2).Equal(twap.BasePrice), twap.BasePrice.String())
+}
@@ -56,6 +56,11 @@ const (
 	CodeIsSwapTokenPairExist                    uint32 = 65043
 	CodeIsPoolTokenPairExist                    uint32 = 65044
 	CodeInternalError                           uint32 = 65045
+	CodeInvalidSwapRoute                        uint32 = 65046
+	CodeNoSwapRoute                             uint32 = 65047
+	CodeInvalidPoolType                         uint32 = 65048
+	CodeTWAPUnavailable                         uint32 = 65049
+	CodeNotSupportedHeight                      uint32 = 65050
 )
 
 func ErrNonExistSwapTokenPair(tokenPairName string) sdk.EnvelopedErr {
@@ -239,3 +244,23 @@ func ErrSwapTokenPairExist() sdk.EnvelopedErr {
 func ErrPoolTokenPairExist() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeIsPoolTokenPairExist, "the pool token pair already exists")}
 }
+
+func ErrInvalidSwapRoute(reason string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidSwapRoute, fmt.Sprintf("invalid swap route: %s", reason))}
+}
+
+func ErrNoSwapRoute(sellToken, buyToken string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNoSwapRoute, fmt.Sprintf("no swap route from %s to %s", sellToken, buyToken))}
+}
+
+func ErrInvalidPoolType(poolType string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeInvalidPoolType, fmt.Sprintf("invalid pool type: %s", poolType))}
+}
+
+func ErrTWAPUnavailable(tokenPairName string, reason string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeTWAPUnavailable, fmt.Sprintf("twap of %s is unavailable: %s", tokenPairName, reason))}
+}
+
+func ErrNotSupportedHeight(feature string, height int64) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNotSupportedHeight, fmt.Sprintf("%s is not supported at height %d", feature, height))}
+}
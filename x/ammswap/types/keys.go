@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "ammswap"
@@ -21,14 +25,39 @@ const (
 	QueryBuyAmount             = "buy"
 	QuerySwapQuoteInfo         = "swapQuoteInfo"
 	QuerySwapAddLiquidityQuote = "swapAddLiquidityQuote"
+	QueryBestSwapRoute         = "bestSwapRoute"
+	QueryTWAP                  = "twap"
 )
 
 var (
 	// TokenPairPrefixKey to be used for KVStore
 	TokenPairPrefixKey = []byte{0x01}
+	// PriceAccumulatorPrefixKey to be used for the latest price accumulator of the token pairs
+	PriceAccumulatorPrefixKey = []byte{0x02}
+	// PriceObservationPrefixKey to be used for the price accumulator history of the token pairs
+	PriceObservationPrefixKey = []byte{0x03}
 )
 
 // nolint
 func GetTokenPairKey(key string) []byte {
 	return append(TokenPairPrefixKey, []byte(key)...)
 }
+
+// GetPriceAccumulatorKey returns the key of the latest price accumulator of the token pair
+func GetPriceAccumulatorKey(tokenPairName string) []byte {
+	return append(PriceAccumulatorPrefixKey, []byte(tokenPairName)...)
+}
+
+// GetPriceObservationsKey returns the prefix of the price observations of the token pair,
+// the name is length prefixed so the observations of a token pair never share the prefix with another one
+func GetPriceObservationsKey(tokenPairName string) []byte {
+	key := make([]byte, 0, len(PriceObservationPrefixKey)+1+len(tokenPairName))
+	key = append(key, PriceObservationPrefixKey...)
+	key = append(key, byte(len(tokenPairName)))
+	return append(key, []byte(tokenPairName)...)
+}
+
+// GetPriceObservationKey returns the key of the price observation of the token pair at the timestamp
+func GetPriceObservationKey(tokenPairName string, timestamp int64) []byte {
+	return append(GetPriceObservationsKey(tokenPairName), sdk.Uint64ToBigEndian(uint64(timestamp))...)
+}
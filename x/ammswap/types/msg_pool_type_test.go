@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/global"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgCreateExchangeWithPoolType(t *testing.T) {
+	addr := sdk.AccAddress([]byte("pool_type_sender____"))
+	msg := NewMsgCreateExchangeWithPoolType(TestBasePooledToken, TestQuotePooledToken, PoolTypeStableSwap, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.NotNil(t, NewMsgCreateExchangeWithPoolType(TestBasePooledToken, TestQuotePooledToken, "unknown", addr).ValidateBasic())
+
+	// the stable swap pools are supported since Venus14
+	global.SetGlobalHeight(2)
+	defer global.SetGlobalHeight(0)
+	require.NotNil(t, msg.ValidateBasic())
+	require.Nil(t, NewMsgCreateExchange(TestBasePooledToken, TestQuotePooledToken, addr).ValidateBasic())
+	tmtypes.InitMilestoneVenus14Height(1)
+	defer tmtypes.InitMilestoneVenus14Height(0)
+	require.Nil(t, msg.ValidateBasic())
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/global"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgSwapExactInRoute(t *testing.T) {
+	addr := sdk.AccAddress([]byte("route_swap_sender___"))
+	soldTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(2))
+	minBoughtTokenAmount := sdk.NewDecCoinFromDec(TestBasePooledToken2, sdk.NewDec(1))
+	pools := []string{
+		GetSwapTokenPairName(TestBasePooledToken, TestQuotePooledToken),
+		GetSwapTokenPairName(TestBasePooledToken2, TestQuotePooledToken),
+	}
+	deadline := time.Now().Unix()
+
+	msg := NewMsgSwapExactInRoute(soldTokenAmount, pools, minBoughtTokenAmount, deadline, addr, addr)
+	require.Nil(t, msg.ValidateBasic())
+	require.Equal(t, RouterKey, msg.Route())
+	require.Equal(t, TypeMsgSwapExactInRoute, msg.Type())
+	require.Equal(t, []sdk.AccAddress{addr}, msg.GetSigners())
+
+	tests := []struct {
+		name string
+		msg  MsgSwapExactInRoute
+	}{
+		{"empty sender", NewMsgSwapExactInRoute(soldTokenAmount, pools, minBoughtTokenAmount, deadline, addr, nil)},
+		{"empty recipient", NewMsgSwapExactInRoute(soldTokenAmount, pools, minBoughtTokenAmount, deadline, nil, addr)},
+		{"zero sold amount", NewMsgSwapExactInRoute(sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.ZeroDec()), pools, minBoughtTokenAmount, deadline, addr, addr)},
+		{"same token", NewMsgSwapExactInRoute(soldTokenAmount, pools, sdk.NewDecCoinFromDec(TestBasePooledToken, sdk.NewDec(1)), deadline, addr, addr)},
+		{"no pool", NewMsgSwapExactInRoute(soldTokenAmount, nil, minBoughtTokenAmount, deadline, addr, addr)},
+		{"too many pools", NewMsgSwapExactInRoute(soldTokenAmount, make([]string, MaxSwapRouteHops+1), minBoughtTokenAmount, deadline, addr, addr)},
+		{"empty pool name", NewMsgSwapExactInRoute(soldTokenAmount, []string{pools[0], ""}, minBoughtTokenAmount, deadline, addr, addr)},
+	}
+	for _, test := range tests {
+		require.NotNil(t, test.msg.ValidateBasic(), test.name)
+	}
+
+	// the routed swaps are supported since Venus13
+	global.SetGlobalHeight(2)
+	defer global.SetGlobalHeight(0)
+	require.NotNil(t, msg.ValidateBasic())
+	tmtypes.InitMilestoneVenus13Height(1)
+	defer tmtypes.InitMilestoneVenus13Height(0)
+	require.Nil(t, msg.ValidateBasic())
+}
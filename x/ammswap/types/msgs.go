@@ -1,13 +1,21 @@
 package types
 
 import (
+	"fmt"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/global"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 )
 
 // PoolSwap message types and routes
 const (
-	TypeMsgAddLiquidity = "add_liquidity"
-	TypeMsgTokenSwap    = "token_swap"
+	TypeMsgAddLiquidity     = "add_liquidity"
+	TypeMsgTokenSwap        = "token_swap"
+	TypeMsgSwapExactInRoute = "swap_exact_in_route"
+
+	// MaxSwapRouteHops is the max number of pools a routed swap goes through
+	MaxSwapRouteHops = 4
 )
 
 // MsgAddLiquidity Deposit quote_amount and base_amount at current ratio to mint pool tokens.
@@ -142,7 +150,8 @@ func (msg MsgRemoveLiquidity) GetSwapTokenPairName() string {
 type MsgCreateExchange struct {
 	Token0Name string         `json:"token0_name"`
 	Token1Name string         `json:"token1_name"`
-	Sender     sdk.AccAddress `json:"sender"` // Sender
+	Sender     sdk.AccAddress `json:"sender"`              // Sender
+	PoolType   string         `json:"pool_type,omitempty"` // The curve type of pool, default constant product
 }
 
 // NewMsgCreateExchange create a new exchange with token
@@ -154,6 +163,16 @@ func NewMsgCreateExchange(token0Name string, token1Name string, sender sdk.AccAd
 	}
 }
 
+// NewMsgCreateExchangeWithPoolType create a new exchange with token and the curve type of pool
+func NewMsgCreateExchangeWithPoolType(token0Name string, token1Name string, poolType string, sender sdk.AccAddress) MsgCreateExchange {
+	msg := NewMsgCreateExchange(token0Name, token1Name, sender)
+	// the constant product pool leaves the pool type empty, so the msg is the same as the one created before
+	if poolType != PoolTypeConstantProduct {
+		msg.PoolType = poolType
+	}
+	return msg
+}
+
 // Route should return the name of the module
 func (msg MsgCreateExchange) Route() string { return RouterKey }
 
@@ -176,6 +195,13 @@ func (msg MsgCreateExchange) ValidateBasic() sdk.Error {
 	if msg.Token0Name == msg.Token1Name {
 		return ErrToken0NameEqualToken1Name()
 	}
+
+	if err := ValidatePoolType(msg.PoolType); err != nil {
+		return err
+	}
+	if !IsDefaultPoolType(msg.PoolType) && global.GetGlobalHeight() > 0 && !tmtypes.HigherThanVenus14(global.GetGlobalHeight()) {
+		return ErrNotSupportedHeight(fmt.Sprintf("pool type %s", msg.PoolType), global.GetGlobalHeight())
+	}
 	return nil
 }
 
@@ -265,3 +291,82 @@ func (msg MsgTokenToToken) GetSigners() []sdk.AccAddress {
 func (msg MsgTokenToToken) GetSwapTokenPairName() string {
 	return GetSwapTokenPairName(msg.MinBoughtTokenAmount.Denom, msg.SoldTokenAmount.Denom)
 }
+
+// MsgSwapExactInRoute defines the message for swapping the exact amount of token through the pools one by one
+type MsgSwapExactInRoute struct {
+	SoldTokenAmount      sdk.SysCoin    `json:"sold_token_amount"`       // Amount of Tokens sold.
+	Pools                []string       `json:"pools"`                   // Names of the swap token pairs to swap through in order.
+	MinBoughtTokenAmount sdk.SysCoin    `json:"min_bought_token_amount"` // Minimum token purchased from the last pool.
+	Deadline             int64          `json:"deadline"`                // Time after which this transaction can no longer be executed.
+	Recipient            sdk.AccAddress `json:"recipient"`               // Recipient address,transfer Tokens to recipient.default recipient is sender.
+	Sender               sdk.AccAddress `json:"sender"`                  // Sender
+}
+
+// NewMsgSwapExactInRoute is a constructor function for MsgSwapExactInRoute
+func NewMsgSwapExactInRoute(
+	soldTokenAmount sdk.SysCoin, pools []string, minBoughtTokenAmount sdk.SysCoin, deadline int64, recipient, sender sdk.AccAddress,
+) MsgSwapExactInRoute {
+	return MsgSwapExactInRoute{
+		SoldTokenAmount:      soldTokenAmount,
+		Pools:                pools,
+		MinBoughtTokenAmount: minBoughtTokenAmount,
+		Deadline:             deadline,
+		Recipient:            recipient,
+		Sender:               sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSwapExactInRoute) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSwapExactInRoute) Type() string { return TypeMsgSwapExactInRoute }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSwapExactInRoute) ValidateBasic() sdk.Error {
+	if global.GetGlobalHeight() > 0 && !tmtypes.HigherThanVenus13(global.GetGlobalHeight()) {
+		return ErrNotSupportedHeight("route swap", global.GetGlobalHeight())
+	}
+
+	if msg.Sender.Empty() {
+		return ErrAddressIsRequire("sender")
+	}
+
+	if msg.Recipient.Empty() {
+		return ErrAddressIsRequire("recipient")
+	}
+
+	if !(msg.SoldTokenAmount.IsPositive()) {
+		return ErrSoldTokenAmountIsNegative()
+	}
+	if !msg.SoldTokenAmount.IsValid() {
+		return ErrSoldTokenAmount()
+	}
+
+	if !msg.MinBoughtTokenAmount.IsValid() {
+		return ErrMinBoughtTokenAmount()
+	}
+
+	if msg.SoldTokenAmount.Denom == msg.MinBoughtTokenAmount.Denom {
+		return ErrInvalidSwapRoute("sold token is the same as bought token")
+	}
+	if len(msg.Pools) == 0 || len(msg.Pools) > MaxSwapRouteHops {
+		return ErrInvalidSwapRoute(fmt.Sprintf("the number of pools should be in [1, %d]", MaxSwapRouteHops))
+	}
+	for _, tokenPairName := range msg.Pools {
+		if tokenPairName == "" {
+			return ErrInvalidSwapRoute("empty swap token pair name")
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSwapExactInRoute) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSwapExactInRoute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
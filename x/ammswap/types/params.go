@@ -13,6 +13,12 @@ var (
 	defaultFeeRate = sdk.NewDecWithPrec(3, 3)
 )
 
+// Bounds of the amplification coefficient of the StableSwap pools
+const (
+	DefaultStableSwapAmplification int64 = 100
+	MaxStableSwapAmplification     int64 = 1000000
+)
+
 // Default parameter namespace
 const (
 	DefaultParamspace = ModuleName
@@ -23,6 +29,11 @@ const (
 // Parameter store keys
 var (
 	KeyFeeRate = []byte("FeeRate")
+
+	// KeyStableSwapAmplification is added after genesis, so it's read with GetIfExists
+	KeyStableSwapAmplification = []byte("StableSwapAmplification")
+	// KeyEnableTWAP is added after genesis, so it's read with GetIfExists
+	KeyEnableTWAP = []byte("EnableTWAP")
 )
 
 // ParamKeyTable for swap module
@@ -33,19 +44,27 @@ func ParamKeyTable() params.KeyTable {
 // Params - used for initializing default parameter for swap at genesis
 type Params struct {
 	FeeRate sdk.Dec `json:"fee_rate"`
+	// amplification coefficient of the StableSwap invariant shared by all the StableSwap pools
+	StableSwapAmplification int64 `json:"stable_swap_amplification"`
+	// whether the price accumulators of the token pairs are updated every block
+	EnableTWAP bool `json:"enable_twap"`
 }
 
 // NewParams creates a new Params object
 func NewParams(feeRate sdk.Dec) Params {
 	return Params{
-		FeeRate: feeRate,
+		FeeRate:                 feeRate,
+		StableSwapAmplification: DefaultStableSwapAmplification,
+		EnableTWAP:              true,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
 	return fmt.Sprintf(`Poolswap Params:
-  TradeFeeRate: %s`, p.FeeRate)
+  TradeFeeRate: %s
+  StableSwapAmplification: %d
+  EnableTWAP: %t`, p.FeeRate, p.StableSwapAmplification, p.EnableTWAP)
 }
 
 func validateParams(value interface{}) error {
@@ -63,10 +82,31 @@ func validateParams(value interface{}) error {
 	return nil
 }
 
+func validateStableSwapAmplification(value interface{}) error {
+	v, ok := value.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", value)
+	}
+
+	if v <= 0 || v > MaxStableSwapAmplification {
+		return fmt.Errorf("stable swap amplification should be in (0, %d]: %d", MaxStableSwapAmplification, v)
+	}
+	return nil
+}
+
+func validateEnableTWAP(value interface{}) error {
+	if _, ok := value.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", value)
+	}
+	return nil
+}
+
 // ParamSetPairs implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		{Key: KeyFeeRate, Value: &p.FeeRate, ValidatorFn: validateParams},
+		{Key: KeyStableSwapAmplification, Value: &p.StableSwapAmplification, ValidatorFn: validateStableSwapAmplification},
+		{Key: KeyEnableTWAP, Value: &p.EnableTWAP, ValidatorFn: validateEnableTWAP},
 	}
 }
 
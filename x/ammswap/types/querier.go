@@ -50,3 +50,37 @@ type QueryBuyAmountParams struct {
 	SoldToken  sdk.SysCoin
 	TokenToBuy string
 }
+
+// nolint
+type QueryBestSwapRouteParams struct {
+	SellTokenAmount string `json:"sell_token_amount"`
+	BuyToken        string `json:"buy_token"`
+}
+
+// NewQueryBestSwapRouteParams creates a new instance of QueryBestSwapRouteParams
+func NewQueryBestSwapRouteParams(sellTokenAmount string, buyToken string) QueryBestSwapRouteParams {
+	return QueryBestSwapRouteParams{
+		SellTokenAmount: sellTokenAmount,
+		BuyToken:        buyToken,
+	}
+}
+
+// SwapRoute is the route of pools with the most amount bought
+type SwapRoute struct {
+	Route     []string    `json:"route"`
+	BuyAmount sdk.SysCoin `json:"buy_amount"`
+}
+
+// QueryTWAPParams defines the params of querying the twap of the token pair in the last Window seconds
+type QueryTWAPParams struct {
+	TokenPairName string `json:"token_pair_name"`
+	Window        int64  `json:"window"`
+}
+
+// NewQueryTWAPParams creates a new instance of QueryTWAPParams
+func NewQueryTWAPParams(tokenPairName string, window int64) QueryTWAPParams {
+	return QueryTWAPParams{
+		TokenPairName: tokenPairName,
+		Window:        window,
+	}
+}
@@ -12,20 +12,37 @@ import (
 // PoolTokenPrefix defines pool token prefix name
 const PoolTokenPrefix = "ammswap_"
 
+// Pool types define the invariant curve used by the swap token pair
+const (
+	PoolTypeConstantProduct = "constant_product"
+	PoolTypeStableSwap      = "stable_swap"
+)
+
 // SwapTokenPair defines token pair exchange
 type SwapTokenPair struct {
-	QuotePooledCoin sdk.SysCoin `json:"quote_pooled_coin"` // The volume of quote token in the token pair exchange pool
-	BasePooledCoin  sdk.SysCoin `json:"base_pooled_coin"`  // The volume of base token in the token pair exchange pool
-	PoolTokenName   string      `json:"pool_token_name"`   // The name of pool token
+	QuotePooledCoin sdk.SysCoin `json:"quote_pooled_coin"`   // The volume of quote token in the token pair exchange pool
+	BasePooledCoin  sdk.SysCoin `json:"base_pooled_coin"`    // The volume of base token in the token pair exchange pool
+	PoolTokenName   string      `json:"pool_token_name"`     // The name of pool token
+	PoolType        string      `json:"pool_type,omitempty"` // The curve type of pool, empty for the constant product pool
 }
 
 func NewSwapPair(token0, token1 string) SwapTokenPair {
 	base, quote := GetBaseQuoteTokenName(token0, token1)
 
 	swapTokenPair := SwapTokenPair{
-		sdk.NewDecCoinFromDec(quote, sdk.ZeroDec()),
-		sdk.NewDecCoinFromDec(base, sdk.ZeroDec()),
-		GetPoolTokenName(token0, token1),
+		QuotePooledCoin: sdk.NewDecCoinFromDec(quote, sdk.ZeroDec()),
+		BasePooledCoin:  sdk.NewDecCoinFromDec(base, sdk.ZeroDec()),
+		PoolTokenName:   GetPoolTokenName(token0, token1),
+	}
+	return swapTokenPair
+}
+
+// NewSwapPairWithPoolType creates an empty swap token pair of the pool type.
+// The constant product pool leaves the pool type empty, so it's encoded the same as the pools created before.
+func NewSwapPairWithPoolType(token0, token1, poolType string) SwapTokenPair {
+	swapTokenPair := NewSwapPair(token0, token1)
+	if poolType != PoolTypeConstantProduct {
+		swapTokenPair.PoolType = poolType
 	}
 	return swapTokenPair
 }
@@ -44,7 +61,21 @@ func NewSwapTokenPair(quotePooledCoin sdk.SysCoin, basePooledCoin sdk.SysCoin, p
 func (s SwapTokenPair) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`QuotePooledCoin: %s
 BasePooledCoin: %s
-PoolTokenName: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName))
+PoolTokenName: %s
+PoolType: %s`, s.QuotePooledCoin.String(), s.BasePooledCoin.String(), s.PoolTokenName, s.GetPoolType()))
+}
+
+// GetPoolType returns the curve type of the pool
+func (s SwapTokenPair) GetPoolType() string {
+	if s.PoolType == "" {
+		return PoolTypeConstantProduct
+	}
+	return s.PoolType
+}
+
+// IsStableSwap returns true if the pool uses the StableSwap invariant
+func (s SwapTokenPair) IsStableSwap() bool {
+	return s.PoolType == PoolTypeStableSwap
 }
 
 // TokenPairName defines token pair
@@ -104,6 +135,21 @@ func ValidateSwapAmountName(amountName string) error {
 	return nil
 }
 
+// IsDefaultPoolType returns true if the pool type is the constant product one
+func IsDefaultPoolType(poolType string) bool {
+	return poolType == "" || poolType == PoolTypeConstantProduct
+}
+
+// ValidatePoolType checks the pool type, the empty one means the constant product pool
+func ValidatePoolType(poolType string) error {
+	switch poolType {
+	case "", PoolTypeConstantProduct, PoolTypeStableSwap:
+		return nil
+	default:
+		return ErrInvalidPoolType(poolType)
+	}
+}
+
 func GetPoolTokenName(token1, token2 string) string {
 	return PoolTokenPrefix + GetSwapTokenPairName(token1, token2)
 }
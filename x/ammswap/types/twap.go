@@ -0,0 +1,83 @@
+package types
+
+import (
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+const (
+	// TWAPObservationInterval is the minimum seconds between two price observations of a token pair
+	TWAPObservationInterval int64 = 60
+	// MaxTWAPWindow is the longest window in seconds of the twap, the older observations are pruned
+	MaxTWAPWindow int64 = 7 * 24 * 60 * 60
+)
+
+// PriceAccumulator accumulates the prices of a token pair weighted by the seconds they last
+type PriceAccumulator struct {
+	Timestamp            int64   `json:"timestamp"`              // Unix seconds of the last accumulation
+	BaseCumulativePrice  sdk.Dec `json:"base_cumulative_price"`  // Cumulative price of base token in quote token
+	QuoteCumulativePrice sdk.Dec `json:"quote_cumulative_price"` // Cumulative price of quote token in base token
+}
+
+// NewPriceAccumulator creates an empty price accumulator starting at the timestamp
+func NewPriceAccumulator(timestamp int64) PriceAccumulator {
+	return PriceAccumulator{
+		Timestamp:            timestamp,
+		BaseCumulativePrice:  sdk.ZeroDec(),
+		QuoteCumulativePrice: sdk.ZeroDec(),
+	}
+}
+
+// Accumulate adds the current prices of the token pair lasting until the timestamp.
+// The empty pool has no price, so only the timestamp moves forward.
+func (p PriceAccumulator) Accumulate(swapTokenPair SwapTokenPair, timestamp int64) PriceAccumulator {
+	elapsed := timestamp - p.Timestamp
+	if elapsed <= 0 {
+		return p
+	}
+	p.Timestamp = timestamp
+	base, quote := swapTokenPair.BasePooledCoin.Amount, swapTokenPair.QuotePooledCoin.Amount
+	if !base.IsPositive() || !quote.IsPositive() {
+		return p
+	}
+	seconds := sdk.NewDec(elapsed)
+	p.BaseCumulativePrice = p.BaseCumulativePrice.Add(quote.Quo(base).Mul(seconds))
+	p.QuoteCumulativePrice = p.QuoteCumulativePrice.Add(base.Quo(quote).Mul(seconds))
+	return p
+}
+
+// Interpolate estimates the cumulative prices at the timestamp between the accumulator and the later one
+func (p PriceAccumulator) Interpolate(later PriceAccumulator, timestamp int64) PriceAccumulator {
+	if timestamp <= p.Timestamp || later.Timestamp <= p.Timestamp {
+		return p
+	}
+	if timestamp >= later.Timestamp {
+		return later
+	}
+	elapsed, total := sdk.NewDec(timestamp-p.Timestamp), sdk.NewDec(later.Timestamp-p.Timestamp)
+	return PriceAccumulator{
+		Timestamp:            timestamp,
+		BaseCumulativePrice:  p.BaseCumulativePrice.Add(later.BaseCumulativePrice.Sub(p.BaseCumulativePrice).Mul(elapsed).Quo(total)),
+		QuoteCumulativePrice: p.QuoteCumulativePrice.Add(later.QuoteCumulativePrice.Sub(p.QuoteCumulativePrice).Mul(elapsed).Quo(total)),
+	}
+}
+
+// TWAP is the time-weighted average prices of a token pair in [StartTime, EndTime]
+type TWAP struct {
+	TokenPairName string  `json:"token_pair_name"`
+	StartTime     int64   `json:"start_time"`
+	EndTime       int64   `json:"end_time"`
+	BasePrice     sdk.Dec `json:"base_price"`  // Average price of base token in quote token
+	QuotePrice    sdk.Dec `json:"quote_price"` // Average price of quote token in base token
+}
+
+// NewTWAP calculates the twap between the cumulative prices at the start and the end
+func NewTWAP(tokenPairName string, start, end PriceAccumulator) TWAP {
+	window := sdk.NewDec(end.Timestamp - start.Timestamp)
+	return TWAP{
+		TokenPairName: tokenPairName,
+		StartTime:     start.Timestamp,
+		EndTime:       end.Timestamp,
+		BasePrice:     end.BaseCumulativePrice.Sub(start.BaseCumulativePrice).Quo(window),
+		QuotePrice:    end.QuoteCumulativePrice.Sub(start.QuoteCumulativePrice).Quo(window),
+	}
+}
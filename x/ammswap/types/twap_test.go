@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceAccumulator(t *testing.T) {
+	swapTokenPair := NewSwapPair(TestBasePooledToken, TestQuotePooledToken)
+	accumulator := NewPriceAccumulator(100)
+
+	// the empty pool only moves the timestamp
+	accumulator = accumulator.Accumulate(swapTokenPair, 110)
+	require.Equal(t, int64(110), accumulator.Timestamp)
+	require.True(t, accumulator.BaseCumulativePrice.IsZero())
+
+	// 1 base = 2 quote lasting 10 seconds
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(200)
+	start := accumulator
+	accumulator = accumulator.Accumulate(swapTokenPair, 120)
+	require.True(t, sdk.NewDec(20).Equal(accumulator.BaseCumulativePrice), accumulator.BaseCumulativePrice.String())
+	require.True(t, sdk.NewDec(5).Equal(accumulator.QuoteCumulativePrice), accumulator.QuoteCumulativePrice.String())
+	require.Equal(t, accumulator, accumulator.Accumulate(swapTokenPair, 120))
+
+	// 1 base = 4 quote lasting 10 seconds
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(400)
+	end := accumulator.Accumulate(swapTokenPair, 130)
+	twap := NewTWAP(swapTokenPair.TokenPairName(), accumulator, end)
+	require.True(t, sdk.NewDec(4).Equal(twap.BasePrice), twap.BasePrice.String())
+	twap = NewTWAP(swapTokenPair.TokenPairName(), start, end)
+	require.True(t, sdk.NewDec(3).Equal(twap.BasePrice), twap.BasePrice.String())
+	require.True(t, sdk.NewDecWithPrec(375, 3).Equal(twap.QuotePrice), twap.QuotePrice.String())
+
+	// the middle of the last 10 seconds
+	middle := accumulator.Interpolate(end, 125)
+	require.Equal(t, int64(125), middle.Timestamp)
+	require.True(t, sdk.NewDec(40).Equal(middle.BaseCumulativePrice), middle.BaseCumulativePrice.String())
+	require.Equal(t, accumulator, accumulator.Interpolate(end, 100))
+	require.Equal(t, end, accumulator.Interpolate(end, 140))
+}
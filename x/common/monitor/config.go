@@ -2,12 +2,14 @@ package monitor
 
 // const
 const (
-	XNameSpace       = xNameSpace
-	xNameSpace       = "x"
-	orderSubSystem   = "order"
-	stakingSubSystem = "staking"
-	streamSubSystem  = "stream"
-	portSubSystem    = "port"
+	XNameSpace        = xNameSpace
+	xNameSpace        = "x"
+	orderSubSystem    = "order"
+	stakingSubSystem  = "staking"
+	streamSubSystem   = "stream"
+	portSubSystem     = "port"
+	watcherSubSystem  = "watcher"
+	parallelSubSystem = "parallel"
 )
 
 type prometheusConfig struct {
@@ -0,0 +1,81 @@
+package monitor
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	parallelTxsMetrics     *ParallelTxsMetrics
+	initParallelTxsMetrics sync.Once
+)
+
+// ParallelTxsMetrics monitors the parallel execution of the txs of the last block
+type ParallelTxsMetrics struct {
+	// Txs is the number of txs of the block
+	Txs metrics.Gauge
+	// Conflicts is the number of txs whose parallel execution conflicted with the txs before them
+	Conflicts metrics.Gauge
+	// Reruns is the number of txs executed again
+	Reruns metrics.Gauge
+	// Speedup is the sum of the execution times of the txs divided by the execution time of the block
+	Speedup metrics.Gauge
+}
+
+// GetParallelTxsMetrics returns Metrics build using Prometheus client library if Prometheus is enabled
+// Otherwise, it returns no-op Metrics
+func GetParallelTxsMetrics() *ParallelTxsMetrics {
+	initParallelTxsMetrics.Do(func() {
+		if DefaultPrometheusConfig().Prometheus {
+			parallelTxsMetrics = NewParallelTxsMetrics()
+		} else {
+			parallelTxsMetrics = NopParallelTxsMetrics()
+		}
+	})
+
+	return parallelTxsMetrics
+}
+
+// NewParallelTxsMetrics returns a pointer of a new ParallelTxsMetrics object
+func NewParallelTxsMetrics() *ParallelTxsMetrics {
+	return &ParallelTxsMetrics{
+		Txs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: parallelSubSystem,
+			Name:      "txs",
+			Help:      "number of txs of the last block executed in parallel",
+		}, nil),
+		Conflicts: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: parallelSubSystem,
+			Name:      "conflicts",
+			Help:      "number of txs of the last block conflicting with the txs before them",
+		}, nil),
+		Reruns: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: parallelSubSystem,
+			Name:      "reruns",
+			Help:      "number of txs of the last block executed again",
+		}, nil),
+		Speedup: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: parallelSubSystem,
+			Name:      "speedup",
+			Help:      "sum of the execution times of the txs of the last block divided by its execution time",
+		}, nil),
+	}
+}
+
+// NopParallelTxsMetrics returns a pointer of a no-op Metrics
+func NopParallelTxsMetrics() *ParallelTxsMetrics {
+	return &ParallelTxsMetrics{
+		Txs:       discard.NewGauge(),
+		Conflicts: discard.NewGauge(),
+		Reruns:    discard.NewGauge(),
+		Speedup:   discard.NewGauge(),
+	}
+}
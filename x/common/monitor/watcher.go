@@ -0,0 +1,129 @@
+package monitor
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// WatcherKindLabel is the label of the kind of the watchDB entries checked, account or storage
+const WatcherKindLabel = "kind"
+
+var (
+	watcherMetrics     *WatcherMetrics
+	initWatcherMetrics sync.Once
+)
+
+// WatcherMetrics monitors how far the watchDB of the evm watcher lags behind the committed state
+type WatcherMetrics struct {
+	// QueueDepth is the number of jobs waiting to be written to the watchDB
+	QueueDepth metrics.Gauge
+	// Height is the height of the last block written to the watchDB
+	Height metrics.Gauge
+	// CommittedHeight is the height of the last block committed to the state
+	CommittedHeight metrics.Gauge
+	// HeightLag is the number of committed blocks not written to the watchDB yet
+	HeightLag metrics.Gauge
+	// CommitLatency is the time from the commit of a block to its write to the watchDB
+	CommitLatency metrics.Histogram
+	// PruneBase is the first height kept in the watchDB by the pruning
+	PruneBase metrics.Gauge
+
+	// CheckedEntries, Mismatches and Repaired count the entries of the consistency checks
+	CheckedEntries metrics.Counter
+	Mismatches     metrics.Counter
+	Repaired       metrics.Counter
+}
+
+// GetWatcherMetrics returns Metrics build using Prometheus client library if Prometheus is enabled
+// Otherwise, it returns no-op Metrics
+func GetWatcherMetrics() *WatcherMetrics {
+	initWatcherMetrics.Do(func() {
+		if DefaultPrometheusConfig().Prometheus {
+			watcherMetrics = NewWatcherMetrics()
+		} else {
+			watcherMetrics = NopWatcherMetrics()
+		}
+	})
+
+	return watcherMetrics
+}
+
+// NewWatcherMetrics returns a pointer of a new WatcherMetrics object
+func NewWatcherMetrics() *WatcherMetrics {
+	return &WatcherMetrics{
+		QueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "queue_depth",
+			Help:      "number of jobs waiting to be written to the watchDB",
+		}, nil),
+		Height: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "height",
+			Help:      "height of the last block written to the watchDB",
+		}, nil),
+		CommittedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "committed_height",
+			Help:      "height of the last block committed to the state",
+		}, nil),
+		HeightLag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "height_lag",
+			Help:      "number of committed blocks not written to the watchDB yet",
+		}, nil),
+		CommitLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "commit_latency",
+			Help:      "seconds from the commit of a block to its write to the watchDB",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, nil),
+		PruneBase: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "prune_base",
+			Help:      "first height kept in the watchDB by the pruning",
+		}, nil),
+		CheckedEntries: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "check_entries",
+			Help:      "number of watchDB entries compared with the state",
+		}, []string{WatcherKindLabel}),
+		Mismatches: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "check_mismatches",
+			Help:      "number of watchDB entries found different from the state",
+		}, []string{WatcherKindLabel}),
+		Repaired: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: xNameSpace,
+			Subsystem: watcherSubSystem,
+			Name:      "check_repaired",
+			Help:      "number of watchDB entries repaired from the state",
+		}, []string{WatcherKindLabel}),
+	}
+}
+
+// NopWatcherMetrics returns a pointer of a no-op Metrics
+func NopWatcherMetrics() *WatcherMetrics {
+	return &WatcherMetrics{
+		QueueDepth:      discard.NewGauge(),
+		Height:          discard.NewGauge(),
+		CommittedHeight: discard.NewGauge(),
+		HeightLag:       discard.NewGauge(),
+		CommitLatency:   discard.NewHistogram(),
+		PruneBase:       discard.NewGauge(),
+		CheckedEntries:  discard.NewCounter(),
+		Mismatches:      discard.NewCounter(),
+		Repaired:        discard.NewCounter(),
+	}
+}
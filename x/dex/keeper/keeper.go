@@ -310,10 +310,24 @@ func (k Keeper) SortProducts(ctx sdk.Context, products []string) {
 
 // GetParams gets inflation params from the global param store
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.GetParamSubspace().GetParamSet(ctx, &params)
+	subspace := k.GetParamSubspace()
+	subspace.GetParamSetForInitGenesis(ctx, &params, [][]byte{types.KeyContinuousAuctionProducts})
+	subspace.GetIfExists(ctx, types.KeyContinuousAuctionProducts, &params.ContinuousAuctionProducts)
 	return params
 }
 
+// IsContinuousAuctionProduct returns true if the product is matched by the continuous auction engine
+func (k Keeper) IsContinuousAuctionProduct(ctx sdk.Context, product string) bool {
+	var products []string
+	k.GetParamSubspace().GetIfExists(ctx, types.KeyContinuousAuctionProducts, &products)
+	for _, p := range products {
+		if p == product {
+			return true
+		}
+	}
+	return false
+}
+
 // SetParams sets inflation params from the global param store
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.GetParamSubspace().SetParamSet(ctx, &params)
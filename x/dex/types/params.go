@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -18,6 +19,9 @@ var (
 	keyDelistVotingPeriod     = []byte("DelistVotingPeriod")
 	keyWithdrawPeriod         = []byte("WithdrawPeriod")
 	keyOwnershipConfirmWindow = []byte("OwnershipConfirmWindow")
+
+	// KeyContinuousAuctionProducts is added after genesis, so it's read with GetIfExists
+	KeyContinuousAuctionProducts = []byte("ContinuousAuctionProducts")
 )
 
 // Params defines param object
@@ -35,6 +39,9 @@ type Params struct {
 
 	WithdrawPeriod         time.Duration `json:"withdraw_period"`
 	OwnershipConfirmWindow time.Duration `json:"ownership_confirm_window"`
+
+	// products matched by the continuous auction engine instead of the periodic auction engine
+	ContinuousAuctionProducts []string `json:"continuous_auction_products"`
 }
 
 // ParamSetPairs implements the ParamSet interface and returns all the key/value pairs
@@ -48,9 +55,30 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		{Key: keyDelistVotingPeriod, Value: &p.DelistVotingPeriod, ValidatorFn: common.ValidateDurationPositive("delist voting period")},
 		{Key: keyWithdrawPeriod, Value: &p.WithdrawPeriod, ValidatorFn: common.ValidateDurationPositive("withdraw period")},
 		{Key: keyOwnershipConfirmWindow, Value: &p.OwnershipConfirmWindow, ValidatorFn: common.ValidateDurationPositive("ownership confirm window")},
+		{Key: KeyContinuousAuctionProducts, Value: &p.ContinuousAuctionProducts, ValidatorFn: validateContinuousAuctionProducts},
 	}
 }
 
+func validateContinuousAuctionProducts(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	seen := make(map[string]struct{}, len(v))
+	for _, product := range v {
+		if len(strings.Split(product, "_")) != 2 {
+			return fmt.Errorf("invalid continuous auction product: %s", product)
+		}
+		if _, ok := seen[product]; ok {
+			return fmt.Errorf("duplicate continuous auction product: %s", product)
+		}
+		seen[product] = struct{}{}
+	}
+
+	return nil
+}
+
 // ParamKeyTable for auth module
 func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable().RegisterParamSet(&Params{})
@@ -70,12 +98,14 @@ func DefaultParams() *Params {
 		DelistVotingPeriod:     time.Hour * 72,
 		WithdrawPeriod:         DefaultWithdrawPeriod,
 		OwnershipConfirmWindow: DefaultOwnershipConfirmWindow,
+
+		ContinuousAuctionProducts: []string{},
 	}
 }
 
 // String implements the stringer interface.
 func (p Params) String() string {
 	return fmt.Sprintf("Params: \nDexListFee:%s\nTransferOwnershipFee:%s\nRegisterOperatorFee:%s\nDelistMaxDepositPeriod:%s\n"+
-		"DelistMinDeposit:%s\nDelistVotingPeriod:%s\nWithdrawPeriod:%d\nOwnershipConfirmWindow: %s\n",
-		p.ListFee, p.TransferOwnershipFee, p.RegisterOperatorFee, p.DelistMaxDepositPeriod, p.DelistMinDeposit, p.DelistVotingPeriod, p.WithdrawPeriod, p.OwnershipConfirmWindow)
+		"DelistMinDeposit:%s\nDelistVotingPeriod:%s\nWithdrawPeriod:%d\nOwnershipConfirmWindow: %s\nContinuousAuctionProducts: %v\n",
+		p.ListFee, p.TransferOwnershipFee, p.RegisterOperatorFee, p.DelistMaxDepositPeriod, p.DelistMinDeposit, p.DelistVotingPeriod, p.WithdrawPeriod, p.OwnershipConfirmWindow, p.ContinuousAuctionProducts)
 }
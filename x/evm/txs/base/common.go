@@ -48,6 +48,7 @@ func msg2st(ctx *sdk.Context, k *Keeper, msg *types.MsgEthereumTx, st *types.Sta
 	st.Recipient = msg.Data.Recipient
 	st.Amount = msg.Data.Amount
 	st.Payload = msg.Data.Payload
+	st.AccessList = msg.Data.Accesses
 	st.ChainID = chainIDEpoch
 	st.TxHash = &ethHash
 	st.Sender = sender
@@ -128,9 +128,15 @@ func evmDecoder(_ codec.CdcAbstraction, txBytes []byte, height int64) (tx sdk.Tx
 	}
 
 	var ethTx MsgEthereumTx
-	if err = authtypes.EthereumTxDecode(txBytes, &ethTx); err == nil {
-		tx = &ethTx
+	if err = authtypes.EthereumTxDecode(txBytes, &ethTx); err != nil {
+		return
+	}
+	// the typed transactions of EIP-2718 are supported since Venus8
+	if ethTx.IsTypedTx() && height >= 0 && !types.HigherThanVenus8(height) {
+		err = fmt.Errorf("typed transaction is not supported lower than Venus8")
+		return
 	}
+	tx = &ethTx
 	return
 }
 
@@ -157,5 +163,8 @@ func sanityCheck(tx sdk.Tx, height int64) (sdk.Tx, error) {
 	if tx.GetType() == sdk.EvmTxType && types.HigherThanVenus(height) {
 		return nil, fmt.Errorf("amino decode is not allowed for MsgEthereumTx")
 	}
+	if ethTx, ok := tx.(*MsgEthereumTx); ok && ethTx.IsTypedTx() {
+		return nil, fmt.Errorf("amino decode is not allowed for typed MsgEthereumTx")
+	}
 	return tx, nil
 }
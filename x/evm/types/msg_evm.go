@@ -28,6 +28,7 @@ var (
 
 var big2 = big.NewInt(2)
 var big8 = big.NewInt(8)
+var big27 = big.NewInt(27)
 var DefaultDeployContractFnSignature = ethcmn.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000001")
 var DefaultSendCoinFnSignature = ethcmn.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000010")
 var emptyEthAddr = ethcmn.Address{}
@@ -186,6 +187,12 @@ func (msg *MsgEthereumTx) ValidateBasic() error {
 		return sdkerrors.Wrapf(types.ErrInvalidValue, "amount cannot be negative %s", msg.Data.Amount)
 	}
 
+	if msg.IsTypedTx() {
+		if err := msg.validateTyped(); err != nil {
+			return sdkerrors.Wrap(types.ErrInvalidValue, err.Error())
+		}
+	}
+
 	return nil
 }
 
@@ -237,6 +244,10 @@ var rlpHashDataPool = &sync.Pool{
 // RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
 // given chainID used for signing.
 func (msg *MsgEthereumTx) RLPSignBytes(chainID *big.Int) (h ethcmn.Hash) {
+	if msg.IsTypedTx() {
+		return msg.typedSignHash(chainID)
+	}
+
 	rlpData := rlpHashDataPool.Get().(*rlpHashData)
 	rlpData.GasLimit = msg.Data.GasLimit
 	rlpData.Payload = msg.Data.Payload
@@ -271,23 +282,36 @@ func (msg *MsgEthereumTx) HomesteadSignHash() ethcmn.Hash {
 }
 
 // EncodeRLP implements the rlp.Encoder interface.
+// The typed transaction is encoded as a RLP string of its EIP-2718 envelope.
 func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
+	if msg.IsTypedTx() {
+		envelope, err := msg.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		return rlp.Encode(w, envelope)
+	}
 	return rlp.Encode(w, &msg.Data)
 }
 
 // DecodeRLP implements the rlp.Decoder interface.
 func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
-	_, _, err := s.Kind()
+	kind, _, err := s.Kind()
 	if err != nil {
 		// return error if stream is too large
 		return err
 	}
 
-	if err := s.Decode(&msg.Data); err != nil {
-		return err
+	if kind == rlp.List {
+		return s.Decode(&msg.Data)
 	}
 
-	return nil
+	// it's a typed transaction wrapped in a RLP string
+	envelope, err := s.Bytes()
+	if err != nil {
+		return err
+	}
+	return msg.decodeTyped(envelope)
 }
 
 // Sign calculates a secp256k1 ECDSA signature and signs the transaction. It
@@ -311,7 +335,15 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) error {
 
 	var v *big.Int
 
-	if chainID.Sign() == 0 {
+	if msg.IsTypedTx() {
+		// the typed transaction carries the chain id, and v is the recovery id
+		if msg.Data.ChainID == nil {
+			msg.Data.ChainID = new(big.Int).Set(chainID)
+		} else if msg.Data.ChainID.Cmp(chainID) != 0 {
+			return fmt.Errorf("invalid chain id for signer: have %s want %s", msg.Data.ChainID, chainID)
+		}
+		v = big.NewInt(int64(sig[64]))
+	} else if chainID.Sign() == 0 {
 		v = new(big.Int).SetBytes([]byte{sig[64] + 27})
 	} else {
 		v = big.NewInt(int64(sig[64] + 35))
@@ -335,7 +367,21 @@ var sigBigNumPool = &sync.Pool{
 func (msg *MsgEthereumTx) firstVerifySig(chainID *big.Int) (ethcmn.Address, error) {
 	var V *big.Int
 	var sigHash ethcmn.Hash
-	if isProtectedV(msg.Data.V) {
+	if msg.IsTypedTx() {
+		if msg.Data.ChainID == nil || msg.Data.ChainID.Cmp(chainID) != 0 {
+			return emptyEthAddr, fmt.Errorf("invalid chain id for signer: have %s want %s", msg.Data.ChainID, chainID)
+		}
+		if msg.Data.V.BitLen() > 1 {
+			return emptyEthAddr, errors.New("invalid signature")
+		}
+
+		// the recovery id is shifted to the homestead V for recoverEthSig
+		bigNum := sigBigNumPool.Get().(*big.Int)
+		defer sigBigNumPool.Put(bigNum)
+		V = bigNum.Add(msg.Data.V, big27)
+
+		sigHash = msg.RLPSignBytes(chainID)
+	} else if isProtectedV(msg.Data.V) {
 		// do not allow recovery for transactions with an unprotected chainID
 		if chainID.Sign() == 0 {
 			return emptyEthAddr, errors.New("chainID cannot be zero")
@@ -407,8 +453,9 @@ func (msg *MsgEthereumTx) GetGas() uint64 {
 }
 
 // Protected says whether the transaction is replay-protected.
+// The typed transaction is always protected by its chain id.
 func (msg *MsgEthereumTx) Protected() bool {
-	return isProtectedV(msg.Data.V)
+	return msg.IsTypedTx() || isProtectedV(msg.Data.V)
 }
 
 // Fee returns gasprice * gaslimit.
@@ -428,6 +475,12 @@ func (msg *MsgEthereumTx) CalcFee(fee *big.Int) *big.Int {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (msg *MsgEthereumTx) ChainID() *big.Int {
+	if msg.IsTypedTx() {
+		if msg.Data.ChainID == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(msg.Data.ChainID)
+	}
 	return deriveChainID(msg.Data.V)
 }
 
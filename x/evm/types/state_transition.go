@@ -31,6 +31,7 @@ type StateTransition struct {
 	Recipient    *common.Address
 	Amount       *big.Int
 	Payload      []byte
+	AccessList   ethtypes.AccessList
 
 	ChainID    *big.Int
 	Csdb       *CommitStateDB
@@ -149,7 +150,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 		}
 	}()
 
-	cost, err := core.IntrinsicGas(st.Payload, []ethtypes.AccessTuple{}, contractCreation, config.IsHomestead(), config.IsIstanbul())
+	cost, err := core.IntrinsicGas(st.Payload, st.AccessList, contractCreation, config.IsHomestead(), config.IsIstanbul())
 	if err != nil {
 		return exeRes, resData, sdkerrors.Wrap(err, "invalid intrinsic gas for transaction"), innerTxs, erc20Contracts
 	}
@@ -206,6 +207,11 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 	}
 
 	evm := st.newEVM(ctx, csdb, gasLimit, st.Price, &config, vmConfig)
+	if len(st.AccessList) > 0 {
+		// warm up the addresses and slots of the EIP-2930 access list for the EIP-2929 gas pricing
+		rules := evm.ChainConfig().Rules(evm.Context.BlockNumber)
+		csdb.PrepareAccessList(st.Sender, st.Recipient, vm.ActivePrecompiles(rules), st.AccessList)
+	}
 
 	var (
 		ret             []byte
@@ -337,7 +343,7 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (exe
 			traceLogs, traceErr = GetTracerResult(tracer, result)
 			if traceErr != nil {
 				traceLogs = []byte(traceErr.Error())
-			} else {
+			} else if !isNativeTracer(tracer) {
 				traceLogs, traceErr = integratePreimage(csdb, traceLogs)
 				if traceErr != nil {
 					traceLogs = []byte(traceErr.Error())
@@ -8,7 +8,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/okex/exchain/app/crypto/ethsecp256k1"
 	ethermint "github.com/okex/exchain/app/types"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
@@ -523,3 +525,33 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 	suite.Require().Equal(fromBalance, sdk.NewDec(4940).BigInt())
 	suite.Require().Equal(toBalance, sdk.NewDec(50).BigInt())
 }
+
+func (suite *StateDBTestSuite) TestTransitionDbAccessList() {
+	suite.stateDB = types.CreateEmptyCommitStateDB(suite.app.EvmKeeper.GenerateCSDBParams(), suite.ctx)
+	recipient := ethcmn.HexToAddress("0x0101")
+	slot := ethcmn.HexToHash("0x01")
+	st := types.StateTransition{
+		AccountNonce: 0,
+		Price:        sdk.NewDec(10).BigInt(),
+		GasLimit:     100000,
+		Recipient:    &recipient,
+		Amount:       big.NewInt(0),
+		AccessList:   ethtypes.AccessList{{Address: recipient, StorageKeys: []ethcmn.Hash{slot}}},
+		ChainID:      big.NewInt(1),
+		Csdb:         suite.stateDB,
+		TxHash:       &ethcmn.Hash{},
+		Sender:       suite.address,
+		Simulate:     suite.ctx.IsCheckTx(),
+	}
+	suite.ctx.SetGasMeter(sdk.NewGasMeter(100000))
+	_, _, err, _, _ := st.TransitionDb(suite.ctx, types.DefaultChainConfig())
+	suite.Require().NoError(err)
+
+	// the intrinsic gas covers the access list
+	suite.Require().Equal(params.TxGas+params.TxAccessListAddressGas+params.TxAccessListStorageKeyGas, suite.ctx.GasMeter().GasConsumed())
+	// the addresses and slots of the access list are warm
+	suite.Require().True(suite.stateDB.AddressInAccessList(suite.address))
+	addressWarm, slotWarm := suite.stateDB.SlotInAccessList(recipient, slot)
+	suite.Require().True(addressWarm)
+	suite.Require().True(slotWarm)
+}
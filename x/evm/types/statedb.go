@@ -615,7 +615,7 @@ func (csdb *CommitStateDB) PrepareAccessList(sender ethcmn.Address, dest *ethcmn
 	}
 
 	csdb.AddAddressToAccessList(sender)
-	if csdb != nil {
+	if dest != nil {
 		csdb.AddAddressToAccessList(*dest)
 		// If it's a create-tx, the destination will be added inside evm.create
 	}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// fourByteTracer counts the 4-byte function selectors of the calls in a tx together with the size of the
+// call data, the keys of the result are formatted as "0x<selector>-<size of the arguments>"
+type fourByteTracer struct {
+	ids         map[string]int
+	precompiles precompiles
+}
+
+func newFourByteTracer(config json.RawMessage) (NativeTracer, error) {
+	return &fourByteTracer{ids: make(map[string]int)}, nil
+}
+
+// CaptureStart implements vm.Tracer interface
+func (t *fourByteTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool,
+	input []byte, gas uint64, value *big.Int) {
+	t.precompiles = newPrecompiles(env)
+	// the call data of the top-level call is counted as well
+	if len(input) >= 4 {
+		t.store(input[0:4], len(input)-4)
+	}
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *fourByteTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext,
+	rData []byte, depth int, err error) {
+	if err != nil {
+		return
+	}
+	var off int
+	switch op {
+	case vm.CALL, vm.CALLCODE:
+		off = 1
+	case vm.DELEGATECALL, vm.STATICCALL:
+		off = 0
+	default:
+		return
+	}
+	// the precompiled contracts have no selectors
+	if t.precompiles.contains(stackAddress(scope, 1)) {
+		return
+	}
+	if input := memorySlice(scope, 2+off, 3+off); len(input) >= 4 {
+		t.store(input[0:4], len(input)-4)
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *fourByteTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext,
+	depth int, err error) {
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *fourByteTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {}
+
+// GetResult returns the json-encoded counts of the selectors
+func (t *fourByteTracer) GetResult() (json.RawMessage, error) {
+	return json.Marshal(t.ids)
+}
+
+func (t *fourByteTracer) store(selector []byte, size int) {
+	t.ids[fmt.Sprintf("0x%x-%d", selector, size)]++
+}
@@ -0,0 +1,287 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// callLog is a log emitted in a call frame
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+	// Position of the log relative to the subcalls within the same frame
+	Position hexutil.Uint `json:"position"`
+}
+
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	To           *common.Address `json:"to,omitempty"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*callFrame    `json:"calls,omitempty"`
+	Logs         []callLog       `json:"logs,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+
+	// the fields below are used while the frame is running
+	gasIn   uint64
+	gasCost uint64
+	gasSet  bool
+	outOff  uint64
+	outLen  uint64
+}
+
+type callTracerConfig struct {
+	// OnlyTopCall only traces the top-level call and skips the subcalls
+	OnlyTopCall bool `json:"onlyTopCall"`
+	// WithLog includes the logs emitted in every call frame
+	WithLog bool `json:"withLog"`
+}
+
+// callTracer reports the call frames of a tx. Since the interpreter only captures the opcodes,
+// a new frame is pushed at the CALL/CREATE ops and popped at the first op after the frame returns.
+type callTracer struct {
+	config      callTracerConfig
+	precompiles precompiles
+	callstack   []*callFrame
+	descended   bool
+}
+
+func newCallTracer(config json.RawMessage) (NativeTracer, error) {
+	t := &callTracer{}
+	if err := unmarshalTracerConfig(config, &t.config); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CaptureStart implements vm.Tracer interface
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool,
+	input []byte, gas uint64, value *big.Int) {
+	t.precompiles = newPrecompiles(env)
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	toCopy := to
+	t.callstack = []*callFrame{{
+		Type:  typ.String(),
+		From:  from,
+		To:    &toCopy,
+		Input: common.CopyBytes(input),
+		Gas:   hexutil.Uint64(gas),
+		Value: (*hexutil.Big)(cloneBig(value)),
+	}}
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext,
+	rData []byte, depth int, err error) {
+	if err != nil {
+		t.CaptureFault(env, pc, op, gas, cost, scope, depth, err)
+		return
+	}
+	if len(t.callstack) == 0 || (t.config.OnlyTopCall && depth > 1) {
+		return
+	}
+
+	// the first op of the new frame records the gas available for it
+	if t.descended {
+		if depth >= len(t.callstack) {
+			frame := t.callstack[len(t.callstack)-1]
+			frame.Gas, frame.gasSet = hexutil.Uint64(gas), true
+		}
+		t.descended = false
+	}
+	// the first op after a frame returns to its parent
+	if depth == len(t.callstack)-1 {
+		t.pop(env, scope, gas)
+	}
+
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		if t.config.OnlyTopCall {
+			return
+		}
+		t.push(&callFrame{
+			Type:    op.String(),
+			From:    scope.Contract.Address(),
+			Input:   memorySlice(scope, 1, 2),
+			Value:   (*hexutil.Big)(scope.Stack.Back(0).ToBig()),
+			gasIn:   gas,
+			gasCost: cost,
+		})
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		to := stackAddress(scope, 1)
+		if t.config.OnlyTopCall || t.precompiles.contains(to) {
+			return
+		}
+		off := 1
+		if op == vm.DELEGATECALL || op == vm.STATICCALL {
+			off = 0
+		}
+		frame := &callFrame{
+			Type:    op.String(),
+			From:    scope.Contract.Address(),
+			To:      &to,
+			Input:   memorySlice(scope, 2+off, 3+off),
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  scope.Stack.Back(4 + off).Uint64(),
+			outLen:  scope.Stack.Back(5 + off).Uint64(),
+		}
+		if off == 1 {
+			frame.Value = (*hexutil.Big)(scope.Stack.Back(2).ToBig())
+		}
+		t.push(frame)
+	case vm.SELFDESTRUCT:
+		if t.config.OnlyTopCall {
+			return
+		}
+		to := stackAddress(scope, 0)
+		t.appendCall(&callFrame{
+			Type:  op.String(),
+			From:  scope.Contract.Address(),
+			To:    &to,
+			Input: []byte{},
+			Value: (*hexutil.Big)(cloneBig(env.StateDB.GetBalance(scope.Contract.Address()))),
+		})
+	case vm.LOG0, vm.LOG1, vm.LOG2, vm.LOG3, vm.LOG4:
+		if t.config.WithLog {
+			t.captureLog(op, scope)
+		}
+	case vm.REVERT:
+		t.callstack[len(t.callstack)-1].Error = vm.ErrExecutionReverted.Error()
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext,
+	depth int, err error) {
+	// the error of the top-level call is reported in CaptureEnd
+	if depth <= 1 || depth != len(t.callstack) {
+		return
+	}
+	frame := t.callstack[len(t.callstack)-1]
+	if frame.Error != "" {
+		return
+	}
+	t.callstack = t.callstack[:len(t.callstack)-1]
+	frame.Error = err.Error()
+	frame.GasUsed = frame.Gas
+	t.appendCall(frame)
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+	if len(t.callstack) == 0 {
+		return
+	}
+	// the frames which are not popped are not returned normally, fold them into their parents
+	for len(t.callstack) > 1 {
+		frame := t.callstack[len(t.callstack)-1]
+		t.callstack = t.callstack[:len(t.callstack)-1]
+		t.appendCall(frame)
+	}
+
+	top := t.callstack[0]
+	top.GasUsed = hexutil.Uint64(gasUsed)
+	if err == nil {
+		top.Output = common.CopyBytes(output)
+		return
+	}
+	top.Error = err.Error()
+	if errors.Is(err, vm.ErrExecutionReverted) && len(output) > 0 {
+		top.Output = common.CopyBytes(output)
+		if reason, unpackErr := abi.UnpackRevert(output); unpackErr == nil {
+			top.RevertReason = reason
+		}
+	}
+}
+
+// GetResult returns the json-encoded top-level call frame
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstack) == 0 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	top := t.callstack[0]
+	if t.config.WithLog {
+		clearFailedLogs(top, false)
+	}
+	return json.Marshal(top)
+}
+
+func (t *callTracer) push(frame *callFrame) {
+	t.callstack = append(t.callstack, frame)
+	t.descended = true
+}
+
+// pop finishes the last frame with the stack and the memory of its parent, and appends it to the parent
+func (t *callTracer) pop(env *vm.EVM, scope *vm.ScopeContext, gas uint64) {
+	frame := t.callstack[len(t.callstack)-1]
+	t.callstack = t.callstack[:len(t.callstack)-1]
+
+	success := !scope.Stack.Back(0).IsZero()
+	if frame.Type == vm.CREATE.String() || frame.Type == vm.CREATE2.String() {
+		frame.GasUsed = hexutil.Uint64(frame.gasIn - frame.gasCost - gas)
+		if success {
+			to := stackAddress(scope, 0)
+			frame.To = &to
+			frame.Output = env.StateDB.GetCode(to)
+		}
+	} else {
+		if frame.gasSet {
+			frame.GasUsed = hexutil.Uint64(frame.gasIn - frame.gasCost + uint64(frame.Gas) - gas)
+		}
+		if success {
+			frame.Output = scope.Memory.GetCopy(int64(frame.outOff), int64(frame.outLen))
+		}
+	}
+	if !success && frame.Error == "" {
+		frame.Error = "internal failure"
+	}
+	t.appendCall(frame)
+}
+
+func (t *callTracer) appendCall(frame *callFrame) {
+	parent := t.callstack[len(t.callstack)-1]
+	parent.Calls = append(parent.Calls, frame)
+}
+
+func (t *callTracer) captureLog(op vm.OpCode, scope *vm.ScopeContext) {
+	size := int(op - vm.LOG0)
+	topics := make([]common.Hash, size)
+	for i := 0; i < size; i++ {
+		topics[i] = common.Hash(scope.Stack.Back(2 + i).Bytes32())
+	}
+	frame := t.callstack[len(t.callstack)-1]
+	frame.Logs = append(frame.Logs, callLog{
+		Address:  scope.Contract.Address(),
+		Topics:   topics,
+		Data:     memorySlice(scope, 0, 1),
+		Position: hexutil.Uint(len(frame.Calls)),
+	})
+}
+
+// clearFailedLogs removes the logs of the failed frames and their subcalls, since they are discarded by the state
+func clearFailedLogs(frame *callFrame, parentFailed bool) {
+	failed := parentFailed || frame.Error != ""
+	if failed {
+		frame.Logs = nil
+	}
+	for _, call := range frame.Calls {
+		clearFailedLogs(call, failed)
+	}
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	// CallTracerName is the name of the native tracer which reports the call frames of a tx
+	CallTracerName = "callTracer"
+	// PrestateTracerName is the name of the native tracer which reports the state touched by a tx
+	PrestateTracerName = "prestateTracer"
+	// FourByteTracerName is the name of the native tracer which counts the 4-byte selectors of the calls
+	FourByteTracerName = "4byteTracer"
+)
+
+// NativeTracer is a vm.Tracer implemented in go, which is selected by name in TraceConfig.Tracer.
+// Its result keeps the same json shape as the native tracers of go-ethereum.
+type NativeTracer interface {
+	vm.Tracer
+	GetResult() (json.RawMessage, error)
+}
+
+type nativeTracerCtor func(config json.RawMessage) (NativeTracer, error)
+
+var nativeTracers = map[string]nativeTracerCtor{
+	CallTracerName:     newCallTracer,
+	PrestateTracerName: newPrestateTracer,
+	FourByteTracerName: newFourByteTracer,
+}
+
+// IsNativeTracer returns true if the tracer name refers to a native tracer
+func IsNativeTracer(name string) bool {
+	_, ok := nativeTracers[name]
+	return ok
+}
+
+// isNativeTracer returns true if the tracer is a native one,
+// whose result keeps the same shape as go-ethereum without the preimages
+func isNativeTracer(tracer vm.Tracer) bool {
+	switch tracer.(type) {
+	case *callTracer, *prestateTracer, *fourByteTracer:
+		return true
+	}
+	return false
+}
+
+// newNativeTracer creates the native tracer by name with its tracer config
+func newNativeTracer(name string, config json.RawMessage) (NativeTracer, error) {
+	ctor, ok := nativeTracers[name]
+	if !ok {
+		return nil, fmt.Errorf("native tracer %s is not found", name)
+	}
+	return ctor(config)
+}
+
+// unmarshalTracerConfig decodes the tracer config, an empty config keeps the default values of cfg
+func unmarshalTracerConfig(config json.RawMessage, cfg interface{}) error {
+	if len(config) == 0 || string(config) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(config, cfg); err != nil {
+		return fmt.Errorf("invalid tracer config: %s", err.Error())
+	}
+	return nil
+}
+
+// maxMemorySliceExpansion limits the bytes beyond the current memory which are read for an op,
+// the op itself fails to expand the memory with such a huge size because of out of gas
+const maxMemorySliceExpansion = 1 << 20
+
+// memorySlice copies the memory in [offset, offset+size) whose offset and size are the n-th items from
+// the top of the stack, the part out of the current memory is filled with zero
+func memorySlice(scope *vm.ScopeContext, offsetIdx, sizeIdx int) []byte {
+	offset, size := scope.Stack.Back(offsetIdx), scope.Stack.Back(sizeIdx)
+	if !offset.IsUint64() || !size.IsUint64() || size.IsZero() {
+		return nil
+	}
+	off, length := offset.Uint64(), size.Uint64()
+	memLen := uint64(scope.Memory.Len())
+	if off+length < off || off+length > memLen+maxMemorySliceExpansion {
+		return nil
+	}
+	cpy := make([]byte, length)
+	if off < memLen {
+		copy(cpy, scope.Memory.Data()[off:])
+	}
+	return cpy
+}
+
+// stackAddress converts the n-th item from the top of the stack to an address
+func stackAddress(scope *vm.ScopeContext, n int) common.Address {
+	return common.Address(scope.Stack.Back(n).Bytes20())
+}
+
+// precompiles records the active precompiled contracts of the block being traced
+type precompiles map[common.Address]struct{}
+
+func newPrecompiles(env *vm.EVM) precompiles {
+	rules := env.ChainConfig().Rules(env.Context.BlockNumber)
+	active := vm.ActivePrecompiles(rules)
+	p := make(precompiles, len(active))
+	for _, addr := range active {
+		p[addr] = struct{}{}
+	}
+	return p
+}
+
+func (p precompiles) contains(addr common.Address) bool {
+	_, ok := p[addr]
+	return ok
+}
+
+func cloneBig(b *big.Int) *big.Int {
+	if b == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(b)
+}
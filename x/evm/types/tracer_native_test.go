@@ -0,0 +1,136 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tracerCaller = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	tracerOuter  = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tracerInner  = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	// outer: SLOAD(0), SSTORE(1, 5), CALL inner with 0x12345678 as input and 32 bytes as output
+	tracerOuterCode = common.FromHex("600054506005600155" + "6312345678" + "60e01b600052" +
+		"60206020600460006000" + "60bb" + "5af1" + "5000")
+	// inner: LOG1 with topic 0x01, return 32 bytes of 0x2a
+	tracerInnerCode = common.FromHex("600160006000a1" + "602a600052" + "60206000f3")
+)
+
+// traceOuterCall executes the call to the outer contract with the tracer
+func traceOuterCall(t *testing.T, tracer NativeTracer) json.RawMessage {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetCode(tracerOuter, tracerOuterCode)
+	statedb.SetCode(tracerInner, tracerInnerCode)
+	statedb.SetBalance(tracerCaller, big.NewInt(1000))
+	statedb.SetNonce(tracerCaller, 1)
+
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		Difficulty:  big.NewInt(0),
+		GasLimit:    10000000,
+	}
+	evm := vm.NewEVM(blockCtx, vm.TxContext{Origin: tracerCaller, GasPrice: big.NewInt(0)}, statedb,
+		params.AllEthashProtocolChanges, vm.Config{Debug: true, Tracer: tracer})
+	_, _, err = evm.Call(vm.AccountRef(tracerCaller), tracerOuter, common.FromHex("0xdeadbeef"), 1000000, big.NewInt(10))
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res
+}
+
+func TestCallTracer(t *testing.T) {
+	tracer, err := newNativeTracer(CallTracerName, json.RawMessage(`{"withLog":true}`))
+	require.NoError(t, err)
+
+	var top callFrame
+	require.NoError(t, json.Unmarshal(traceOuterCall(t, tracer), &top))
+	require.Equal(t, "CALL", top.Type)
+	require.Equal(t, tracerCaller, top.From)
+	require.Equal(t, tracerOuter, *top.To)
+	require.Equal(t, big.NewInt(10), top.Value.ToInt())
+	require.Equal(t, "", top.Error)
+	require.Len(t, top.Calls, 1)
+
+	inner := top.Calls[0]
+	require.Equal(t, "CALL", inner.Type)
+	require.Equal(t, tracerOuter, inner.From)
+	require.Equal(t, tracerInner, *inner.To)
+	require.Equal(t, hexutil.Bytes(common.FromHex("0x12345678")), inner.Input)
+	require.Equal(t, hexutil.Bytes(common.LeftPadBytes([]byte{0x2a}, 32)), inner.Output)
+	require.True(t, inner.GasUsed > 0)
+	require.Len(t, inner.Logs, 1)
+	require.Equal(t, tracerInner, inner.Logs[0].Address)
+	require.Equal(t, []common.Hash{common.BigToHash(big.NewInt(1))}, inner.Logs[0].Topics)
+
+	// only the top-level call is traced
+	tracer, err = newNativeTracer(CallTracerName, json.RawMessage(`{"onlyTopCall":true}`))
+	require.NoError(t, err)
+	top = callFrame{}
+	require.NoError(t, json.Unmarshal(traceOuterCall(t, tracer), &top))
+	require.Equal(t, tracerOuter, *top.To)
+	require.Len(t, top.Calls, 0)
+
+	// invalid tracer config
+	_, err = newNativeTracer(CallTracerName, json.RawMessage(`{"onlyTopCall":1}`))
+	require.Error(t, err)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	tracer, err := newNativeTracer(PrestateTracerName, nil)
+	require.NoError(t, err)
+
+	var pre map[common.Address]*prestateAccount
+	require.NoError(t, json.Unmarshal(traceOuterCall(t, tracer), &pre))
+	require.Contains(t, pre, tracerInner)
+	require.Equal(t, big.NewInt(1000), pre[tracerCaller].Balance.ToInt())
+	require.Equal(t, uint64(0), pre[tracerCaller].Nonce)
+	require.Zero(t, pre[tracerOuter].Balance.ToInt().Sign())
+	require.Equal(t, hexutil.Bytes(tracerOuterCode), pre[tracerOuter].Code)
+	require.Len(t, pre[tracerOuter].Storage, 2)
+
+	tracer, err = newNativeTracer(PrestateTracerName, json.RawMessage(`{"diffMode":true}`))
+	require.NoError(t, err)
+	var diff struct {
+		Pre  map[common.Address]*prestateAccount `json:"pre"`
+		Post map[common.Address]*prestateAccount `json:"post"`
+	}
+	require.NoError(t, json.Unmarshal(traceOuterCall(t, tracer), &diff))
+	// the inner contract is not modified
+	require.NotContains(t, diff.Pre, tracerInner)
+	require.NotContains(t, diff.Post, tracerInner)
+	require.Equal(t, big.NewInt(10), diff.Post[tracerOuter].Balance.ToInt())
+	require.Equal(t, map[common.Hash]common.Hash{common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(5))},
+		diff.Post[tracerOuter].Storage)
+	require.Len(t, diff.Pre[tracerOuter].Storage, 0)
+}
+
+func TestFourByteTracer(t *testing.T) {
+	tracer, err := newNativeTracer(FourByteTracerName, nil)
+	require.NoError(t, err)
+
+	var ids map[string]int
+	require.NoError(t, json.Unmarshal(traceOuterCall(t, tracer), &ids))
+	require.Equal(t, map[string]int{"0xdeadbeef-0": 1, "0x12345678-0": 1}, ids)
+}
+
+func TestTracerConfigWithNativeTracer(t *testing.T) {
+	require.NoError(t, TestTracerConfig(&TraceConfig{Tracer: CallTracerName}))
+	require.NoError(t, TestTracerConfig(&TraceConfig{Tracer: PrestateTracerName, TracerConfig: json.RawMessage(`{"diffMode":true}`)}))
+	require.Error(t, TestTracerConfig(&TraceConfig{Tracer: PrestateTracerName, TracerConfig: json.RawMessage(`[]`)}))
+}
@@ -0,0 +1,223 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+func (a *prestateAccount) exists() bool {
+	return a.Nonce > 0 || len(a.Code) > 0 || len(a.Storage) > 0 || (a.Balance != nil && a.Balance.ToInt().Sign() != 0)
+}
+
+type prestateTracerConfig struct {
+	// DiffMode reports the state before and after the tx, only the modified parts are included
+	DiffMode bool `json:"diffMode"`
+}
+
+// prestateTracer reports the state of the accounts touched by a tx before it's executed
+type prestateTracer struct {
+	config  prestateTracerConfig
+	env     *vm.EVM
+	pre     map[common.Address]*prestateAccount
+	post    map[common.Address]*prestateAccount
+	created map[common.Address]bool
+	deleted map[common.Address]bool
+}
+
+func newPrestateTracer(config json.RawMessage) (NativeTracer, error) {
+	t := &prestateTracer{
+		pre:     make(map[common.Address]*prestateAccount),
+		post:    make(map[common.Address]*prestateAccount),
+		created: make(map[common.Address]bool),
+		deleted: make(map[common.Address]bool),
+	}
+	if err := unmarshalTracerConfig(config, &t.config); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CaptureStart implements vm.Tracer interface
+func (t *prestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool,
+	input []byte, gas uint64, value *big.Int) {
+	t.env = env
+	t.lookupAccount(from)
+	t.lookupAccount(to)
+	t.lookupAccount(env.Context.Coinbase)
+
+	// the value has been transferred to the recipient
+	toBal := new(big.Int).Sub(t.pre[to].Balance.ToInt(), value)
+	t.pre[to].Balance = (*hexutil.Big)(toBal)
+	if create && env.ChainConfig().IsEIP158(env.Context.BlockNumber) && t.pre[to].Nonce > 0 {
+		t.pre[to].Nonce--
+	}
+
+	// the sender has paid the value and the fee of the gas limit, and its nonce has been increased
+	isHomestead := env.ChainConfig().IsHomestead(env.Context.BlockNumber)
+	isIstanbul := env.ChainConfig().IsIstanbul(env.Context.BlockNumber)
+	intrinsicGas, err := core.IntrinsicGas(input, nil, create, isHomestead, isIstanbul)
+	if err != nil {
+		intrinsicGas = 0
+	}
+	fee := new(big.Int).SetUint64(gas + intrinsicGas)
+	if env.TxContext.GasPrice != nil {
+		fee.Mul(fee, env.TxContext.GasPrice)
+	} else {
+		fee.SetUint64(0)
+	}
+	fromBal := new(big.Int).Add(t.pre[from].Balance.ToInt(), value)
+	fromBal.Add(fromBal, fee)
+	t.pre[from].Balance = (*hexutil.Big)(fromBal)
+	if t.pre[from].Nonce > 0 {
+		t.pre[from].Nonce--
+	}
+
+	if create && t.config.DiffMode {
+		t.created[to] = true
+	}
+}
+
+// CaptureState implements vm.Tracer interface
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext,
+	rData []byte, depth int, err error) {
+	if err != nil || t.env == nil {
+		return
+	}
+	stackLen := len(scope.Stack.Data())
+	caller := scope.Contract.Address()
+	switch {
+	case stackLen >= 1 && (op == vm.SLOAD || op == vm.SSTORE):
+		t.lookupStorage(caller, common.Hash(scope.Stack.Back(0).Bytes32()))
+	case stackLen >= 1 && (op == vm.EXTCODECOPY || op == vm.EXTCODEHASH || op == vm.EXTCODESIZE || op == vm.BALANCE):
+		t.lookupAccount(stackAddress(scope, 0))
+	case stackLen >= 1 && op == vm.SELFDESTRUCT:
+		t.lookupAccount(stackAddress(scope, 0))
+		t.deleted[caller] = true
+	case stackLen >= 5 && (op == vm.DELEGATECALL || op == vm.CALL || op == vm.STATICCALL || op == vm.CALLCODE):
+		t.lookupAccount(stackAddress(scope, 1))
+	case op == vm.CREATE:
+		addr := crypto.CreateAddress(caller, env.StateDB.GetNonce(caller))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	case stackLen >= 4 && op == vm.CREATE2:
+		initCode := memorySlice(scope, 1, 2)
+		salt := scope.Stack.Back(3).Bytes32()
+		addr := crypto.CreateAddress2(caller, salt, crypto.Keccak256(initCode))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	}
+}
+
+// CaptureFault implements vm.Tracer interface
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext,
+	depth int, err error) {
+}
+
+// CaptureEnd implements vm.Tracer interface
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+	if !t.config.DiffMode || t.env == nil {
+		return
+	}
+
+	for addr, state := range t.pre {
+		// the deleted accounts have no post state
+		if t.deleted[addr] {
+			continue
+		}
+		modified := false
+		postAccount := &prestateAccount{Storage: make(map[common.Hash]common.Hash)}
+		newBalance := t.env.StateDB.GetBalance(addr)
+		newNonce := t.env.StateDB.GetNonce(addr)
+		newCode := t.env.StateDB.GetCode(addr)
+
+		if newBalance.Cmp(state.Balance.ToInt()) != 0 {
+			modified = true
+			postAccount.Balance = (*hexutil.Big)(cloneBig(newBalance))
+		}
+		if newNonce != state.Nonce {
+			modified = true
+			postAccount.Nonce = newNonce
+		}
+		if !bytes.Equal(newCode, state.Code) {
+			modified = true
+			postAccount.Code = common.CopyBytes(newCode)
+		}
+		for key, val := range state.Storage {
+			// the empty slots are not included in the pre state
+			if val == (common.Hash{}) {
+				delete(state.Storage, key)
+			}
+			newVal := t.env.StateDB.GetState(addr, key)
+			if val == newVal {
+				// the slot is read only
+				delete(state.Storage, key)
+			} else {
+				modified = true
+				if newVal != (common.Hash{}) {
+					postAccount.Storage[key] = newVal
+				}
+			}
+		}
+
+		if modified {
+			t.post[addr] = postAccount
+		} else {
+			// the account is not modified, exclude it from the pre state
+			delete(t.pre, addr)
+		}
+	}
+	// the pre state of the created contracts was empty unless they existed before the tx
+	for addr := range t.created {
+		if state, ok := t.pre[addr]; ok && !state.exists() {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+// GetResult returns the json-encoded pre state, or the pre state and the post state in diff mode
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	if t.config.DiffMode {
+		return json.Marshal(struct {
+			Post map[common.Address]*prestateAccount `json:"post"`
+			Pre  map[common.Address]*prestateAccount `json:"pre"`
+		}{t.post, t.pre})
+	}
+	return json.Marshal(t.pre)
+}
+
+// lookupAccount fetches the state of the account if it's the first time touched by the tx
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+	t.pre[addr] = &prestateAccount{
+		Balance: (*hexutil.Big)(cloneBig(t.env.StateDB.GetBalance(addr))),
+		Nonce:   t.env.StateDB.GetNonce(addr),
+		Code:    common.CopyBytes(t.env.StateDB.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage fetches the value of the storage slot if it's the first time touched by the tx
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	if _, ok := t.pre[addr].Storage[key]; ok {
+		return
+	}
+	t.pre[addr].Storage[key] = t.env.StateDB.GetState(addr, key)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/tendermint/go-amino"
@@ -10,6 +11,7 @@ import (
 	"github.com/okex/exchain/app/utils"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // TxData implements the Ethereum transaction data structure. It is used
@@ -29,6 +31,16 @@ type TxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// Type is the EIP-2718 type of the transaction, the legacy transaction is type 0.
+	// The following fields are only carried by the typed transactions, and they are not
+	// part of the legacy RLP encoding. For the dynamic fee transaction, Price is the
+	// effective gas price derived from GasTipCap and GasFeeCap.
+	Type      uint8               `json:"type,omitempty" rlp:"-"`
+	ChainID   *big.Int            `json:"chainId,omitempty" rlp:"-"`
+	GasTipCap *big.Int            `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+	GasFeeCap *big.Int            `json:"maxFeePerGas,omitempty" rlp:"-"`
+	Accesses  ethtypes.AccessList `json:"accessList,omitempty" rlp:"-"`
 }
 
 // encodableTxData implements the Ethereum transaction data structure. It is used
@@ -48,9 +60,21 @@ type encodableTxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// typed transaction fields
+	Type      uint8               `json:"type,omitempty" rlp:"-"`
+	ChainID   string              `json:"chainId,omitempty" rlp:"-"`
+	GasTipCap string              `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+	GasFeeCap string              `json:"maxFeePerGas,omitempty" rlp:"-"`
+	Accesses  ethtypes.AccessList `json:"accessList,omitempty" rlp:"-"`
 }
 
 func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error {
+	return tx.unmarshalFromAmino(data, true)
+}
+
+// unmarshalFromAmino decodes the amino bytes, the fields of the typed transaction are only decoded if withTyped is set
+func (tx *encodableTxData) unmarshalFromAmino(data []byte, withTyped bool) error {
 	var dataLen uint64 = 0
 	var subData []byte
 
@@ -80,6 +104,10 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 			subData = data[:dataLen]
 		}
 
+		if pos > 10 && !withTyped {
+			return fmt.Errorf("unexpect feild num %d", pos)
+		}
+
 		switch pos {
 		case 1:
 			var n int
@@ -120,6 +148,79 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 			}
 			tx.Hash = new(ethcmn.Hash)
 			copy(tx.Hash[:], subData)
+		case 11:
+			var n int
+			var txType uint64
+			txType, n, err = amino.DecodeUvarint(data)
+			if err != nil {
+				return err
+			}
+			if txType > math.MaxUint8 {
+				return errors.New("tx type overflow")
+			}
+			tx.Type = uint8(txType)
+			dataLen = uint64(n)
+		case 12:
+			tx.ChainID = string(subData)
+		case 13:
+			tx.GasTipCap = string(subData)
+		case 14:
+			tx.GasFeeCap = string(subData)
+		case 15:
+			var tuple ethtypes.AccessTuple
+			if err = unmarshalAccessTupleFromAmino(subData, &tuple); err != nil {
+				return err
+			}
+			tx.Accesses = append(tx.Accesses, tuple)
+		default:
+			return fmt.Errorf("unexpect feild num %d", pos)
+		}
+	}
+	return nil
+}
+
+func unmarshalAccessTupleFromAmino(data []byte, tuple *ethtypes.AccessTuple) error {
+	var dataLen uint64 = 0
+	var subData []byte
+
+	for {
+		data = data[dataLen:]
+
+		if len(data) == 0 {
+			break
+		}
+
+		pos, pbType, err := amino.ParseProtoPosAndTypeMustOneByte(data[0])
+		if err != nil {
+			return err
+		}
+		data = data[1:]
+
+		if pbType != amino.Typ3_ByteLength {
+			return fmt.Errorf("unexpect pb type %d", pbType)
+		}
+		var n int
+		dataLen, n, err = amino.DecodeUvarint(data)
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+		if len(data) < int(dataLen) {
+			return fmt.Errorf("invalid access tuple")
+		}
+		subData = data[:dataLen]
+
+		switch pos {
+		case 1:
+			if dataLen != ethcmn.AddressLength {
+				return errors.New("eth addr len error")
+			}
+			copy(tuple.Address[:], subData)
+		case 2:
+			if dataLen != ethcmn.HashLength {
+				return errors.New("hash len error")
+			}
+			tuple.StorageKeys = append(tuple.StorageKeys, ethcmn.BytesToHash(subData))
 		default:
 			return fmt.Errorf("unexpect feild num %d", pos)
 		}
@@ -128,13 +229,20 @@ func (tx *encodableTxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error
 }
 
 func (td TxData) String() string {
+	var str string
 	if td.Recipient != nil {
-		return fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=%s amount=%s data=0x%x v=%s r=%s s=%s",
+		str = fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=%s amount=%s data=0x%x v=%s r=%s s=%s",
 			td.AccountNonce, td.Price, td.GasLimit, td.Recipient.Hex(), td.Amount, td.Payload, td.V, td.R, td.S)
+	} else {
+		str = fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=nil amount=%s data=0x%x v=%s r=%s s=%s",
+			td.AccountNonce, td.Price, td.GasLimit, td.Amount, td.Payload, td.V, td.R, td.S)
+	}
+	if td.Type == ethtypes.LegacyTxType {
+		return str
 	}
 
-	return fmt.Sprintf("nonce=%d price=%s gasLimit=%d recipient=nil amount=%s data=0x%x v=%s r=%s s=%s",
-		td.AccountNonce, td.Price, td.GasLimit, td.Amount, td.Payload, td.V, td.R, td.S)
+	return fmt.Sprintf("%s type=%d chainID=%s gasTipCap=%s gasFeeCap=%s accessList=%d",
+		str, td.Type, td.ChainID, td.GasTipCap, td.GasFeeCap, len(td.Accesses))
 }
 
 // MarshalAmino defines custom encoding scheme for TxData
@@ -164,6 +272,21 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		return nil, err
 	}
 
+	chainID, err := marshalOptionalBigInt(td.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	gasTipCap, err := marshalOptionalBigInt(td.GasTipCap)
+	if err != nil {
+		return nil, err
+	}
+
+	gasFeeCap, err := marshalOptionalBigInt(td.GasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
 	e := encodableTxData{
 		AccountNonce: td.AccountNonce,
 		Price:        gasPrice,
@@ -175,6 +298,11 @@ func (td TxData) MarshalAmino() ([]byte, error) {
 		R:            r,
 		S:            s,
 		Hash:         td.Hash,
+		Type:         td.Type,
+		ChainID:      chainID,
+		GasTipCap:    gasTipCap,
+		GasFeeCap:    gasFeeCap,
+		Accesses:     td.Accesses,
 	}
 
 	return ModuleCdc.MarshalBinaryBare(e)
@@ -249,12 +377,12 @@ func (td *TxData) UnmarshalAmino(data []byte) error {
 		td.S = s
 	}
 
-	return nil
+	return td.setTypedFields(&e)
 }
 
-func (td *TxData) unmarshalFromAmino(cdc *amino.Codec, data []byte) error {
+func (td *TxData) unmarshalFromAmino(data []byte, withTyped bool) error {
 	var e encodableTxData
-	err := e.UnmarshalFromAmino(cdc, data)
+	err := e.unmarshalFromAmino(data, withTyped)
 	if err != nil {
 		return err
 	}
@@ -319,15 +447,49 @@ func (td *TxData) unmarshalFromAmino(cdc *amino.Codec, data []byte) error {
 		td.S = s
 	}
 
-	return nil
+	return td.setTypedFields(&e)
+}
+
+// setTypedFields sets the fields only carried by the typed transactions
+func (td *TxData) setTypedFields(e *encodableTxData) (err error) {
+	td.Type = e.Type
+	td.Accesses = e.Accesses
+	if td.ChainID, err = unmarshalOptionalBigInt(e.ChainID); err != nil {
+		return err
+	}
+	if td.GasTipCap, err = unmarshalOptionalBigInt(e.GasTipCap); err != nil {
+		return err
+	}
+	td.GasFeeCap, err = unmarshalOptionalBigInt(e.GasFeeCap)
+	return err
+}
+
+// marshalOptionalBigInt marshals the big int which is nil for the legacy transaction, nil is marshaled to
+// the empty string, so the amino encoding of the legacy transaction isn't changed
+func marshalOptionalBigInt(i *big.Int) (string, error) {
+	if i == nil {
+		return "", nil
+	}
+	return utils.MarshalBigInt(i)
+}
+
+func unmarshalOptionalBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return utils.UnmarshalBigInt(s)
 }
 
-func (td *TxData) UnmarshalFromAmino(cdc *amino.Codec, data []byte) error {
-	err := td.unmarshalFromAmino(cdc, data)
+// UnmarshalFromAmino decodes the amino bytes with or without the length prefix. The bytes without the prefix always
+// start with the key of the nonce or the price, the typed transaction fields are only decoded in that case at first,
+// so the length prefix isn't taken as the key of these fields by mistake.
+func (td *TxData) UnmarshalFromAmino(_ *amino.Codec, data []byte) error {
+	withTyped := len(data) > 0 && (data[0] == byte(1<<3|amino.Typ3_Varint) || data[0] == byte(2<<3|amino.Typ3_ByteLength))
+	err := td.unmarshalFromAmino(data, withTyped)
 	if err != nil {
 		u64, n, err := amino.DecodeUvarint(data)
 		if err == nil && int(u64) == (len(data)-n) {
-			return td.unmarshalFromAmino(cdc, data[n:])
+			return td.unmarshalFromAmino(data[n:], true)
 		} else {
 			return err
 		}
@@ -0,0 +1,266 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+	errEmptyTypedTx       = errors.New("empty typed transaction bytes")
+)
+
+// accessListTxPayload is the RLP payload of the EIP-2930 access list transaction
+type accessListTxPayload struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	Gas        uint64
+	To         *ethcmn.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList ethtypes.AccessList
+	V, R, S    *big.Int
+}
+
+// dynamicFeeTxPayload is the RLP payload of the EIP-1559 dynamic fee transaction
+type dynamicFeeTxPayload struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *ethcmn.Address `rlp:"nil"`
+	Value      *big.Int
+	Data       []byte
+	AccessList ethtypes.AccessList
+	V, R, S    *big.Int
+}
+
+// NewMsgEthereumTxAccessList returns a reference to a new EIP-2930 access list transaction message,
+// the recipient is nil for contract creation.
+func NewMsgEthereumTxAccessList(
+	chainID *big.Int, nonce uint64, to *ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasPrice *big.Int, payload []byte, accesses ethtypes.AccessList,
+) *MsgEthereumTx {
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, gasPrice, payload)
+	msg.Data.Type = ethtypes.AccessListTxType
+	msg.Data.ChainID = new(big.Int).Set(chainID)
+	msg.Data.Accesses = accesses
+	return msg
+}
+
+// NewMsgEthereumTxDynamicFee returns a reference to a new EIP-1559 dynamic fee transaction message,
+// the recipient is nil for contract creation.
+func NewMsgEthereumTxDynamicFee(
+	chainID *big.Int, nonce uint64, to *ethcmn.Address, amount *big.Int,
+	gasLimit uint64, gasTipCap, gasFeeCap *big.Int, payload []byte, accesses ethtypes.AccessList,
+) *MsgEthereumTx {
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, nil, payload)
+	msg.Data.Type = ethtypes.DynamicFeeTxType
+	msg.Data.ChainID = new(big.Int).Set(chainID)
+	msg.Data.GasTipCap = new(big.Int).Set(gasTipCap)
+	msg.Data.GasFeeCap = new(big.Int).Set(gasFeeCap)
+	msg.Data.Price = dynamicFeeGasPrice(msg.Data.GasTipCap, msg.Data.GasFeeCap)
+	msg.Data.Accesses = accesses
+	return msg
+}
+
+// dynamicFeeGasPrice returns the effective gas price of the dynamic fee transaction.
+// There is no base fee on the chain, so the sender pays the tip capped by the fee cap.
+func dynamicFeeGasPrice(gasTipCap, gasFeeCap *big.Int) *big.Int {
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		return new(big.Int).Set(gasFeeCap)
+	}
+	return new(big.Int).Set(gasTipCap)
+}
+
+// IsTypedTx returns true if the transaction is an EIP-2718 typed transaction
+func (msg *MsgEthereumTx) IsTypedTx() bool {
+	return msg.Data.Type != ethtypes.LegacyTxType
+}
+
+// AccessList returns the access list of the transaction, it's nil for the legacy transaction
+func (msg *MsgEthereumTx) AccessList() ethtypes.AccessList {
+	return msg.Data.Accesses
+}
+
+// MarshalBinary returns the canonical encoding of the transaction. For the legacy transaction it
+// is the RLP encoding, and for the typed transaction it's the EIP-2718 envelope type || payload.
+func (msg *MsgEthereumTx) MarshalBinary() ([]byte, error) {
+	if !msg.IsTypedTx() {
+		return rlp.EncodeToBytes(&msg.Data)
+	}
+	var buf bytes.Buffer
+	if err := msg.encodeTyped(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the canonical encoding of the transaction, it supports both the legacy
+// and the typed transactions.
+func (msg *MsgEthereumTx) UnmarshalBinary(b []byte) error {
+	if len(b) > 0 && b[0] > 0x7f {
+		// the legacy transaction is a RLP list
+		return rlp.DecodeBytes(b, msg)
+	}
+	return msg.decodeTyped(b)
+}
+
+func (msg *MsgEthereumTx) encodeTyped(buf *bytes.Buffer) error {
+	buf.WriteByte(msg.Data.Type)
+	switch msg.Data.Type {
+	case ethtypes.AccessListTxType:
+		return rlp.Encode(buf, &accessListTxPayload{
+			ChainID:    msg.Data.ChainID,
+			Nonce:      msg.Data.AccountNonce,
+			GasPrice:   msg.Data.Price,
+			Gas:        msg.Data.GasLimit,
+			To:         msg.Data.Recipient,
+			Value:      msg.Data.Amount,
+			Data:       msg.Data.Payload,
+			AccessList: msg.Data.Accesses,
+			V:          msg.Data.V,
+			R:          msg.Data.R,
+			S:          msg.Data.S,
+		})
+	case ethtypes.DynamicFeeTxType:
+		return rlp.Encode(buf, &dynamicFeeTxPayload{
+			ChainID:    msg.Data.ChainID,
+			Nonce:      msg.Data.AccountNonce,
+			GasTipCap:  msg.Data.GasTipCap,
+			GasFeeCap:  msg.Data.GasFeeCap,
+			Gas:        msg.Data.GasLimit,
+			To:         msg.Data.Recipient,
+			Value:      msg.Data.Amount,
+			Data:       msg.Data.Payload,
+			AccessList: msg.Data.Accesses,
+			V:          msg.Data.V,
+			R:          msg.Data.R,
+			S:          msg.Data.S,
+		})
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+func (msg *MsgEthereumTx) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case ethtypes.AccessListTxType:
+		var payload accessListTxPayload
+		if err := rlp.DecodeBytes(b[1:], &payload); err != nil {
+			return err
+		}
+		msg.Data = TxData{
+			AccountNonce: payload.Nonce,
+			Price:        payload.GasPrice,
+			GasLimit:     payload.Gas,
+			Recipient:    payload.To,
+			Amount:       payload.Value,
+			Payload:      payload.Data,
+			V:            payload.V,
+			R:            payload.R,
+			S:            payload.S,
+			Type:         ethtypes.AccessListTxType,
+			ChainID:      payload.ChainID,
+			Accesses:     payload.AccessList,
+		}
+	case ethtypes.DynamicFeeTxType:
+		var payload dynamicFeeTxPayload
+		if err := rlp.DecodeBytes(b[1:], &payload); err != nil {
+			return err
+		}
+		msg.Data = TxData{
+			AccountNonce: payload.Nonce,
+			Price:        dynamicFeeGasPrice(payload.GasTipCap, payload.GasFeeCap),
+			GasLimit:     payload.Gas,
+			Recipient:    payload.To,
+			Amount:       payload.Value,
+			Payload:      payload.Data,
+			V:            payload.V,
+			R:            payload.R,
+			S:            payload.S,
+			Type:         ethtypes.DynamicFeeTxType,
+			ChainID:      payload.ChainID,
+			GasTipCap:    payload.GasTipCap,
+			GasFeeCap:    payload.GasFeeCap,
+			Accesses:     payload.AccessList,
+		}
+	default:
+		return ErrTxTypeNotSupported
+	}
+	return nil
+}
+
+// typedSignHash returns the hash signed by the sender of the typed transaction,
+// it's keccak256(type || rlp(payload without signature values)).
+func (msg *MsgEthereumTx) typedSignHash(chainID *big.Int) (h ethcmn.Hash) {
+	var fields []interface{}
+	switch msg.Data.Type {
+	case ethtypes.AccessListTxType:
+		fields = []interface{}{
+			chainID,
+			msg.Data.AccountNonce,
+			msg.Data.Price,
+			msg.Data.GasLimit,
+			msg.Data.Recipient,
+			msg.Data.Amount,
+			msg.Data.Payload,
+			msg.Data.Accesses,
+		}
+	case ethtypes.DynamicFeeTxType:
+		fields = []interface{}{
+			chainID,
+			msg.Data.AccountNonce,
+			msg.Data.GasTipCap,
+			msg.Data.GasFeeCap,
+			msg.Data.GasLimit,
+			msg.Data.Recipient,
+			msg.Data.Amount,
+			msg.Data.Payload,
+			msg.Data.Accesses,
+		}
+	default:
+		return
+	}
+
+	hasher := sha3.NewLegacyKeccak256()
+	_, _ = hasher.Write([]byte{msg.Data.Type})
+	_ = rlp.Encode(hasher, fields)
+	hasher.Sum(h[:0])
+	return
+}
+
+// validateTyped checks the fields only carried by the typed transactions
+func (msg *MsgEthereumTx) validateTyped() error {
+	switch msg.Data.Type {
+	case ethtypes.AccessListTxType:
+	case ethtypes.DynamicFeeTxType:
+		if msg.Data.GasTipCap == nil || msg.Data.GasFeeCap == nil {
+			return errors.New("gas tip cap and gas fee cap are required")
+		}
+		if msg.Data.GasTipCap.Sign() < 0 || msg.Data.GasFeeCap.Sign() < 0 {
+			return fmt.Errorf("gas tip cap %s and gas fee cap %s cannot be negative", msg.Data.GasTipCap, msg.Data.GasFeeCap)
+		}
+		if msg.Data.GasTipCap.Cmp(msg.Data.GasFeeCap) > 0 {
+			return fmt.Errorf("gas tip cap %s cannot be higher than gas fee cap %s", msg.Data.GasTipCap, msg.Data.GasFeeCap)
+		}
+	default:
+		return ErrTxTypeNotSupported
+	}
+	if msg.Data.ChainID == nil || msg.Data.ChainID.Sign() <= 0 {
+		return fmt.Errorf("invalid chain id %s", msg.Data.ChainID)
+	}
+	return nil
+}
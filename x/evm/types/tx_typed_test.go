@@ -0,0 +1,172 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/app/crypto/ethsecp256k1"
+	authtypes "github.com/okex/exchain/libs/cosmos-sdk/x/auth/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+)
+
+func newTestTypedTxs(chainID *big.Int, to *ethcmn.Address) []*MsgEthereumTx {
+	accesses := ethtypes.AccessList{
+		{Address: ethcmn.HexToAddress("0x01"), StorageKeys: []ethcmn.Hash{ethcmn.HexToHash("0x02"), ethcmn.HexToHash("0x03")}},
+		{Address: ethcmn.HexToAddress("0x04"), StorageKeys: []ethcmn.Hash{}},
+	}
+	return []*MsgEthereumTx{
+		NewMsgEthereumTxAccessList(chainID, 1, to, big.NewInt(10), 100000, big.NewInt(20), []byte("test"), accesses),
+		NewMsgEthereumTxAccessList(chainID, 2, nil, big.NewInt(0), 100000, big.NewInt(20), []byte("test"), nil),
+		NewMsgEthereumTxDynamicFee(chainID, 3, to, big.NewInt(10), 100000, big.NewInt(2), big.NewInt(30), []byte("test"), accesses),
+		NewMsgEthereumTxDynamicFee(chainID, 4, to, big.NewInt(10), 100000, big.NewInt(50), big.NewInt(50), nil, nil),
+	}
+}
+
+func TestTypedTxCompatibleWithGoEthereum(t *testing.T) {
+	chainID := big.NewInt(65)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	signer := ethtypes.NewLondonSigner(chainID)
+
+	for _, msg := range newTestTypedTxs(chainID, &addr) {
+		require.NoError(t, msg.ValidateBasic())
+		require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+		raw, err := authtypes.EthereumTxEncode(msg)
+		require.NoError(t, err)
+
+		// go-ethereum decodes the envelope and recovers the same sender and hash
+		ethTx := new(ethtypes.Transaction)
+		require.NoError(t, ethTx.UnmarshalBinary(raw))
+		require.Equal(t, msg.Data.Type, ethTx.Type())
+		sender, err := ethtypes.Sender(signer, ethTx)
+		require.NoError(t, err)
+		require.Equal(t, addr, sender)
+		require.Equal(t, signer.Hash(ethTx), msg.RLPSignBytes(chainID))
+		require.Equal(t, ethTx.Hash(), ethcrypto.Keccak256Hash(raw))
+
+		// the envelope signed by go-ethereum is decoded and verified
+		signedTx, err := ethtypes.SignTx(ethTx, signer, priv.ToECDSA())
+		require.NoError(t, err)
+		raw, err = signedTx.MarshalBinary()
+		require.NoError(t, err)
+		var decoded MsgEthereumTx
+		require.NoError(t, authtypes.EthereumTxDecode(raw, &decoded))
+		require.NoError(t, decoded.VerifySig(chainID, 1))
+		require.Equal(t, addr, decoded.EthereumAddress())
+		require.Equal(t, chainID, decoded.ChainID())
+		require.True(t, decoded.Protected())
+		require.Equal(t, ethTx.AccessList(), decoded.AccessList())
+		require.Equal(t, msg.Data.Price, decoded.Data.Price)
+		reencoded, err := authtypes.EthereumTxEncode(&decoded)
+		require.NoError(t, err)
+		require.Equal(t, raw, reencoded)
+
+		// the envelope wrapped in a RLP string
+		rlpRaw, err := rlp.EncodeToBytes(&decoded)
+		require.NoError(t, err)
+		var rlpDecoded MsgEthereumTx
+		require.NoError(t, rlp.DecodeBytes(rlpRaw, &rlpDecoded))
+		require.Equal(t, decoded.Data, rlpDecoded.Data)
+	}
+}
+
+func TestTypedTxSig(t *testing.T) {
+	chainID := big.NewInt(65)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+
+	msg := newTestTypedTxs(chainID, &addr)[2]
+	require.Error(t, msg.Sign(big.NewInt(66), priv.ToECDSA()))
+	require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+	require.Error(t, msg.VerifySig(big.NewInt(66), 1))
+
+	// the fields out of the signature are tampered
+	msg.Data.GasTipCap = big.NewInt(3)
+	require.NoError(t, msg.VerifySig(chainID, 1))
+	require.NotEqual(t, addr, msg.EthereumAddress())
+}
+
+func TestTypedTxValidation(t *testing.T) {
+	chainID := big.NewInt(65)
+	to := ethcmn.HexToAddress("0x01")
+
+	msg := NewMsgEthereumTxDynamicFee(chainID, 0, &to, nil, 100000, big.NewInt(2), big.NewInt(30), nil, nil)
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, big.NewInt(2), msg.Data.Price)
+	require.Equal(t, big.NewInt(200000), msg.Fee())
+
+	msg = NewMsgEthereumTxDynamicFee(chainID, 0, &to, nil, 100000, big.NewInt(31), big.NewInt(30), nil, nil)
+	require.Error(t, msg.ValidateBasic())
+
+	msg = NewMsgEthereumTxDynamicFee(chainID, 0, &to, nil, 100000, big.NewInt(0), big.NewInt(30), nil, nil)
+	require.Error(t, msg.ValidateBasic())
+
+	msg = NewMsgEthereumTxAccessList(big.NewInt(0), 0, &to, nil, 100000, big.NewInt(20), nil, nil)
+	require.Error(t, msg.ValidateBasic())
+
+	msg = NewMsgEthereumTxAccessList(chainID, 0, &to, nil, 100000, big.NewInt(20), nil, nil)
+	msg.Data.Type = 3
+	require.Error(t, msg.ValidateBasic())
+	_, err := msg.MarshalBinary()
+	require.Equal(t, ErrTxTypeNotSupported, err)
+	require.Error(t, msg.UnmarshalBinary([]byte{3, 0xc0}))
+}
+
+func TestTypedTxAmino(t *testing.T) {
+	chainID := big.NewInt(65)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+
+	legacy := NewMsgEthereumTx(0, &addr, big.NewInt(1), 100000, big.NewInt(2), []byte("test"))
+	require.NoError(t, legacy.Sign(chainID, priv.ToECDSA()))
+	for _, msg := range append(newTestTypedTxs(chainID, &addr), legacy) {
+		require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+		raw, err := ModuleCdc.MarshalBinaryBare(msg)
+		require.NoError(t, err)
+
+		var msg2 MsgEthereumTx
+		require.NoError(t, ModuleCdc.UnmarshalBinaryBare(raw, &msg2))
+		var msg3 MsgEthereumTx
+		v, err := ModuleCdc.UnmarshalBinaryBareWithRegisteredUnmarshaller(raw, &msg3)
+		require.NoError(t, err)
+		msg3 = *v.(*MsgEthereumTx)
+		require.EqualValues(t, msg2, msg3)
+		require.Equal(t, msg.Data.Type, msg3.Data.Type)
+		require.Equal(t, msg.Data.ChainID, msg3.Data.ChainID)
+		require.Equal(t, msg.Data.GasTipCap, msg3.Data.GasTipCap)
+		require.Equal(t, msg.Data.GasFeeCap, msg3.Data.GasFeeCap)
+		require.Equal(t, len(msg.Data.Accesses), len(msg3.Data.Accesses))
+		for i, tuple := range msg.Data.Accesses {
+			require.Equal(t, tuple.Address, msg3.Data.Accesses[i].Address)
+			require.Equal(t, len(tuple.StorageKeys), len(msg3.Data.Accesses[i].StorageKeys))
+		}
+	}
+}
+
+func TestTypedTxDecoder(t *testing.T) {
+	chainID := big.NewInt(65)
+	priv, _ := ethsecp256k1.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	msg := newTestTypedTxs(chainID, &addr)[2]
+	require.NoError(t, msg.Sign(chainID, priv.ToECDSA()))
+	raw, err := authtypes.EthereumTxEncode(msg)
+	require.NoError(t, err)
+
+	tmtypes.UnittestOnlySetMilestoneVenusHeight(1)
+	defer tmtypes.UnittestOnlySetMilestoneVenusHeight(0)
+
+	_, err = evmDecoder(nil, raw, 10)
+	require.Error(t, err)
+
+	tmtypes.InitMilestoneVenus8Height(5)
+	defer tmtypes.InitMilestoneVenus8Height(0)
+	tx, err := evmDecoder(nil, raw, 10)
+	require.NoError(t, err)
+	require.Equal(t, msg.Data.GasFeeCap, tx.(*MsgEthereumTx).Data.GasFeeCap)
+}
@@ -0,0 +1,273 @@
+package watcher
+
+import (
+	"bytes"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/app/rpc/namespaces/eth/state"
+	app "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/common/monitor"
+)
+
+const (
+	FlagCheckInterval = "watchdb-check-interval"
+	FlagCheckSamples  = "watchdb-check-samples"
+	FlagCheckRepair   = "watchdb-check-repair"
+
+	CheckKindAccount = "account"
+	CheckKindStorage = "storage"
+)
+
+// StateReader reads the committed state the watch db is checked against, at a height or at the
+// latest height if height is 0. A missing account is returned as nil.
+type StateReader interface {
+	GetAccount(height int64, addr sdk.AccAddress) (auth.Account, error)
+	GetState(height int64, addr common.Address, key common.Hash) (common.Hash, error)
+}
+
+// Mismatch is an entry of the watch db different from the state.
+type Mismatch struct {
+	Kind string
+	// Key is the key of the entry in the watch db
+	Key []byte
+	// Repaired is true if the entry was replaced by the state, or removed
+	Repaired bool
+}
+
+// ConsistencyChecker compares samples of the accounts and the storage slots of the watch db with
+// the committed state.
+type ConsistencyChecker struct {
+	store   *WatchStore
+	reader  StateReader
+	samples int
+	repair  bool
+	metrics *monitor.WatcherMetrics
+	logger  log.Logger
+	rand    *rand.Rand
+}
+
+// NewConsistencyChecker returns a ConsistencyChecker sampling up to samples accounts and as many
+// storage slots per check, and repairing the mismatches if repair is true.
+func NewConsistencyChecker(store *WatchStore, reader StateReader, samples int, repair bool,
+	metrics *monitor.WatcherMetrics, logger log.Logger) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		store:   store,
+		reader:  reader,
+		samples: samples,
+		repair:  repair,
+		metrics: metrics,
+		logger:  logger,
+		rand:    rand.New(rand.NewSource(rand.Int63())),
+	}
+}
+
+// Check compares samples of the watch db with the state at height, the watch db must hold the
+// blocks up to height and no further. The entries written by the rpc from the latest state are
+// also compared with the latest state before being reported.
+func (c *ConsistencyChecker) Check(height int64) []Mismatch {
+	var mismatches []Mismatch
+	for _, addr := range c.sampleKeys(prefixAccount, sdk.AddrLen) {
+		if m := c.checkAccount(height, sdk.AccAddress(addr)); m != nil {
+			mismatches = append(mismatches, *m)
+		}
+	}
+	for _, key := range c.sampleKeys(PrefixState, common.AddressLength+common.HashLength) {
+		addr := common.BytesToAddress(key[:common.AddressLength])
+		if m := c.checkState(height, addr, common.BytesToHash(key[common.AddressLength:])); m != nil {
+			mismatches = append(mismatches, *m)
+		}
+	}
+	return mismatches
+}
+
+func (c *ConsistencyChecker) checkAccount(height int64, addr sdk.AccAddress) *Mismatch {
+	key := GetMsgAccountKey(addr.Bytes())
+	rdbKey := append(prefixRpcDb, key...)
+	fromRdb := false
+	bz, err := c.store.Get(key)
+	if err == nil && bz == nil {
+		fromRdb = true
+		bz, err = c.store.Get(rdbKey)
+	}
+	if err != nil || bz == nil {
+		return nil
+	}
+	watched, err := DecodeAccount(bz)
+	if err != nil {
+		c.logger.Error("failed to decode watchDB account", "address", addr, "err", err)
+		return nil
+	}
+	c.metrics.CheckedEntries.With(monitor.WatcherKindLabel, CheckKindAccount).Add(1)
+
+	committed, err := c.readAccount(height, addr)
+	if err != nil || committed == nil {
+		// the accounts deleted are erased from the watch db in the next block
+		return nil
+	}
+	if accountsEqual(watched, committed) {
+		return nil
+	}
+	if fromRdb {
+		if latest, err := c.readAccount(0, addr); err != nil || latest == nil || accountsEqual(watched, latest) {
+			return nil
+		}
+	}
+
+	m := &Mismatch{Kind: CheckKindAccount, Key: key}
+	c.metrics.Mismatches.With(monitor.WatcherKindLabel, CheckKindAccount).Add(1)
+	c.logger.Error("watchDB account mismatch", "height", height, "address", addr, "rpcdb", fromRdb,
+		"watchdb", watched.String(), "state", committed.String())
+	if c.repair {
+		// the rpc db is a cache of the latest state, the rpc fills it again
+		c.store.Delete(rdbKey)
+		if !fromRdb {
+			bz, err := EncodeAccount(committed)
+			if err != nil {
+				return m
+			}
+			c.store.Set(key, bz)
+		}
+		m.Repaired = true
+		c.metrics.Repaired.With(monitor.WatcherKindLabel, CheckKindAccount).Add(1)
+	}
+	return m
+}
+
+func (c *ConsistencyChecker) readAccount(height int64, addr sdk.AccAddress) (*app.EthAccount, error) {
+	acc, err := c.reader.GetAccount(height, addr)
+	if err != nil || acc == nil {
+		return nil, err
+	}
+	switch v := acc.(type) {
+	case app.EthAccount:
+		return &v, nil
+	case *app.EthAccount:
+		return v, nil
+	default:
+		return nil, nil
+	}
+}
+
+// accountsEqual compares the fields of the accounts served by the rpc.
+func accountsEqual(a, b *app.EthAccount) bool {
+	return a.GetCoins().IsEqual(b.GetCoins()) &&
+		a.GetSequence() == b.GetSequence() &&
+		bytes.Equal(a.CodeHash, b.CodeHash)
+}
+
+func (c *ConsistencyChecker) checkState(height int64, addr common.Address, slot common.Hash) *Mismatch {
+	key := GetMsgStateKey(addr, slot.Bytes())
+	rdbKey := append(prefixRpcDb, key...)
+	fromRdb := false
+	bz, err := c.store.Get(key)
+	if err == nil && bz == nil {
+		fromRdb = true
+		bz, err = c.store.Get(rdbKey)
+	}
+	if err != nil || bz == nil {
+		return nil
+	}
+	watched := common.BytesToHash(bz)
+	c.metrics.CheckedEntries.With(monitor.WatcherKindLabel, CheckKindStorage).Add(1)
+
+	committed, err := c.reader.GetState(height, addr, slot)
+	if err != nil || watched == committed {
+		return nil
+	}
+	if fromRdb {
+		if latest, err := c.reader.GetState(0, addr, slot); err != nil || watched == latest {
+			return nil
+		}
+	}
+
+	m := &Mismatch{Kind: CheckKindStorage, Key: key}
+	c.metrics.Mismatches.With(monitor.WatcherKindLabel, CheckKindStorage).Add(1)
+	c.logger.Error("watchDB storage mismatch", "height", height, "address", addr, "key", slot, "rpcdb", fromRdb,
+		"watchdb", watched, "state", committed)
+	if c.repair {
+		c.store.Delete(rdbKey)
+		if fromRdb {
+			state.RemoveStateFromLru(key)
+		} else {
+			c.store.Set(key, committed.Bytes())
+			state.SetStateToLru(key, committed.Bytes())
+		}
+		m.Repaired = true
+		c.metrics.Repaired.With(monitor.WatcherKindLabel, CheckKindStorage).Add(1)
+	}
+	return m
+}
+
+// sampleKeys returns up to samples distinct keys, without the prefix, of the entries under the
+// prefix and under the same prefix in the rpc db, from a random key on.
+func (c *ConsistencyChecker) sampleKeys(prefix []byte, keyLen int) [][]byte {
+	start := make([]byte, keyLen)
+	c.rand.Read(start)
+
+	seen := make(map[string]bool)
+	var keys [][]byte
+	for _, p := range [][]byte{prefix, append(append([]byte{}, prefixRpcDb...), prefix...)} {
+		limit := len(keys) + (c.samples+1)/2
+		// from the random key to the end, then from the beginning to the random key
+		ranges := [][2][]byte{
+			{append(append([]byte{}, p...), start...), prefixEnd(p)},
+			{p, append(append([]byte{}, p...), start...)},
+		}
+		for _, r := range ranges {
+			it := c.store.Iterator(r[0], r[1])
+			if it == nil {
+				continue
+			}
+			for ; it.Valid() && len(keys) < limit; it.Next() {
+				key := it.Key()[len(p):]
+				if len(key) != keyLen || seen[string(key)] {
+					continue
+				}
+				seen[string(key)] = true
+				keys = append(keys, append([]byte{}, key...))
+			}
+			it.Close()
+		}
+	}
+	return keys
+}
+
+// prefixEnd returns the end of the range of the keys starting with the prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// SetStateReader enables the consistency checks of the watch db every FlagCheckInterval blocks.
+func (w *Watcher) SetStateReader(reader StateReader) {
+	interval := viper.GetInt64(FlagCheckInterval)
+	if !w.Enabled() || interval <= 0 {
+		return
+	}
+	w.checkInterval = interval
+	w.checker = NewConsistencyChecker(w.store, reader, viper.GetInt(FlagCheckSamples),
+		viper.GetBool(FlagCheckRepair), w.metrics, w.log)
+}
+
+// dispatchCheck checks the watch db after the jobs of the height are done.
+func (w *Watcher) dispatchCheck(height uint64) {
+	if w.checker == nil || height%uint64(w.checkInterval) != 0 {
+		return
+	}
+	w.dispatchJob(func() {
+		mismatches := w.checker.Check(int64(height))
+		w.log.Info("watchDB consistency checked", "height", height, "mismatches", len(mismatches))
+	})
+}
@@ -0,0 +1,159 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	app "github.com/okex/exchain/app/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/cosmos-sdk/x/auth"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	"github.com/okex/exchain/x/common/monitor"
+)
+
+// mockStateReader holds the accounts and the storage of the latest height and of one height
+// before it.
+type mockStateReader struct {
+	height   int64
+	accounts map[int64]map[string]auth.Account
+	storage  map[int64]map[string]common.Hash
+}
+
+func newMockStateReader(height int64) *mockStateReader {
+	return &mockStateReader{
+		height:   height,
+		accounts: map[int64]map[string]auth.Account{height: {}, height + 1: {}},
+		storage:  map[int64]map[string]common.Hash{height: {}, height + 1: {}},
+	}
+}
+
+func (r *mockStateReader) at(height int64) int64 {
+	if height == 0 {
+		return r.height + 1
+	}
+	return height
+}
+
+func (r *mockStateReader) GetAccount(height int64, addr sdk.AccAddress) (auth.Account, error) {
+	return r.accounts[r.at(height)][string(addr)], nil
+}
+
+func (r *mockStateReader) GetState(height int64, addr common.Address, key common.Hash) (common.Hash, error) {
+	return r.storage[r.at(height)][string(GetMsgStateKey(addr, key.Bytes()))], nil
+}
+
+func newTestAccount(seed byte, balance int64, sequence uint64) *app.EthAccount {
+	addr := sdk.AccAddress(common.BytesToAddress([]byte{seed}).Bytes())
+	return &app.EthAccount{
+		BaseAccount: auth.NewBaseAccount(addr, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, balance)), nil, 1, sequence),
+		CodeHash:    ethcrypto.Keccak256(nil),
+	}
+}
+
+func TestConsistencyChecker(t *testing.T) {
+	const height = 10
+	store := &WatchStore{db: dbm.NewMemDB()}
+	reader := newMockStateReader(height)
+	setAccount := func(key []byte, height int64, watched, committed *app.EthAccount) {
+		bz, err := EncodeAccount(watched)
+		require.NoError(t, err)
+		store.Set(key, bz)
+		reader.accounts[height][string(committed.Address)] = committed
+	}
+
+	// consistent, stale, cached by the rpc at the latest height, and stale in the rpc cache
+	consistent, stale := newTestAccount(1, 10, 1), newTestAccount(2, 10, 1)
+	cached, staleCached := newTestAccount(3, 10, 1), newTestAccount(4, 10, 1)
+	setAccount(GetMsgAccountKey(consistent.Address), height, consistent, consistent)
+	setAccount(GetMsgAccountKey(stale.Address), height, stale, newTestAccount(2, 5, 2))
+	setAccount(append(prefixRpcDb, GetMsgAccountKey(cached.Address)...), height, newTestAccount(3, 20, 2), cached)
+	reader.accounts[height+1][string(cached.Address)] = newTestAccount(3, 20, 2)
+	setAccount(append(prefixRpcDb, GetMsgAccountKey(staleCached.Address)...), height, staleCached, newTestAccount(4, 1, 1))
+	reader.accounts[height+1][string(staleCached.Address)] = newTestAccount(4, 1, 1)
+	// accounts deleted from the state are erased from the watch db later
+	deleted := newTestAccount(5, 1, 1)
+	bz, err := EncodeAccount(deleted)
+	require.NoError(t, err)
+	store.Set(GetMsgAccountKey(deleted.Address), bz)
+
+	contract := common.BytesToAddress([]byte{6})
+	slot1, slot2 := common.BytesToHash([]byte{1}), common.BytesToHash([]byte{2})
+	store.Set(GetMsgStateKey(contract, slot1.Bytes()), common.BytesToHash([]byte{7}).Bytes())
+	reader.storage[height][string(GetMsgStateKey(contract, slot1.Bytes()))] = common.BytesToHash([]byte{7})
+	store.Set(GetMsgStateKey(contract, slot2.Bytes()), common.BytesToHash([]byte{7}).Bytes())
+	reader.storage[height][string(GetMsgStateKey(contract, slot2.Bytes()))] = common.BytesToHash([]byte{8})
+
+	checker := NewConsistencyChecker(store, reader, 100, false, monitor.NopWatcherMetrics(), log.NewNopLogger())
+	mismatches := checker.Check(height)
+	require.ElementsMatch(t, []Mismatch{
+		{Kind: CheckKindAccount, Key: GetMsgAccountKey(stale.Address)},
+		{Kind: CheckKindAccount, Key: GetMsgAccountKey(staleCached.Address)},
+		{Kind: CheckKindStorage, Key: GetMsgStateKey(contract, slot2.Bytes())},
+	}, mismatches)
+
+	// the repairs copy the state, and drop the rpc cache
+	checker.repair = true
+	mismatches = checker.Check(height)
+	require.Len(t, mismatches, 3)
+	for _, m := range mismatches {
+		require.True(t, m.Repaired)
+	}
+	require.Empty(t, checker.Check(height))
+
+	bz, err = store.Get(GetMsgAccountKey(stale.Address))
+	require.NoError(t, err)
+	repaired, err := DecodeAccount(bz)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), repaired.GetSequence())
+	require.False(t, store.Has(append(prefixRpcDb, GetMsgAccountKey(staleCached.Address)...)))
+	bz, err = store.Get(GetMsgStateKey(contract, slot2.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash([]byte{8}).Bytes(), bz)
+}
+
+func TestSampleKeys(t *testing.T) {
+	store := &WatchStore{db: dbm.NewMemDB()}
+	for i := byte(0); i < 10; i++ {
+		store.Set(GetMsgAccountKey(common.BytesToAddress([]byte{i}).Bytes()), []byte{1})
+		store.Set(append(prefixRpcDb, GetMsgAccountKey(common.BytesToAddress([]byte{i + 100}).Bytes())...), []byte{1})
+	}
+	// the keys of other sizes are skipped
+	store.Set(append(GetMsgAccountKey(common.BytesToAddress([]byte{1}).Bytes()), 0), []byte{1})
+
+	checker := NewConsistencyChecker(store, nil, 6, false, monitor.NopWatcherMetrics(), log.NewNopLogger())
+	keys := checker.sampleKeys(prefixAccount, common.AddressLength)
+	require.Len(t, keys, 6)
+	fromRdb := 0
+	for _, key := range keys {
+		require.Len(t, key, common.AddressLength)
+		if key[common.AddressLength-1] >= 100 {
+			fromRdb++
+		}
+	}
+	require.Equal(t, 3, fromRdb)
+
+	checker.samples = 100
+	require.Len(t, checker.sampleKeys(prefixAccount, common.AddressLength), 20)
+}
+
+func TestApplyWatchDataWrittenHeight(t *testing.T) {
+	w := &Watcher{
+		store:   &WatchStore{db: dbm.NewMemDB()},
+		enable:  true,
+		jobChan: make(chan func(), 1),
+		metrics: monitor.NopWatcherMetrics(),
+		log:     log.NewNopLogger(),
+	}
+	w.committedHeight = 5
+
+	// the delta of the committed height is written by the job
+	w.ApplyWatchData(WatchData{Batches: []*Batch{{Key: []byte("key"), Value: []byte("value"), TypeValue: TypeOthers}}})
+	require.Equal(t, uint64(0), w.writtenHeight)
+	(<-w.jobChan)()
+	require.Equal(t, uint64(5), w.writtenHeight)
+	require.True(t, w.store.Has([]byte("key")))
+}
@@ -0,0 +1,420 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/viper"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+	prototypes "github.com/okex/exchain/x/evm/watcher/proto"
+)
+
+const (
+	FlagLogIndex = "fast-query-log-index"
+
+	logPositionLen = 16
+
+	logIndexKindAddress = byte(0)
+	// the kind of the topic at position i is logIndexKindTopic + i
+	logIndexKindTopic = byte(1)
+	maxIndexedTopics  = 4
+
+	// the context of a query is checked every so many index entries
+	logIndexCheckInterval = 1024
+)
+
+var (
+	keyLogIndexBase = prefixLogIndexBase
+
+	errLogIndexDisabled = errors.New("log index is disabled")
+
+	logIndexEnable     = false
+	onceLogIndexEnable sync.Once
+)
+
+// IsLogIndexEnabled returns true if the log index is maintained in the watch db.
+func IsLogIndexEnabled() bool {
+	onceLogIndexEnable.Do(func() {
+		logIndexEnable = IsWatcherEnabled() && viper.GetBool(FlagLogIndex)
+	})
+	return logIndexEnable
+}
+
+// LogPosition is the position of a log in the chain, logs are indexed in this order.
+type LogPosition struct {
+	Height   uint64
+	TxIndex  uint32
+	LogIndex uint32
+}
+
+// Bytes encodes the position so that the encodings sort like the positions.
+func (p LogPosition) Bytes() []byte {
+	bz := make([]byte, logPositionLen)
+	binary.BigEndian.PutUint64(bz, p.Height)
+	binary.BigEndian.PutUint32(bz[8:], p.TxIndex)
+	binary.BigEndian.PutUint32(bz[12:], p.LogIndex)
+	return bz
+}
+
+// LogPositionFromBytes decodes a position encoded by Bytes.
+func LogPositionFromBytes(bz []byte) (LogPosition, error) {
+	if len(bz) != logPositionLen {
+		return LogPosition{}, fmt.Errorf("invalid log position length %d", len(bz))
+	}
+	return LogPosition{
+		Height:   binary.BigEndian.Uint64(bz),
+		TxIndex:  binary.BigEndian.Uint32(bz[8:]),
+		LogIndex: binary.BigEndian.Uint32(bz[12:]),
+	}, nil
+}
+
+func logPositionOf(height uint64, log *ethtypes.Log) LogPosition {
+	return LogPosition{Height: height, TxIndex: uint32(log.TxIndex), LogIndex: uint32(log.Index)}
+}
+
+func getLogKey(pos LogPosition) []byte {
+	return append(append([]byte{}, prefixLog...), pos.Bytes()...)
+}
+
+// getLogIndexPrefix returns the prefix of the index entries of a kind and a value, an address or
+// a topic, the entries are suffixed by the position of the log.
+func getLogIndexPrefix(kind byte, value []byte) []byte {
+	prefix := make([]byte, 0, len(prefixLogIndex)+1+len(value)+logPositionLen)
+	prefix = append(prefix, prefixLogIndex...)
+	prefix = append(prefix, kind)
+	return append(prefix, value...)
+}
+
+// MsgLogIndex is a log or an entry of the log index.
+type MsgLogIndex struct {
+	key   []byte
+	value string
+}
+
+func (m MsgLogIndex) GetType() uint32 {
+	return TypeOthers
+}
+
+func (m MsgLogIndex) GetKey() []byte {
+	return m.key
+}
+
+func (m MsgLogIndex) GetValue() string {
+	return m.value
+}
+
+// NewMsgLogIndex returns the messages saving the logs of a transaction and their index entries.
+func NewMsgLogIndex(height uint64, logs []*ethtypes.Log) []*MsgLogIndex {
+	msgs := make([]*MsgLogIndex, 0, len(logs)*3)
+	for _, log := range logs {
+		value, err := proto.Marshal(logToProto(log))
+		if err != nil {
+			continue
+		}
+		pos := logPositionOf(height, log)
+		msgs = append(msgs, &MsgLogIndex{key: getLogKey(pos), value: string(value)})
+		for _, entry := range logIndexEntries(pos, log) {
+			// the entries hold no value, but an empty value is lost in the delta sync
+			msgs = append(msgs, &MsgLogIndex{key: entry, value: "\x01"})
+		}
+	}
+	return msgs
+}
+
+func logIndexEntries(pos LogPosition, log *ethtypes.Log) [][]byte {
+	posBytes := pos.Bytes()
+	entries := [][]byte{append(getLogIndexPrefix(logIndexKindAddress, log.Address.Bytes()), posBytes...)}
+	for i, topic := range log.Topics {
+		if i == maxIndexedTopics {
+			break
+		}
+		entries = append(entries, append(getLogIndexPrefix(logIndexKindTopic+byte(i), topic.Bytes()), posBytes...))
+	}
+	return entries
+}
+
+// NewMsgLogIndexBase returns the message saving the first height covered by the log index.
+func NewMsgLogIndexBase(height uint64) *MsgLogIndex {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, height)
+	return &MsgLogIndex{key: keyLogIndexBase, value: string(bz)}
+}
+
+// saveLogIndex saves the logs of a transaction to the log index, the first block indexed sets
+// the base of the index.
+func (w *Watcher) saveLogIndex(logs []*ethtypes.Log) {
+	if !IsLogIndexEnabled() {
+		return
+	}
+	if !w.logIndexBaseSaved {
+		if _, ok := getLogIndexBase(w.store.db); !ok {
+			w.batch = append(w.batch, NewMsgLogIndexBase(w.height))
+		}
+		w.logIndexBaseSaved = true
+	}
+	for _, msg := range NewMsgLogIndex(w.height, logs) {
+		w.batch = append(w.batch, msg)
+	}
+}
+
+func getLogIndexBase(db dbm.DB) (uint64, bool) {
+	bz, err := db.Get(keyLogIndexBase)
+	if err != nil || len(bz) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(bz), true
+}
+
+// LogIndexBase returns the first height covered by the log index, and false if there is no
+// log index.
+func (q Querier) LogIndexBase() (uint64, bool) {
+	if !q.enabled() || !IsLogIndexEnabled() {
+		return 0, false
+	}
+	return getLogIndexBase(q.store.db)
+}
+
+// FilterLogsFromIndex returns the logs of the heights from to to matching the addresses and the
+// topics, in the order of the chain, starting after the position if not nil. If limit is
+// positive, at most limit logs are returned along with the position of the last one to resume
+// from; the position is nil when there are no more logs.
+func (q Querier) FilterLogsFromIndex(ctx context.Context, from, to uint64,
+	addresses []common.Address, topics [][]common.Hash, after *LogPosition, limit int) ([]*ethtypes.Log, *LogPosition, error) {
+	if !q.enabled() || !IsLogIndexEnabled() {
+		return nil, nil, errLogIndexDisabled
+	}
+	if from > to {
+		return nil, nil, nil
+	}
+	start := LogPosition{Height: from}.Bytes()
+	if after != nil {
+		if after.Height < from || after.Height > to {
+			return nil, nil, fmt.Errorf("log position at height %d out of range [%d, %d]", after.Height, from, to)
+		}
+		// the smallest key after the position
+		start = append(after.Bytes(), 0)
+	}
+	end := LogPosition{Height: to + 1}.Bytes()
+
+	// the index of the addresses if any, otherwise of the first topic constrained, yields the
+	// candidates which are checked against the whole criteria
+	var prefixes [][]byte
+	switch {
+	case len(addresses) > 0:
+		for _, addr := range addresses {
+			prefixes = append(prefixes, getLogIndexPrefix(logIndexKindAddress, addr.Bytes()))
+		}
+	default:
+		for i, sub := range topics {
+			if len(sub) == 0 || i >= maxIndexedTopics {
+				continue
+			}
+			for _, topic := range sub {
+				prefixes = append(prefixes, getLogIndexPrefix(logIndexKindTopic+byte(i), topic.Bytes()))
+			}
+			break
+		}
+	}
+	if len(prefixes) == 0 {
+		prefixes = [][]byte{prefixLog}
+	}
+
+	candidates, err := newLogIndexIterator(q.store.db, prefixes, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer candidates.Close()
+
+	var logs []*ethtypes.Log
+	var last *LogPosition
+	for checked := 0; candidates.Valid(); checked++ {
+		if checked%logIndexCheckInterval == 0 {
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			default:
+			}
+		}
+		pos := candidates.Position()
+		candidates.Next()
+
+		log, err := q.getIndexedLog(pos)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !logMatches(log, addresses, topics) {
+			continue
+		}
+		if limit > 0 && len(logs) == limit {
+			// there is one more log, resume from the last one returned
+			return logs, last, nil
+		}
+		logs = append(logs, log)
+		last = &pos
+	}
+	return logs, nil, nil
+}
+
+func (q Querier) getIndexedLog(pos LogPosition) (*ethtypes.Log, error) {
+	bz, err := q.store.Get(getLogKey(pos))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("log at %d/%d/%d missing from the log index", pos.Height, pos.TxIndex, pos.LogIndex)
+	}
+	var protoLog prototypes.Log
+	if err := proto.Unmarshal(bz, &protoLog); err != nil {
+		return nil, err
+	}
+	return protoToLog(&protoLog), nil
+}
+
+// logMatches returns true if the log matches the addresses and the topics, like eth_getLogs.
+func logMatches(log *ethtypes.Log, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, addr := range addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, sub := range topics {
+		if len(sub) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range sub {
+			if topic == log.Topics[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// logIndexIterator merges the positions of index entries under several prefixes in order.
+type logIndexIterator struct {
+	prefixes [][]byte
+	its      []dbm.Iterator
+}
+
+func newLogIndexIterator(db dbm.DB, prefixes [][]byte, start, end []byte) (*logIndexIterator, error) {
+	li := &logIndexIterator{}
+	for _, prefix := range prefixes {
+		it, err := db.Iterator(append(append([]byte{}, prefix...), start...), append(append([]byte{}, prefix...), end...))
+		if err != nil {
+			li.Close()
+			return nil, err
+		}
+		li.prefixes = append(li.prefixes, prefix)
+		li.its = append(li.its, it)
+	}
+	return li, nil
+}
+
+// current returns the iterator at the lowest position, or -1 when they are all exhausted.
+func (li *logIndexIterator) current() int {
+	cur := -1
+	var curPos []byte
+	for i, it := range li.its {
+		if !it.Valid() {
+			continue
+		}
+		pos := it.Key()[len(li.prefixes[i]):]
+		if cur < 0 || bytes.Compare(pos, curPos) < 0 {
+			cur, curPos = i, pos
+		}
+	}
+	return cur
+}
+
+func (li *logIndexIterator) Valid() bool {
+	return li.current() >= 0
+}
+
+func (li *logIndexIterator) Position() LogPosition {
+	i := li.current()
+	pos, _ := LogPositionFromBytes(li.its[i].Key()[len(li.prefixes[i]):])
+	return pos
+}
+
+func (li *logIndexIterator) Next() {
+	li.its[li.current()].Next()
+}
+
+func (li *logIndexIterator) Close() {
+	for _, it := range li.its {
+		it.Close()
+	}
+}
+
+// RebuildLogIndex rebuilds the log index of the watch db from the transaction receipts it holds,
+// and returns the number of logs indexed. The heights lower than from are skipped, the base of the
+// index is lowered to the first receipt indexed.
+func RebuildLogIndex(db dbm.DB, from uint64) (int, error) {
+	it, err := db.Iterator(prefixReceipt, []byte{prefixReceipt[0] + 1})
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	const batchSize = 10000
+	batch := db.NewBatch()
+	defer func() { batch.Close() }()
+	pending, count := 0, 0
+	base, hasBase := getLogIndexBase(db)
+	for ; it.Valid(); it.Next() {
+		var protoReceipt prototypes.TransactionReceipt
+		if err := proto.Unmarshal(it.Value(), &protoReceipt); err != nil {
+			return count, fmt.Errorf("failed to decode receipt %x: %w", it.Key(), err)
+		}
+		receipt := protoToReceipt(&protoReceipt)
+		height := uint64(receipt.BlockNumber)
+		if height < from {
+			continue
+		}
+		if !hasBase || height < base {
+			base, hasBase = height, true
+		}
+		for _, msg := range NewMsgLogIndex(height, receipt.Logs) {
+			batch.Set(msg.GetKey(), []byte(msg.GetValue()))
+			pending++
+		}
+		count += len(receipt.Logs)
+
+		if pending >= batchSize {
+			if err := batch.Write(); err != nil {
+				return count, err
+			}
+			batch.Close()
+			batch = db.NewBatch()
+			pending = 0
+		}
+	}
+	if hasBase {
+		msg := NewMsgLogIndexBase(base)
+		batch.Set(msg.GetKey(), []byte(msg.GetValue()))
+	}
+	return count, batch.Write()
+}
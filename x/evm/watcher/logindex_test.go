@@ -0,0 +1,160 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+var (
+	logAddr1  = common.HexToAddress("0x1")
+	logAddr2  = common.HexToAddress("0x2")
+	logTopicA = common.HexToHash("0xa")
+	logTopicB = common.HexToHash("0xb")
+	logTopicC = common.HexToHash("0xc")
+)
+
+func newLogIndexQuerier(t *testing.T) (Querier, dbm.DB) {
+	onceLogIndexEnable.Do(func() {})
+	logIndexEnable = true
+	t.Cleanup(func() { logIndexEnable = false })
+
+	db := dbm.NewMemDB()
+	return Querier{store: &WatchStore{db: db}, sw: true}, db
+}
+
+func newTestLog(height uint64, txIndex, index uint, addr common.Address, topics ...common.Hash) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address:     addr,
+		Topics:      topics,
+		Data:        []byte{},
+		BlockNumber: height,
+		TxHash:      common.BigToHash(common.Big1),
+		TxIndex:     txIndex,
+		Index:       index,
+	}
+}
+
+func saveTestLogs(db dbm.DB, height uint64, logs ...*ethtypes.Log) {
+	for _, msg := range NewMsgLogIndex(height, logs) {
+		db.Set(msg.GetKey(), []byte(msg.GetValue()))
+	}
+}
+
+func positionsOf(logs []*ethtypes.Log) []LogPosition {
+	positions := make([]LogPosition, len(logs))
+	for i, log := range logs {
+		positions[i] = logPositionOf(log.BlockNumber, log)
+	}
+	return positions
+}
+
+func TestLogPosition(t *testing.T) {
+	pos := LogPosition{Height: 10, TxIndex: 2, LogIndex: 7}
+	decoded, err := LogPositionFromBytes(pos.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, pos, decoded)
+	_, err = LogPositionFromBytes([]byte{1})
+	require.Error(t, err)
+}
+
+func TestFilterLogsFromIndex(t *testing.T) {
+	q, db := newLogIndexQuerier(t)
+	saveTestLogs(db, 10,
+		newTestLog(10, 0, 0, logAddr1, logTopicA),
+		newTestLog(10, 0, 1, logAddr2, logTopicA, logTopicB),
+		newTestLog(10, 1, 2, logAddr1, logTopicB))
+	saveTestLogs(db, 11, newTestLog(11, 0, 0, logAddr2, logTopicC))
+	saveTestLogs(db, 12,
+		newTestLog(12, 0, 0, logAddr1, logTopicA, logTopicC),
+		newTestLog(12, 3, 1, logAddr2))
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		from, to  uint64
+		addresses []common.Address
+		topics    [][]common.Hash
+		expected  []LogPosition
+	}{
+		{"all", 1, 20, nil, nil, []LogPosition{{10, 0, 0}, {10, 0, 1}, {10, 1, 2}, {11, 0, 0}, {12, 0, 0}, {12, 3, 1}}},
+		{"range", 11, 11, nil, nil, []LogPosition{{11, 0, 0}}},
+		{"address", 1, 20, []common.Address{logAddr1}, nil, []LogPosition{{10, 0, 0}, {10, 1, 2}, {12, 0, 0}}},
+		{"addresses and topic", 1, 20, []common.Address{logAddr1, logAddr2}, [][]common.Hash{{logTopicA}},
+			[]LogPosition{{10, 0, 0}, {10, 0, 1}, {12, 0, 0}}},
+		{"topic alternatives", 1, 11, nil, [][]common.Hash{{logTopicB, logTopicC}}, []LogPosition{{10, 1, 2}, {11, 0, 0}}},
+		{"second topic", 1, 20, nil, [][]common.Hash{nil, {logTopicB, logTopicC}}, []LogPosition{{10, 0, 1}, {12, 0, 0}}},
+		{"no match", 1, 20, []common.Address{logAddr2}, [][]common.Hash{{logTopicB}}, []LogPosition{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logs, next, err := q.FilterLogsFromIndex(ctx, tc.from, tc.to, tc.addresses, tc.topics, nil, 0)
+			require.NoError(t, err)
+			require.Nil(t, next)
+			require.Equal(t, tc.expected, positionsOf(logs))
+		})
+	}
+
+	// paginate the logs of the first address
+	addresses := []common.Address{logAddr1}
+	logs, next, err := q.FilterLogsFromIndex(ctx, 1, 20, addresses, nil, nil, 2)
+	require.NoError(t, err)
+	require.Equal(t, []LogPosition{{10, 0, 0}, {10, 1, 2}}, positionsOf(logs))
+	require.Equal(t, &LogPosition{10, 1, 2}, next)
+	logs, next, err = q.FilterLogsFromIndex(ctx, 1, 20, addresses, nil, next, 2)
+	require.NoError(t, err)
+	require.Equal(t, []LogPosition{{12, 0, 0}}, positionsOf(logs))
+	require.Nil(t, next)
+
+	_, _, err = q.FilterLogsFromIndex(ctx, 11, 20, addresses, nil, &LogPosition{Height: 10}, 2)
+	require.Error(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _, err = q.FilterLogsFromIndex(canceled, 1, 20, nil, nil, nil, 0)
+	require.Equal(t, context.Canceled, err)
+}
+
+func TestRebuildLogIndex(t *testing.T) {
+	q, db := newLogIndexQuerier(t)
+	_, ok := q.LogIndexBase()
+	require.False(t, ok)
+
+	saveReceipt := func(txHash common.Hash, height uint64, logs ...*ethtypes.Log) {
+		receipt := &TransactionReceipt{BlockNumber: hexutil.Uint64(height), Logs: logs}
+		bz, err := proto.Marshal(receiptToProto(receipt))
+		require.NoError(t, err)
+		require.NoError(t, db.Set(append(prefixReceipt, txHash.Bytes()...), bz))
+	}
+	saveReceipt(common.HexToHash("0x1"), 5, newTestLog(5, 0, 0, logAddr1, logTopicA))
+	saveReceipt(common.HexToHash("0x2"), 8)
+	saveReceipt(common.HexToHash("0x3"), 9,
+		newTestLog(9, 1, 0, logAddr1, logTopicB),
+		newTestLog(9, 1, 1, logAddr2, logTopicA))
+
+	count, err := RebuildLogIndex(db, 6)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	base, ok := q.LogIndexBase()
+	require.True(t, ok)
+	require.Equal(t, uint64(8), base)
+
+	logs, _, err := q.FilterLogsFromIndex(context.Background(), 1, 20, nil, [][]common.Hash{{logTopicA}}, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, []LogPosition{{9, 1, 1}}, positionsOf(logs))
+	require.Equal(t, logAddr2, logs[0].Address)
+
+	// rebuilding from lower heights lowers the base
+	count, err = RebuildLogIndex(db, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	base, _ = q.LogIndexBase()
+	require.Equal(t, uint64(5), base)
+}
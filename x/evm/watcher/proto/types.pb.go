@@ -23,20 +23,25 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Transaction struct {
-	BlockHash        []byte `protobuf:"bytes,1,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
-	BlockNumber      string `protobuf:"bytes,2,opt,name=BlockNumber,proto3" json:"BlockNumber,omitempty"`
-	From             []byte `protobuf:"bytes,3,opt,name=From,proto3" json:"From,omitempty"`
-	Gas              uint64 `protobuf:"varint,4,opt,name=Gas,proto3" json:"Gas,omitempty"`
-	GasPrice         string `protobuf:"bytes,5,opt,name=GasPrice,proto3" json:"GasPrice,omitempty"`
-	Hash             []byte `protobuf:"bytes,6,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	Input            []byte `protobuf:"bytes,7,opt,name=Input,proto3" json:"Input,omitempty"`
-	Nonce            uint64 `protobuf:"varint,8,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
-	To               []byte `protobuf:"bytes,9,opt,name=To,proto3" json:"To,omitempty"`
-	TransactionIndex uint64 `protobuf:"varint,10,opt,name=TransactionIndex,proto3" json:"TransactionIndex,omitempty"`
-	Value            string `protobuf:"bytes,11,opt,name=Value,proto3" json:"Value,omitempty"`
-	V                string `protobuf:"bytes,12,opt,name=V,proto3" json:"V,omitempty"`
-	R                string `protobuf:"bytes,13,opt,name=R,proto3" json:"R,omitempty"`
-	S                string `protobuf:"bytes,14,opt,name=S,proto3" json:"S,omitempty"`
+	BlockHash        []byte         `protobuf:"bytes,1,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	BlockNumber      string         `protobuf:"bytes,2,opt,name=BlockNumber,proto3" json:"BlockNumber,omitempty"`
+	From             []byte         `protobuf:"bytes,3,opt,name=From,proto3" json:"From,omitempty"`
+	Gas              uint64         `protobuf:"varint,4,opt,name=Gas,proto3" json:"Gas,omitempty"`
+	GasPrice         string         `protobuf:"bytes,5,opt,name=GasPrice,proto3" json:"GasPrice,omitempty"`
+	Hash             []byte         `protobuf:"bytes,6,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Input            []byte         `protobuf:"bytes,7,opt,name=Input,proto3" json:"Input,omitempty"`
+	Nonce            uint64         `protobuf:"varint,8,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	To               []byte         `protobuf:"bytes,9,opt,name=To,proto3" json:"To,omitempty"`
+	TransactionIndex uint64         `protobuf:"varint,10,opt,name=TransactionIndex,proto3" json:"TransactionIndex,omitempty"`
+	Value            string         `protobuf:"bytes,11,opt,name=Value,proto3" json:"Value,omitempty"`
+	V                string         `protobuf:"bytes,12,opt,name=V,proto3" json:"V,omitempty"`
+	R                string         `protobuf:"bytes,13,opt,name=R,proto3" json:"R,omitempty"`
+	S                string         `protobuf:"bytes,14,opt,name=S,proto3" json:"S,omitempty"`
+	Type             uint64         `protobuf:"varint,15,opt,name=Type,proto3" json:"Type,omitempty"`
+	ChainID          string         `protobuf:"bytes,16,opt,name=ChainID,proto3" json:"ChainID,omitempty"`
+	GasTipCap        string         `protobuf:"bytes,17,opt,name=GasTipCap,proto3" json:"GasTipCap,omitempty"`
+	GasFeeCap        string         `protobuf:"bytes,18,opt,name=GasFeeCap,proto3" json:"GasFeeCap,omitempty"`
+	AccessList       []*AccessTuple `protobuf:"bytes,19,rep,name=AccessList,proto3" json:"AccessList,omitempty"`
 }
 
 func (m *Transaction) Reset()         { *m = Transaction{} }
@@ -170,6 +175,41 @@ func (m *Transaction) GetS() string {
 	return ""
 }
 
+func (m *Transaction) GetType() uint64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Transaction) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
+func (m *Transaction) GetGasTipCap() string {
+	if m != nil {
+		return m.GasTipCap
+	}
+	return ""
+}
+
+func (m *Transaction) GetGasFeeCap() string {
+	if m != nil {
+		return m.GasFeeCap
+	}
+	return ""
+}
+
+func (m *Transaction) GetAccessList() []*AccessTuple {
+	if m != nil {
+		return m.AccessList
+	}
+	return nil
+}
+
 type Log struct {
 	Address     []byte   `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
 	Topics      [][]byte `protobuf:"bytes,2,rep,name=Topics,proto3" json:"Topics,omitempty"`
@@ -291,6 +331,7 @@ type TransactionReceipt struct {
 	TransactionIndex  uint64 `protobuf:"varint,10,opt,name=TransactionIndex,proto3" json:"TransactionIndex,omitempty"`
 	From              string `protobuf:"bytes,11,opt,name=From,proto3" json:"From,omitempty"`
 	To                []byte `protobuf:"bytes,12,opt,name=To,proto3" json:"To,omitempty"`
+	Type              uint64 `protobuf:"varint,13,opt,name=Type,proto3" json:"Type,omitempty"`
 }
 
 func (m *TransactionReceipt) Reset()         { *m = TransactionReceipt{} }
@@ -410,52 +451,118 @@ func (m *TransactionReceipt) GetTo() []byte {
 	return nil
 }
 
+func (m *TransactionReceipt) GetType() uint64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+type AccessTuple struct {
+	Address     []byte   `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	StorageKeys [][]byte `protobuf:"bytes,2,rep,name=StorageKeys,proto3" json:"StorageKeys,omitempty"`
+}
+
+func (m *AccessTuple) Reset()         { *m = AccessTuple{} }
+func (m *AccessTuple) String() string { return proto.CompactTextString(m) }
+func (*AccessTuple) ProtoMessage()    {}
+func (*AccessTuple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{3}
+}
+func (m *AccessTuple) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccessTuple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccessTuple.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AccessTuple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessTuple.Merge(m, src)
+}
+func (m *AccessTuple) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccessTuple) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessTuple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessTuple proto.InternalMessageInfo
+
+func (m *AccessTuple) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *AccessTuple) GetStorageKeys() [][]byte {
+	if m != nil {
+		return m.StorageKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Transaction)(nil), "x.evm.watcher.proto.Transaction")
 	proto.RegisterType((*Log)(nil), "x.evm.watcher.proto.Log")
 	proto.RegisterType((*TransactionReceipt)(nil), "x.evm.watcher.proto.TransactionReceipt")
+	proto.RegisterType((*AccessTuple)(nil), "x.evm.watcher.proto.AccessTuple")
 }
 
 func init() { proto.RegisterFile("types.proto", fileDescriptor_d938547f84707355) }
 
 var fileDescriptor_d938547f84707355 = []byte{
-	// 563 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xcf, 0x8e, 0xd3, 0x3e,
-	0x10, 0xc7, 0x37, 0x4d, 0xfa, 0xcf, 0xed, 0x6f, 0x7f, 0x8b, 0x41, 0xc8, 0x42, 0x28, 0x8a, 0x7a,
-	0x8a, 0x60, 0x95, 0x48, 0xf0, 0x04, 0x74, 0x81, 0xb2, 0x52, 0xb5, 0x42, 0x6e, 0xe9, 0x81, 0x9b,
-	0x9b, 0x5a, 0x6d, 0xb4, 0x4d, 0x5c, 0xc5, 0x4e, 0x09, 0x6f, 0xc1, 0x43, 0xf0, 0x30, 0x48, 0x5c,
-	0xf6, 0xc8, 0x11, 0xb5, 0x67, 0xde, 0x01, 0x79, 0x9c, 0xb4, 0xdd, 0x16, 0x0e, 0x9c, 0x9a, 0xef,
-	0xb7, 0x9e, 0xf1, 0xcc, 0xc7, 0x33, 0xa8, 0xa3, 0x3e, 0xaf, 0xb8, 0x0c, 0x56, 0x99, 0x50, 0x02,
-	0x3f, 0x2c, 0x02, 0xbe, 0x4e, 0x82, 0x4f, 0x4c, 0x45, 0x0b, 0x9e, 0x19, 0xb3, 0xf7, 0xbd, 0x86,
-	0x3a, 0xe3, 0x8c, 0xa5, 0x92, 0x45, 0x2a, 0x16, 0x29, 0x7e, 0x8a, 0xda, 0xfd, 0xa5, 0x88, 0x6e,
-	0xdf, 0x31, 0xb9, 0x20, 0x96, 0x67, 0xf9, 0x5d, 0xba, 0x37, 0xb0, 0x87, 0x3a, 0x20, 0x6e, 0xf2,
-	0x64, 0xca, 0x33, 0x52, 0xf3, 0x2c, 0xbf, 0x4d, 0x0f, 0x2d, 0x8c, 0x91, 0xf3, 0x36, 0x13, 0x09,
-	0xb1, 0x21, 0x14, 0xbe, 0xf1, 0x05, 0xb2, 0x07, 0x4c, 0x12, 0xc7, 0xb3, 0x7c, 0x87, 0xea, 0x4f,
-	0xfc, 0x04, 0xb5, 0x06, 0x4c, 0xbe, 0xcf, 0xe2, 0x88, 0x93, 0x3a, 0x24, 0xd9, 0x69, 0x9d, 0x01,
-	0x2e, 0x6f, 0x98, 0x0c, 0x70, 0xef, 0x23, 0x54, 0xbf, 0x4e, 0x57, 0xb9, 0x22, 0x4d, 0x30, 0x8d,
-	0xd0, 0xee, 0x8d, 0x48, 0x23, 0x4e, 0x5a, 0x90, 0xd9, 0x08, 0x7c, 0x8e, 0x6a, 0x63, 0x41, 0xda,
-	0x70, 0xb0, 0x36, 0x16, 0xf8, 0x19, 0xba, 0x38, 0x68, 0xf0, 0x3a, 0x9d, 0xf1, 0x82, 0x20, 0x08,
-	0x38, 0xf1, 0x75, 0xc6, 0x09, 0x5b, 0xe6, 0x9c, 0x74, 0xa0, 0x28, 0x23, 0x70, 0x17, 0x59, 0x13,
-	0xd2, 0x05, 0xc7, 0x9a, 0x68, 0x45, 0xc9, 0x7f, 0x46, 0x51, 0xad, 0x46, 0xe4, 0xdc, 0xa8, 0x51,
-	0xef, 0x97, 0x85, 0xec, 0xa1, 0x98, 0x63, 0x82, 0x9a, 0xaf, 0x66, 0xb3, 0x8c, 0x4b, 0x59, 0x32,
-	0xac, 0x24, 0x7e, 0x8c, 0x1a, 0x63, 0xb1, 0x8a, 0x23, 0x49, 0x6a, 0x9e, 0xed, 0x77, 0x69, 0xa9,
-	0x74, 0xd7, 0xaf, 0x99, 0x62, 0x15, 0x37, 0xfd, 0x7d, 0x4c, 0xdb, 0xf0, 0xbb, 0x47, 0x5b, 0x67,
-	0x2b, 0x80, 0x56, 0x1d, 0xe2, 0x4a, 0xa5, 0xef, 0x1f, 0x17, 0xa6, 0xd5, 0x06, 0x44, 0x55, 0xf2,
-	0xfe, 0xfb, 0x36, 0x8f, 0xdf, 0x17, 0x38, 0xeb, 0xa8, 0x92, 0xa8, 0x89, 0x21, 0xa8, 0x49, 0x79,
-	0x22, 0xd6, 0x7c, 0x06, 0x58, 0x5b, 0xb4, 0x92, 0xbd, 0xaf, 0x36, 0xc2, 0x07, 0x10, 0x29, 0x8f,
-	0x78, 0xbc, 0x52, 0xba, 0xac, 0x91, 0x62, 0x2a, 0x37, 0xdd, 0x3b, 0xb4, 0x54, 0xf8, 0x12, 0x3d,
-	0xb8, 0xca, 0x93, 0x7c, 0xc9, 0x54, 0xbc, 0xe6, 0x03, 0x26, 0x3f, 0x48, 0x3e, 0x83, 0x21, 0x72,
-	0xe8, 0xe9, 0x1f, 0xba, 0xd4, 0xa1, 0x98, 0xcb, 0xfe, 0x52, 0xec, 0xe6, 0x69, 0x6f, 0xe0, 0x4b,
-	0xe4, 0x68, 0x41, 0x1c, 0xcf, 0xf6, 0x3b, 0x2f, 0x48, 0xf0, 0x87, 0xe1, 0x0e, 0x86, 0x62, 0x4e,
-	0xe1, 0x14, 0xf6, 0xd1, 0xff, 0x07, 0x75, 0xee, 0x88, 0xb5, 0xe9, 0xb1, 0xad, 0x4f, 0x5e, 0x89,
-	0x54, 0x65, 0x2c, 0x52, 0xd5, 0x13, 0x9a, 0x49, 0x3c, 0xb6, 0x35, 0x96, 0xaa, 0x87, 0xa6, 0x81,
-	0x7c, 0x50, 0xf9, 0x1e, 0x72, 0x0b, 0xee, 0xf9, 0xfb, 0x12, 0xb5, 0x4f, 0x9f, 0xf5, 0x5f, 0x46,
-	0xb6, 0x5a, 0x38, 0x33, 0xb1, 0x66, 0xe1, 0xcc, 0x0a, 0x74, 0xab, 0x15, 0xe8, 0xbf, 0xf9, 0xb6,
-	0x71, 0xad, 0xbb, 0x8d, 0x6b, 0xfd, 0xdc, 0xb8, 0xd6, 0x97, 0xad, 0x7b, 0x76, 0xb7, 0x75, 0xcf,
-	0x7e, 0x6c, 0xdd, 0xb3, 0x8f, 0xcf, 0xe7, 0xb1, 0x5a, 0xe4, 0xd3, 0x20, 0x12, 0x49, 0x28, 0x6e,
-	0x79, 0x11, 0xf2, 0x22, 0x5a, 0xb0, 0x38, 0x0d, 0x8b, 0x90, 0xaf, 0x93, 0xb0, 0xc4, 0x19, 0x02,
-	0xce, 0x69, 0x03, 0x7e, 0x5e, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xb8, 0xa1, 0xa3, 0xb0, 0x56,
-	0x04, 0x00, 0x00,
+	// 660 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcb, 0x6e, 0xdb, 0x3a,
+	0x10, 0x8d, 0x2c, 0xc5, 0x0f, 0xca, 0x79, 0x31, 0x17, 0x17, 0xc4, 0xc5, 0x85, 0x21, 0x78, 0x65,
+	0xb4, 0x81, 0x0d, 0xb4, 0x3f, 0xd0, 0xc4, 0x69, 0x5c, 0xa3, 0x46, 0x50, 0xd0, 0x6a, 0x16, 0xdd,
+	0x31, 0x32, 0x61, 0x0b, 0xb1, 0x44, 0x41, 0xa4, 0x5c, 0xe5, 0x2f, 0xfa, 0x39, 0xfd, 0x84, 0x2e,
+	0xb3, 0xec, 0xb2, 0x48, 0xd6, 0x5d, 0x77, 0x5b, 0x70, 0x28, 0xd9, 0xaa, 0x93, 0x16, 0xe8, 0xca,
+	0x3c, 0x87, 0x33, 0xa3, 0xe1, 0x9c, 0x33, 0x46, 0xae, 0xba, 0x4d, 0xb8, 0xec, 0x27, 0xa9, 0x50,
+	0x02, 0x1f, 0xe7, 0x7d, 0xbe, 0x8a, 0xfa, 0x1f, 0x99, 0x0a, 0x16, 0x3c, 0x35, 0x64, 0xf7, 0x87,
+	0x8d, 0x5c, 0x3f, 0x65, 0xb1, 0x64, 0x81, 0x0a, 0x45, 0x8c, 0xff, 0x47, 0xad, 0xb3, 0xa5, 0x08,
+	0x6e, 0xde, 0x30, 0xb9, 0x20, 0x96, 0x67, 0xf5, 0xda, 0x74, 0x43, 0x60, 0x0f, 0xb9, 0x00, 0x2e,
+	0xb3, 0xe8, 0x9a, 0xa7, 0xa4, 0xe6, 0x59, 0xbd, 0x16, 0xad, 0x52, 0x18, 0x23, 0xe7, 0x22, 0x15,
+	0x11, 0xb1, 0x21, 0x15, 0xce, 0xf8, 0x10, 0xd9, 0x23, 0x26, 0x89, 0xe3, 0x59, 0x3d, 0x87, 0xea,
+	0x23, 0xfe, 0x0f, 0x35, 0x47, 0x4c, 0xbe, 0x4b, 0xc3, 0x80, 0x93, 0x5d, 0x28, 0xb2, 0xc6, 0xba,
+	0x02, 0x7c, 0xbc, 0x6e, 0x2a, 0xc0, 0x77, 0xff, 0x41, 0xbb, 0xe3, 0x38, 0xc9, 0x14, 0x69, 0x00,
+	0x69, 0x80, 0x66, 0x2f, 0x45, 0x1c, 0x70, 0xd2, 0x84, 0xca, 0x06, 0xe0, 0x7d, 0x54, 0xf3, 0x05,
+	0x69, 0x41, 0x60, 0xcd, 0x17, 0xf8, 0x19, 0x3a, 0xac, 0x3c, 0x70, 0x1c, 0xcf, 0x78, 0x4e, 0x10,
+	0x24, 0x3c, 0xe2, 0x75, 0xc5, 0x2b, 0xb6, 0xcc, 0x38, 0x71, 0xa1, 0x29, 0x03, 0x70, 0x1b, 0x59,
+	0x57, 0xa4, 0x0d, 0x8c, 0x75, 0xa5, 0x11, 0x25, 0x7b, 0x06, 0x51, 0x8d, 0xa6, 0x64, 0xdf, 0xa0,
+	0xa9, 0xee, 0xdd, 0xbf, 0x4d, 0x38, 0x39, 0x80, 0xfa, 0x70, 0xc6, 0x04, 0x35, 0x86, 0x0b, 0x16,
+	0xc6, 0xe3, 0x73, 0x72, 0x08, 0x71, 0x25, 0xd4, 0xb3, 0x1e, 0x31, 0xe9, 0x87, 0xc9, 0x90, 0x25,
+	0xe4, 0x08, 0xee, 0x36, 0x44, 0x71, 0x7b, 0xc1, 0xb9, 0xbe, 0xc5, 0xeb, 0x5b, 0x43, 0xe0, 0x57,
+	0x08, 0x9d, 0x06, 0x01, 0x97, 0x72, 0x12, 0x4a, 0x45, 0x8e, 0x3d, 0xbb, 0xe7, 0xbe, 0xf0, 0xfa,
+	0x4f, 0x28, 0xdc, 0x37, 0x61, 0x7e, 0x96, 0x2c, 0x39, 0xad, 0xe4, 0x74, 0xbf, 0x5b, 0xc8, 0x9e,
+	0x88, 0xb9, 0xee, 0xef, 0x74, 0x36, 0x4b, 0xb9, 0x94, 0x85, 0xde, 0x25, 0xc4, 0xff, 0xa2, 0xba,
+	0x2f, 0x92, 0x30, 0x90, 0xa4, 0xe6, 0xd9, 0xbd, 0x36, 0x2d, 0x90, 0x7e, 0xe5, 0x39, 0x53, 0xac,
+	0xd4, 0x58, 0x9f, 0xb7, 0x9d, 0x61, 0xb4, 0xae, 0x52, 0x50, 0x2d, 0x07, 0x65, 0x77, 0x21, 0xaf,
+	0x40, 0xfa, 0xfb, 0x7e, 0x6e, 0x64, 0xa9, 0x43, 0x56, 0x09, 0x7f, 0xf5, 0x62, 0x63, 0xdb, 0x8b,
+	0xe0, 0x09, 0x9d, 0x55, 0xa8, 0x6f, 0x72, 0x08, 0x6a, 0x50, 0x1e, 0x89, 0x15, 0x9f, 0x81, 0x05,
+	0x9a, 0xb4, 0x84, 0xdd, 0xcf, 0x36, 0xc2, 0x15, 0xc1, 0x29, 0x0f, 0x78, 0x98, 0x28, 0xdd, 0xd6,
+	0x54, 0x31, 0x95, 0x99, 0xd7, 0x3b, 0xb4, 0x40, 0xf8, 0x04, 0x1d, 0x0d, 0xb3, 0x28, 0x5b, 0x32,
+	0x15, 0xae, 0xf8, 0x88, 0xc9, 0xf7, 0x92, 0xcf, 0xc0, 0xf0, 0x0e, 0x7d, 0x7c, 0xa1, 0x5b, 0x9d,
+	0x88, 0xb9, 0x3c, 0x5b, 0x8a, 0xb5, 0xf7, 0x37, 0x04, 0x3e, 0x41, 0x8e, 0x06, 0xc4, 0x01, 0x99,
+	0xc8, 0x93, 0x32, 0x4d, 0xc4, 0x9c, 0x42, 0x14, 0xee, 0xa1, 0x83, 0x4a, 0x9f, 0xeb, 0x89, 0xb5,
+	0xe8, 0x36, 0xad, 0x23, 0x87, 0x22, 0x56, 0x29, 0x0b, 0x54, 0x29, 0xa1, 0xd9, 0x9a, 0x6d, 0x5a,
+	0x8f, 0xa5, 0x7c, 0x43, 0xc3, 0x0c, 0xb9, 0xd2, 0xf9, 0x66, 0xc8, 0x4d, 0x63, 0xb3, 0xdf, 0x2e,
+	0x7c, 0xeb, 0xb1, 0xac, 0x7f, 0xb3, 0x5e, 0xe5, 0x9f, 0x83, 0xd9, 0x2e, 0x38, 0x17, 0xeb, 0xda,
+	0x5e, 0xaf, 0x6b, 0xb9, 0x42, 0x7b, 0x9b, 0x15, 0xea, 0x8e, 0x91, 0x5b, 0x71, 0xf1, 0x1f, 0x1c,
+	0xeb, 0x21, 0x77, 0xaa, 0x44, 0xca, 0xe6, 0xfc, 0x2d, 0xbf, 0x2d, 0x6d, 0x5b, 0xa5, 0xce, 0x5e,
+	0x7f, 0xb9, 0xef, 0x58, 0x77, 0xf7, 0x1d, 0xeb, 0xdb, 0x7d, 0xc7, 0xfa, 0xf4, 0xd0, 0xd9, 0xb9,
+	0x7b, 0xe8, 0xec, 0x7c, 0x7d, 0xe8, 0xec, 0x7c, 0x78, 0x3e, 0x0f, 0xd5, 0x22, 0xbb, 0xee, 0x07,
+	0x22, 0x1a, 0x88, 0x1b, 0x9e, 0x0f, 0x78, 0x1e, 0xe8, 0x45, 0x1d, 0xe4, 0x03, 0xbe, 0x8a, 0x06,
+	0x85, 0x5a, 0x03, 0x50, 0xeb, 0xba, 0x0e, 0x3f, 0x2f, 0x7f, 0x0e, 0x00, 0x08, 0x18, 0x36, 0xaa,
+	0x61, 0x05, 0x00, 0x00,
 }
 
 func (m *Transaction) Marshal() (dAtA []byte, err error) {
@@ -478,6 +585,54 @@ func (m *Transaction) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AccessList) > 0 {
+		for iNdEx := len(m.AccessList) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccessList[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
+		}
+	}
+	if len(m.GasFeeCap) > 0 {
+		i -= len(m.GasFeeCap)
+		copy(dAtA[i:], m.GasFeeCap)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.GasFeeCap)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if len(m.GasTipCap) > 0 {
+		i -= len(m.GasTipCap)
+		copy(dAtA[i:], m.GasTipCap)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.GasTipCap)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x78
+	}
 	if len(m.S) > 0 {
 		i -= len(m.S)
 		copy(dAtA[i:], m.S)
@@ -678,6 +833,11 @@ func (m *TransactionReceipt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x68
+	}
 	if len(m.To) > 0 {
 		i -= len(m.To)
 		copy(dAtA[i:], m.To)
@@ -762,6 +922,45 @@ func (m *TransactionReceipt) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *AccessTuple) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessTuple) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessTuple) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StorageKeys) > 0 {
+		for iNdEx := len(m.StorageKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.StorageKeys[iNdEx])
+			copy(dAtA[i:], m.StorageKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.StorageKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -832,6 +1031,27 @@ func (m *Transaction) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	l = len(m.GasTipCap)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	l = len(m.GasFeeCap)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	if len(m.AccessList) > 0 {
+		for _, e := range m.AccessList {
+			l = e.Size()
+			n += 2 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -929,6 +1149,28 @@ func (m *TransactionReceipt) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	return n
+}
+
+func (m *AccessTuple) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.StorageKeys) > 0 {
+		for _, b := range m.StorageKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1386,6 +1628,155 @@ func (m *Transaction) Unmarshal(dAtA []byte) error {
 			}
 			m.S = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasTipCap", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GasTipCap = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasFeeCap", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GasFeeCap = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessList", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccessList = append(m.AccessList, &AccessTuple{})
+			if err := m.AccessList[len(m.AccessList)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2058,6 +2449,141 @@ func (m *TransactionReceipt) Unmarshal(dAtA []byte) error {
 				m.To = []byte{}
 			}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccessTuple) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessTuple: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessTuple: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageKeys = append(m.StorageKeys, make([]byte, postIndex-iNdEx))
+			copy(m.StorageKeys[len(m.StorageKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12,7 +12,7 @@ func transactionToProto(tr *Transaction) *prototypes.Transaction {
 	if tr.To != nil {
 		to = tr.To.Bytes()
 	}
-	return &prototypes.Transaction{
+	protoTx := &prototypes.Transaction{
 		BlockHash:        tr.BlockHash.Bytes(),
 		BlockNumber:      tr.BlockNumber.String(),
 		From:             tr.From.Bytes(),
@@ -27,7 +27,31 @@ func transactionToProto(tr *Transaction) *prototypes.Transaction {
 		V:                tr.V.String(),
 		R:                tr.R.String(),
 		S:                tr.S.String(),
+		Type:             uint64(tr.Type),
 	}
+	if tr.ChainID != nil {
+		protoTx.ChainID = tr.ChainID.String()
+	}
+	if tr.GasTipCap != nil {
+		protoTx.GasTipCap = tr.GasTipCap.String()
+	}
+	if tr.GasFeeCap != nil {
+		protoTx.GasFeeCap = tr.GasFeeCap.String()
+	}
+	if tr.Accesses != nil {
+		protoTx.AccessList = make([]*prototypes.AccessTuple, len(*tr.Accesses))
+		for i, tuple := range *tr.Accesses {
+			keys := make([][]byte, len(tuple.StorageKeys))
+			for j, key := range tuple.StorageKeys {
+				keys[j] = key.Bytes()
+			}
+			protoTx.AccessList[i] = &prototypes.AccessTuple{
+				Address:     tuple.Address.Bytes(),
+				StorageKeys: keys,
+			}
+		}
+	}
+	return protoTx
 }
 
 func protoToTransaction(tr *prototypes.Transaction) *Transaction {
@@ -44,7 +68,7 @@ func protoToTransaction(tr *prototypes.Transaction) *Transaction {
 	v := hexutil.MustDecodeBig(tr.V)
 	r := hexutil.MustDecodeBig(tr.R)
 	s := hexutil.MustDecodeBig(tr.S)
-	return &Transaction{
+	rpcTx := &Transaction{
 		BlockHash:        &blockHash,
 		BlockNumber:      (*hexutil.Big)(blockNum),
 		From:             common.BytesToAddress(tr.From),
@@ -59,27 +83,74 @@ func protoToTransaction(tr *prototypes.Transaction) *Transaction {
 		V:                (*hexutil.Big)(v),
 		R:                (*hexutil.Big)(r),
 		S:                (*hexutil.Big)(s),
+		Type:             hexutil.Uint64(tr.Type),
+	}
+	if tr.ChainID != "" {
+		rpcTx.ChainID = (*hexutil.Big)(hexutil.MustDecodeBig(tr.ChainID))
+	}
+	if tr.GasTipCap != "" {
+		rpcTx.GasTipCap = (*hexutil.Big)(hexutil.MustDecodeBig(tr.GasTipCap))
+	}
+	if tr.GasFeeCap != "" {
+		rpcTx.GasFeeCap = (*hexutil.Big)(hexutil.MustDecodeBig(tr.GasFeeCap))
+	}
+	if tr.Type != uint64(ethtypes.LegacyTxType) {
+		accesses := make(ethtypes.AccessList, len(tr.AccessList))
+		for i, tuple := range tr.AccessList {
+			keys := make([]common.Hash, len(tuple.StorageKeys))
+			for j, key := range tuple.StorageKeys {
+				keys[j] = common.BytesToHash(key)
+			}
+			accesses[i] = ethtypes.AccessTuple{
+				Address:     common.BytesToAddress(tuple.Address),
+				StorageKeys: keys,
+			}
+		}
+		rpcTx.Accesses = &accesses
+	}
+	return rpcTx
+}
+
+func logToProto(log *ethtypes.Log) *prototypes.Log {
+	topics := make([][]byte, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = topic.Bytes()
+	}
+	return &prototypes.Log{
+		Address:     log.Address.Bytes(),
+		Topics:      topics,
+		Data:        log.Data,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash.Bytes(),
+		TxIndex:     uint64(log.TxIndex),
+		BlockHash:   log.BlockHash.Bytes(),
+		Index:       uint64(log.Index),
+		Removed:     log.Removed,
+	}
+}
+
+func protoToLog(log *prototypes.Log) *ethtypes.Log {
+	topics := make([]common.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = common.BytesToHash(topic)
+	}
+	return &ethtypes.Log{
+		Address:     common.BytesToAddress(log.Address),
+		Topics:      topics,
+		Data:        log.Data,
+		BlockNumber: log.BlockNumber,
+		TxHash:      common.BytesToHash(log.TxHash),
+		TxIndex:     uint(log.TxIndex),
+		BlockHash:   common.BytesToHash(log.BlockHash),
+		Index:       uint(log.Index),
+		Removed:     log.Removed,
 	}
 }
 
 func receiptToProto(tr *TransactionReceipt) *prototypes.TransactionReceipt {
 	logs := make([]*prototypes.Log, len(tr.Logs))
 	for i, log := range tr.Logs {
-		topics := make([][]byte, len(log.Topics))
-		for j, topic := range log.Topics {
-			topics[j] = topic.Bytes()
-		}
-		logs[i] = &prototypes.Log{
-			Address:     log.Address.Bytes(),
-			Topics:      topics,
-			Data:        log.Data,
-			BlockNumber: log.BlockNumber,
-			TxHash:      log.TxHash.Bytes(),
-			TxIndex:     uint64(log.TxIndex),
-			BlockHash:   log.BlockHash.Bytes(),
-			Index:       uint64(log.Index),
-			Removed:     log.Removed,
-		}
+		logs[i] = logToProto(log)
 	}
 	var contractAddr []byte
 	if tr.ContractAddress != nil {
@@ -102,27 +173,14 @@ func receiptToProto(tr *TransactionReceipt) *prototypes.TransactionReceipt {
 		TransactionIndex:  uint64(tr.TransactionIndex),
 		From:              tr.From,
 		To:                to,
+		Type:              uint64(tr.Type),
 	}
 }
 
 func protoToReceipt(tr *prototypes.TransactionReceipt) *TransactionReceipt {
 	logs := make([]*ethtypes.Log, len(tr.Logs))
 	for i, log := range tr.Logs {
-		topics := make([]common.Hash, len(log.Topics))
-		for j, topic := range log.Topics {
-			topics[j] = common.BytesToHash(topic)
-		}
-		logs[i] = &ethtypes.Log{
-			Address:     common.BytesToAddress(log.Address),
-			Topics:      topics,
-			Data:        log.Data,
-			BlockNumber: log.BlockNumber,
-			TxHash:      common.BytesToHash(log.TxHash),
-			TxIndex:     uint(log.TxIndex),
-			BlockHash:   common.BytesToHash(log.BlockHash),
-			Index:       uint(log.Index),
-			Removed:     log.Removed,
-		}
+		logs[i] = protoToLog(log)
 	}
 	var contractAddr *common.Address
 	if len(tr.ContractAddress) > 0 {
@@ -147,5 +205,6 @@ func protoToReceipt(tr *prototypes.TransactionReceipt) *TransactionReceipt {
 		TransactionIndex:  hexutil.Uint64(tr.TransactionIndex),
 		From:              tr.From,
 		To:                to,
+		Type:              hexutil.Uint64(tr.Type),
 	}
 }
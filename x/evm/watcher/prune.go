@@ -0,0 +1,273 @@
+package watcher
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	prototypes "github.com/okex/exchain/x/evm/watcher/proto"
+)
+
+const (
+	FlagRetainBlocks = "fast-query-retain-blocks"
+
+	// MinRetainBlocks keeps the hashes of the blocks BLOCKHASH reads in the simulations of eth_call
+	MinRetainBlocks = 256
+)
+
+var keyPruneBase = prefixPruneBase
+
+// GetPruneBase returns the first height kept in the watch db, and false if the watch db was never
+// pruned.
+func GetPruneBase(db dbm.DB) (uint64, bool) {
+	bz, err := db.Get(keyPruneBase)
+	if err != nil || len(bz) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(bz), true
+}
+
+func setPruneBase(batch dbm.Batch, height uint64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, height)
+	batch.Set(keyPruneBase, bz)
+}
+
+// GetLatestHeight returns the height of the last block written to the watch db.
+func GetLatestHeight(db dbm.DB) (uint64, bool) {
+	bz, err := db.Get(keyLatestBlockHeight)
+	if err != nil || bz == nil {
+		return 0, false
+	}
+	height, err := strconv.ParseUint(string(bz), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return height, true
+}
+
+// lowestBlockHeight returns the lowest height of the blocks of the watch db, the block infos are
+// keyed by the decimal heights so they are all scanned.
+func lowestBlockHeight(db dbm.DB) (uint64, bool, error) {
+	it, err := db.Iterator(prefixBlockInfo, prefixEnd(prefixBlockInfo))
+	if err != nil {
+		return 0, false, err
+	}
+	defer it.Close()
+
+	var lowest uint64
+	found := false
+	for ; it.Valid(); it.Next() {
+		height, err := strconv.ParseUint(string(it.Key()[len(prefixBlockInfo):]), 10, 64)
+		if err != nil {
+			continue
+		}
+		if !found || height < lowest {
+			lowest, found = height, true
+		}
+	}
+	return lowest, found, nil
+}
+
+// PruneBlocks deletes the blocks lower than retainHeight from the watch db, with their
+// transactions, receipts, transaction responses and logs. The accounts, the storage, the code and
+// the params are kept. It returns the number of heights pruned.
+func PruneBlocks(db dbm.DB, retainHeight uint64) (uint64, error) {
+	return pruneBlocks(db, retainHeight, nil)
+}
+
+// pruneBlocks prunes the heights one by one, each in a batch so that the queries never see a block
+// without its transactions, and returns when quit is closed.
+func pruneBlocks(db dbm.DB, retainHeight uint64, quit <-chan struct{}) (uint64, error) {
+	base, ok := GetPruneBase(db)
+	if !ok {
+		lowest, found, err := lowestBlockHeight(db)
+		if err != nil || !found {
+			return 0, err
+		}
+		base = lowest
+	}
+
+	var pruned uint64
+	for height := base; height < retainHeight; height++ {
+		select {
+		case <-quit:
+			return pruned, nil
+		default:
+		}
+		if err := pruneHeight(db, height); err != nil {
+			return pruned, fmt.Errorf("failed to prune height %d: %w", height, err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+func pruneHeight(db dbm.DB, height uint64) error {
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	blockInfoKey := append(prefixBlockInfo, strconv.FormatUint(height, 10)...)
+	hash, err := db.Get(blockInfoKey)
+	if err != nil {
+		return err
+	}
+	if hash != nil {
+		blockHash := common.HexToHash(string(hash))
+		if err := pruneBlock(db, batch, blockHash); err != nil {
+			return err
+		}
+		batch.Delete(blockInfoKey)
+	}
+	if err := pruneLogs(db, batch, height); err != nil {
+		return err
+	}
+
+	// the log index no longer covers the height
+	if base, ok := getLogIndexBase(db); ok && base <= height {
+		msg := NewMsgLogIndexBase(height + 1)
+		batch.Set(msg.GetKey(), []byte(msg.GetValue()))
+	}
+	setPruneBase(batch, height+1)
+	return batch.Write()
+}
+
+func pruneBlock(db dbm.DB, batch dbm.Batch, blockHash common.Hash) error {
+	blockKey := append(prefixBlock, blockHash.Bytes()...)
+	bz, err := db.Get(blockKey)
+	if err != nil {
+		return err
+	}
+	if bz != nil {
+		var block struct {
+			Transactions []common.Hash `json:"transactions"`
+		}
+		if err := json.Unmarshal(bz, &block); err != nil {
+			return err
+		}
+		for _, txHash := range block.Transactions {
+			batch.Delete(append(prefixTx, txHash.Bytes()...))
+			batch.Delete(append(prefixReceipt, txHash.Bytes()...))
+			batch.Delete(append(prefixTxResponse, txHash.Bytes()...))
+		}
+		batch.Delete(blockKey)
+	}
+
+	stdTxHashKey := append(prefixStdTxHash, blockHash.Bytes()...)
+	bz, err = db.Get(stdTxHashKey)
+	if err != nil {
+		return err
+	}
+	if bz != nil {
+		var stdTxHashes []common.Hash
+		if err := json.Unmarshal(bz, &stdTxHashes); err != nil {
+			return err
+		}
+		for _, txHash := range stdTxHashes {
+			batch.Delete(append(prefixTxResponse, txHash.Bytes()...))
+		}
+		batch.Delete(stdTxHashKey)
+	}
+	return nil
+}
+
+// pruneLogs deletes the logs of the log index at the height, with their index entries.
+func pruneLogs(db dbm.DB, batch dbm.Batch, height uint64) error {
+	it, err := db.Iterator(getLogKey(LogPosition{Height: height}), getLogKey(LogPosition{Height: height + 1}))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		pos, err := LogPositionFromBytes(it.Key()[len(prefixLog):])
+		if err != nil {
+			return err
+		}
+		var protoLog prototypes.Log
+		if err := proto.Unmarshal(it.Value(), &protoLog); err != nil {
+			return err
+		}
+		for _, entry := range logIndexEntries(pos, protoToLog(&protoLog)) {
+			batch.Delete(entry)
+		}
+		batch.Delete(append([]byte{}, it.Key()...))
+	}
+	return nil
+}
+
+// startPruner keeps the last FlagRetainBlocks blocks in the watch db, the older ones are pruned
+// in the background after the blocks are written.
+func (w *Watcher) startPruner() {
+	retain := viper.GetUint64(FlagRetainBlocks)
+	if !w.Enabled() || retain == 0 {
+		return
+	}
+	if w.log == nil {
+		w.log = log.NewNopLogger()
+	}
+	if retain < MinRetainBlocks {
+		w.log.Info("watchDB retain blocks raised to the minimum", "retain", retain, "min", MinRetainBlocks)
+		retain = MinRetainBlocks
+	}
+	w.retainBlocks = retain
+	w.pruneSignal = make(chan struct{}, 1)
+	w.pruneQuit = make(chan struct{})
+	w.pruneDone = make(chan struct{})
+	if base, ok := GetPruneBase(w.store.db); ok {
+		w.metrics.PruneBase.Set(float64(base))
+	}
+	go w.pruneRoutine()
+}
+
+func (w *Watcher) stopPruner() {
+	if w.pruneQuit == nil {
+		return
+	}
+	close(w.pruneQuit)
+	<-w.pruneDone
+}
+
+// notifyPruner wakes the pruner up without waiting for it.
+func (w *Watcher) notifyPruner() {
+	if w.pruneSignal == nil {
+		return
+	}
+	select {
+	case w.pruneSignal <- struct{}{}:
+	default:
+	}
+}
+
+func (w *Watcher) pruneRoutine() {
+	defer close(w.pruneDone)
+	for {
+		select {
+		case <-w.pruneQuit:
+			return
+		case <-w.pruneSignal:
+		}
+
+		latest, ok := GetLatestHeight(w.store.db)
+		if !ok || latest < w.retainBlocks {
+			continue
+		}
+		retainHeight := latest - w.retainBlocks + 1
+		pruned, err := pruneBlocks(w.store.db, retainHeight, w.pruneQuit)
+		if err != nil {
+			w.log.Error("failed to prune watchDB", "retain height", retainHeight, "err", err)
+		}
+		if pruned > 0 {
+			w.log.Debug("watchDB pruned", "heights", pruned, "retain height", retainHeight)
+			if base, ok := GetPruneBase(w.store.db); ok {
+				w.metrics.PruneBase.Set(float64(base))
+			}
+		}
+	}
+}
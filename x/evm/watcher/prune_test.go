@@ -0,0 +1,102 @@
+package watcher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/okex/exchain/libs/tm-db"
+)
+
+func saveTestBlock(t *testing.T, db dbm.DB, height uint64, txs []common.Hash, stdTxs []common.Hash) {
+	blockHash := common.BigToHash(common.Big1).Bytes()
+	blockHash[0] = byte(height)
+	hash := common.BytesToHash(blockHash)
+
+	msgs := []WatchMessage{
+		NewMsgBlock(Block{Number: 0, Hash: hash, Transactions: txs}),
+		NewMsgBlockInfo(height, hash),
+		NewMsgLatestHeight(height),
+	}
+	if len(stdTxs) > 0 {
+		msgs = append(msgs, NewMsgBlockStdTxHash(stdTxs, hash))
+	}
+	for _, msg := range msgs {
+		require.NoError(t, db.Set(msg.GetKey(), []byte(msg.GetValue())))
+	}
+	for _, tx := range txs {
+		require.NoError(t, db.Set(append(prefixTx, tx.Bytes()...), []byte{1}))
+		require.NoError(t, db.Set(append(prefixReceipt, tx.Bytes()...), []byte{1}))
+	}
+	for _, tx := range stdTxs {
+		require.NoError(t, db.Set(append(prefixTxResponse, tx.Bytes()...), []byte{1}))
+	}
+}
+
+func TestPruneBlocks(t *testing.T) {
+	q, db := newLogIndexQuerier(t)
+	for height := uint64(5); height <= 10; height++ {
+		txHash := common.BigToHash(new(big.Int).SetUint64(height))
+		stdTxHash := common.BigToHash(new(big.Int).SetUint64(height + 100))
+		saveTestBlock(t, db, height, []common.Hash{txHash}, []common.Hash{stdTxHash})
+		saveTestLogs(db, height, newTestLog(height, 0, 0, logAddr1, logTopicA))
+	}
+	require.NoError(t, db.Set(NewMsgLogIndexBase(5).GetKey(), []byte(NewMsgLogIndexBase(5).GetValue())))
+	account := GetMsgAccountKey(logAddr1.Bytes())
+	require.NoError(t, db.Set(account, []byte{1}))
+
+	_, ok := GetPruneBase(db)
+	require.False(t, ok)
+	pruned, err := PruneBlocks(db, 8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), pruned)
+	base, ok := GetPruneBase(db)
+	require.True(t, ok)
+	require.Equal(t, uint64(8), base)
+
+	for height := uint64(5); height <= 10; height++ {
+		kept := height >= 8
+		txHash := common.BigToHash(new(big.Int).SetUint64(height))
+		stdTxHash := common.BigToHash(new(big.Int).SetUint64(height + 100))
+		_, err := q.GetBlockByNumber(height, false)
+		require.Equal(t, kept, err == nil, "height %d", height)
+		for _, key := range [][]byte{
+			append(prefixTx, txHash.Bytes()...),
+			append(prefixReceipt, txHash.Bytes()...),
+			append(prefixTxResponse, stdTxHash.Bytes()...),
+			getLogKey(LogPosition{Height: height}),
+		} {
+			has, err := db.Has(key)
+			require.NoError(t, err)
+			require.Equal(t, kept, has, "height %d key %x", height, key)
+		}
+	}
+	has, err := db.Has(account)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// the log index covers the heights kept
+	logBase, _ := q.LogIndexBase()
+	require.Equal(t, uint64(8), logBase)
+	it, err := db.Iterator(prefixLogIndex, prefixEnd(prefixLogIndex))
+	require.NoError(t, err)
+	entries := 0
+	for ; it.Valid(); it.Next() {
+		entries++
+	}
+	it.Close()
+	require.Equal(t, 3*2, entries)
+
+	// pruning resumes from the base
+	pruned, err = PruneBlocks(db, 8)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), pruned)
+	pruned, err = PruneBlocks(db, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), pruned)
+	latest, ok := GetLatestHeight(db)
+	require.True(t, ok)
+	require.Equal(t, uint64(10), latest)
+}
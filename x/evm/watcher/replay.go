@@ -0,0 +1,102 @@
+package watcher
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tm "github.com/okex/exchain/libs/tendermint/abci/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/evm/types"
+)
+
+// ReplayBlock rebuilds the evm block, transactions, receipts and deployed contract codes of a committed block
+// from the block store and its DeliverTx responses, they are the same as the ones saved by the watcher while the block is executed
+func ReplayBlock(block *tmtypes.Block, deliverTxs []*tm.ResponseDeliverTx, txDecoder sdk.TxDecoder) (Block, []Transaction, []TransactionReceipt, map[string][]byte) {
+	height := uint64(block.Height)
+	blockHash := common.BytesToHash(block.Hash())
+
+	var (
+		txs           []Transaction
+		receipts      []TransactionReceipt
+		blockTxs      = []common.Hash{}
+		bloom         ethtypes.Bloom
+		cumulativeGas uint64
+		evmTxIndex    uint64
+		contractCodes = make(map[string][]byte)
+	)
+	for i, txBytes := range block.Txs {
+		var resp *tm.ResponseDeliverTx
+		if i < len(deliverTxs) {
+			resp = deliverTxs[i]
+		}
+		realTx, err := txDecoder(txBytes, block.Height)
+		if err != nil || realTx.GetType() != sdk.EvmTxType || len(realTx.GetMsgs()) == 0 {
+			continue
+		}
+		evmTx, ok := realTx.GetMsgs()[0].(*types.MsgEthereumTx)
+		if !ok {
+			continue
+		}
+		watchTx := NewEvmTx(evmTx, common.BytesToHash(evmTx.TxHash()), blockHash, height, evmTxIndex)
+		evmTxIndex++
+		if ethTx := watchTx.GetTransaction(); ethTx != nil {
+			txs = append(txs, *ethTx)
+		}
+		blockTxs = append(blockTxs, watchTx.GetTxHash())
+		if resp == nil {
+			continue
+		}
+
+		gasUsed := uint64(resp.GasUsed)
+		cumulativeGas += gasUsed
+		if !resp.IsOK() {
+			receipts = append(receipts, *watchTx.GetFailedReceipts(cumulativeGas, gasUsed))
+			continue
+		}
+		resultData := &types.ResultData{}
+		// the evm2cm txs have no result data of evm
+		if isEvmResponse(resp) {
+			data, err := types.DecodeResultData(resp.Data)
+			if err == nil {
+				resultData = &data
+				for j := range bloom {
+					bloom[j] |= data.Bloom[j]
+				}
+			}
+		}
+		receipts = append(receipts, newTransactionReceipt(TransactionSuccess, evmTx, watchTx.GetTxHash(), blockHash,
+			watchTx.GetIndex(), height, resultData, cumulativeGas, gasUsed))
+		if addr, code, ok := deployedContractCode(evmTx, resultData); ok {
+			contractCodes[addr] = code
+		}
+	}
+
+	header := tmtypes.TM2PB.Header(&block.Header)
+	evmBlock := newBlock(height, bloom, blockHash, header, uint64(0xffffffff), new(big.Int).SetUint64(cumulativeGas), blockTxs)
+	return evmBlock, txs, receipts, contractCodes
+}
+
+// deployedContractCode returns the address and the runtime code of the contract created by the successful tx,
+// the runtime code is the return data of the contract creation
+func deployedContractCode(msg *types.MsgEthereumTx, data *types.ResultData) (string, []byte, bool) {
+	if msg.Data.Recipient != nil || data == nil || data.ContractAddress == (common.Address{}) || len(data.Ret) == 0 {
+		return "", nil, false
+	}
+	return data.ContractAddress.String(), data.Ret, true
+}
+
+func isEvmResponse(resp *tm.ResponseDeliverTx) bool {
+	for _, ev := range resp.Events {
+		if ev.Type == sdk.EventTypeMessage {
+			for _, attr := range ev.Attributes {
+				if string(attr.Key) == sdk.AttributeKeyModule &&
+					string(attr.Value) == types.AttributeValueCategory {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -65,17 +65,7 @@ func (w *Watcher) RecordTxAndFailedReceipt(tx tm.TxEssentials, resp *tm.Response
 }
 
 func (w *Watcher) IsRealEvmTx(resp *tm.ResponseDeliverTx) bool {
-	for _, ev := range resp.Events {
-		if ev.Type == sdk.EventTypeMessage {
-			for _, attr := range ev.Attributes {
-				if string(attr.Key) == sdk.AttributeKeyModule &&
-					string(attr.Value) == types.AttributeValueCategory {
-					return true
-				}
-			}
-		}
-	}
-	return false
+	return isEvmResponse(resp)
 }
 
 func (w *Watcher) getRealTx(tx tm.TxEssentials, txDecoder sdk.TxDecoder) (sdk.Tx, error) {
@@ -43,6 +43,10 @@ var (
 	prefixRpcDb        = []byte{0x13}
 	prefixTxResponse   = []byte{0x14}
 	prefixStdTxHash    = []byte{0x15}
+	prefixLog          = []byte{0x16}
+	prefixLogIndex     = []byte{0x17}
+	prefixLogIndexBase = []byte{0x18}
+	prefixPruneBase    = []byte{0x19}
 
 	KeyLatestHeight = "LatestHeight"
 
@@ -485,6 +489,7 @@ type TransactionReceipt struct {
 	tx                    *types.MsgEthereumTx
 	From                  string          `json:"from"`
 	To                    *common.Address `json:"to"`
+	Type                  hexutil.Uint64  `json:"type"`
 }
 
 func (tr *TransactionReceipt) GetValue() string {
@@ -534,6 +539,7 @@ func newTransactionReceipt(status uint32, tx *types.MsgEthereumTx, txHash, block
 		originBlockHash:       blockHash,
 		BlockNumber:           hexutil.Uint64(height),
 		TransactionIndex:      hexutil.Uint64(txIndex),
+		Type:                  hexutil.Uint64(tx.Data.Type),
 		tx:                    tx,
 	}
 	return tr
@@ -609,26 +615,46 @@ type Block struct {
 
 // Transaction represents a transaction returned to RPC clients.
 type Transaction struct {
-	BlockHash         *common.Hash    `json:"blockHash"`
-	BlockNumber       *hexutil.Big    `json:"blockNumber"`
-	From              common.Address  `json:"from"`
-	Gas               hexutil.Uint64  `json:"gas"`
-	GasPrice          *hexutil.Big    `json:"gasPrice"`
-	Hash              common.Hash     `json:"hash"`
-	Input             hexutil.Bytes   `json:"input"`
-	Nonce             hexutil.Uint64  `json:"nonce"`
-	To                *common.Address `json:"to"`
-	TransactionIndex  *hexutil.Uint64 `json:"transactionIndex"`
-	Value             *hexutil.Big    `json:"value"`
-	V                 *hexutil.Big    `json:"v"`
-	R                 *hexutil.Big    `json:"r"`
-	S                 *hexutil.Big    `json:"s"`
+	BlockHash         *common.Hash         `json:"blockHash"`
+	BlockNumber       *hexutil.Big         `json:"blockNumber"`
+	From              common.Address       `json:"from"`
+	Gas               hexutil.Uint64       `json:"gas"`
+	GasPrice          *hexutil.Big         `json:"gasPrice"`
+	Hash              common.Hash          `json:"hash"`
+	Input             hexutil.Bytes        `json:"input"`
+	Nonce             hexutil.Uint64       `json:"nonce"`
+	To                *common.Address      `json:"to"`
+	TransactionIndex  *hexutil.Uint64      `json:"transactionIndex"`
+	Value             *hexutil.Big         `json:"value"`
+	V                 *hexutil.Big         `json:"v"`
+	R                 *hexutil.Big         `json:"r"`
+	S                 *hexutil.Big         `json:"s"`
+	Type              hexutil.Uint64       `json:"type"`
+	ChainID           *hexutil.Big         `json:"chainId,omitempty"`
+	GasTipCap         *hexutil.Big         `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap         *hexutil.Big         `json:"maxFeePerGas,omitempty"`
+	Accesses          *ethtypes.AccessList `json:"accessList,omitempty"`
 	tx                *types.MsgEthereumTx
 	originBlockHash   *common.Hash
 	originBlockNumber uint64
 	originIndex       uint64
 }
 
+// SetTypedTxFields sets the type of the transaction and the fields only carried by the typed transactions
+func (tr *Transaction) SetTypedTxFields(tx *types.MsgEthereumTx) {
+	tr.Type = hexutil.Uint64(tx.Data.Type)
+	if !tx.IsTypedTx() {
+		return
+	}
+	tr.ChainID = (*hexutil.Big)(tx.Data.ChainID)
+	accesses := tx.AccessList()
+	tr.Accesses = &accesses
+	if tx.Data.Type == ethtypes.DynamicFeeTxType {
+		tr.GasTipCap = (*hexutil.Big)(tx.Data.GasTipCap)
+		tr.GasFeeCap = (*hexutil.Big)(tx.Data.GasFeeCap)
+	}
+}
+
 func (tr *Transaction) GetValue() string {
 	// Verify signature and retrieve sender address
 	err := tr.tx.VerifySig(tr.tx.ChainID(), int64(tr.originBlockNumber))
@@ -646,6 +672,7 @@ func (tr *Transaction) GetValue() string {
 	tr.V = (*hexutil.Big)(tr.tx.Data.V)
 	tr.R = (*hexutil.Big)(tr.tx.Data.R)
 	tr.S = (*hexutil.Big)(tr.tx.Data.S)
+	tr.SetTypedTxFields(tr.tx)
 
 	if *tr.originBlockHash != (common.Hash{}) {
 		tr.BlockHash = tr.originBlockHash
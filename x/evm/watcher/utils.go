@@ -37,6 +37,7 @@ func NewTransaction(tx *evmtypes.MsgEthereumTx, txHash, blockHash common.Hash, b
 		R:        (*hexutil.Big)(tx.Data.R),
 		S:        (*hexutil.Big)(tx.Data.S),
 	}
+	rpcTx.SetTypedTxFields(tx)
 
 	if blockHash != (common.Hash{}) {
 		rpcTx.BlockHash = &blockHash
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -18,6 +20,7 @@ import (
 	ctypes "github.com/okex/exchain/libs/tendermint/rpc/core/types"
 	tmstate "github.com/okex/exchain/libs/tendermint/state"
 	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/common/monitor"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/spf13/viper"
 	"github.com/tendermint/go-amino"
@@ -51,6 +54,19 @@ type Watcher struct {
 	filterMap     map[string]struct{}
 	InfuraKeeper  InfuraKeeper
 	delAccountMtx sync.Mutex
+	// the base of the log index is saved with the first logs indexed
+	logIndexBaseSaved bool
+	metrics           *monitor.WatcherMetrics
+	// the heights of the last blocks committed and written to the watch db
+	committedHeight uint64
+	writtenHeight   uint64
+	checker         *ConsistencyChecker
+	checkInterval   int64
+	// the pruning of the blocks older than the last retainBlocks blocks
+	retainBlocks uint64
+	pruneSignal  chan struct{}
+	pruneQuit    chan struct{}
+	pruneDone    chan struct{}
 }
 
 var (
@@ -74,7 +90,11 @@ func GetWatchLruSize() int {
 	return watcherLruSize
 }
 
+// NewWatcher returns a Watcher, a nil logger discards the logs.
 func NewWatcher(logger log.Logger) *Watcher {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
 	return &Watcher{store: InstanceOfWatchStore(),
 		cumulativeGas:  make(map[uint64]uint64),
 		enable:         IsWatcherEnabled(),
@@ -85,6 +105,7 @@ func NewWatcher(logger log.Logger) *Watcher {
 		checkWd:        viper.GetBool(FlagCheckWd),
 		filterMap:      make(map[string]struct{}),
 		eraseKeyFilter: make(map[string][]byte),
+		metrics:        monitor.GetWatcherMetrics(),
 	}
 }
 
@@ -140,11 +161,15 @@ func (w *Watcher) SaveTransactionReceipt(status uint32, msg *evmtypes.MsgEthereu
 	tr := newTransactionReceipt(status, msg, txHash, w.blockHash, txIndex, w.height, data, w.cumulativeGas[txIndex], gasUsed)
 	if w.InfuraKeeper != nil {
 		w.InfuraKeeper.OnSaveTransactionReceipt(tr)
+		if addr, code, ok := deployedContractCode(msg, data); ok && status == TransactionSuccess {
+			w.InfuraKeeper.OnSaveContractCode(addr, code)
+		}
 	}
 	wMsg := NewMsgTransactionReceipt(tr, txHash)
 	if wMsg != nil {
 		w.batch = append(w.batch, wMsg)
 	}
+	w.saveLogIndex(data.Logs)
 }
 
 func (w *Watcher) UpdateCumulativeGas(txIndex, gasUsed uint64) {
@@ -328,13 +353,29 @@ func (w *Watcher) Commit() {
 	}
 	//hold it in temp
 	batch := w.batch
+	height, committed := w.height, time.Now()
+	atomic.StoreUint64(&w.committedHeight, height)
+	w.metrics.CommittedHeight.Set(float64(height))
+	if written := atomic.LoadUint64(&w.writtenHeight); written > 0 {
+		w.metrics.HeightLag.Set(float64(height - written))
+	}
 	// No need to write db when upload delta is enabled.
 	if tmtypes.UploadDelta {
 		return
 	}
 	w.dispatchJob(func() {
 		w.commitBatch(batch)
+		w.onHeightWritten(height, committed)
+		w.notifyPruner()
 	})
+	w.dispatchCheck(height)
+}
+
+func (w *Watcher) onHeightWritten(height uint64, committed time.Time) {
+	atomic.StoreUint64(&w.writtenHeight, height)
+	w.metrics.Height.Set(float64(height))
+	w.metrics.HeightLag.Set(float64(atomic.LoadUint64(&w.committedHeight) - height))
+	w.metrics.CommitLatency.Observe(time.Since(committed).Seconds())
 }
 
 func (w *Watcher) CommitWatchData(data WatchData) {
@@ -478,11 +519,18 @@ func (w *Watcher) ApplyWatchData(watchData interface{}) {
 	if !ok {
 		panic("use watch data failed")
 	}
-	w.dispatchJob(func() { w.CommitWatchData(wd) })
+	// the watch data of the delta is applied after the block is committed
+	height, committed := atomic.LoadUint64(&w.committedHeight), time.Now()
+	w.dispatchJob(func() {
+		w.CommitWatchData(wd)
+		w.onHeightWritten(height, committed)
+		w.notifyPruner()
+	})
 }
 
 func (w *Watcher) SetWatchDataManager() {
 	go w.jobRoutine()
+	w.startPruner()
 	tmstate.SetEvmWatchDataManager(w)
 }
 
@@ -607,6 +655,7 @@ func (w *Watcher) jobRoutine() {
 	w.lazyInitialization()
 	for job := range w.jobChan {
 		job()
+		w.metrics.QueueDepth.Set(float64(len(w.jobChan)))
 	}
 	w.jobDone.Done()
 }
@@ -625,6 +674,7 @@ func (w *Watcher) Stop() {
 	}
 	close(w.jobChan)
 	w.jobDone.Wait()
+	w.stopPruner()
 }
 func (w *Watcher) dispatchJob(f func()) {
 	// if jobRoutine were too slow to write data  to disk
@@ -632,6 +682,7 @@ func (w *Watcher) dispatchJob(f func()) {
 	// why: something wrong happened: such as db panic(disk maybe is full)(it should be the only reason)
 	//								  ApplyWatchData were executed every 4 seoncds(block schedual)
 	w.jobChan <- f
+	w.metrics.QueueDepth.Set(float64(len(w.jobChan)))
 }
 
 func (w *Watcher) Height() uint64 {
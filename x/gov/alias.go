@@ -44,6 +44,7 @@ var (
 	NewMsgSubmitProposal       = types.NewMsgSubmitProposal
 	NewMsgDeposit              = types.NewMsgDeposit
 	NewMsgVote                 = types.NewMsgVote
+	NewMsgVoteWeighted         = types.NewMsgVoteWeighted
 	ParamKeyTable              = types.ParamKeyTable
 	NewDepositParams           = types.NewDepositParams
 	NewTallyParams             = types.NewTallyParams
@@ -52,6 +53,7 @@ var (
 	NewTallyResultFromMap      = types.NewTallyResultFromMap
 	EmptyTallyResult           = types.EmptyTallyResult
 	NewTextProposal            = types.NewTextProposal
+	NewCancelScheduledProposal = types.NewCancelScheduledProposal
 	RegisterProposalType       = types.RegisterProposalType
 	ContentFromProposalType    = types.ContentFromProposalType
 	IsValidProposalType        = types.IsValidProposalType
@@ -86,6 +88,7 @@ type (
 	MsgSubmitProposal = types.MsgSubmitProposal
 	MsgDeposit        = types.MsgDeposit
 	MsgVote           = types.MsgVote
+	MsgVoteWeighted   = types.MsgVoteWeighted
 	DepositParams     = types.DepositParams
 	TallyParams       = types.TallyParams
 	VotingParams      = types.VotingParams
@@ -33,6 +33,7 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	govQueryCmd.AddCommand(flags.GetCommands(
 		GetCmdQueryProposal(queryRoute, cdc),
 		GetCmdQueryProposals(queryRoute, cdc),
+		GetCmdQueryScheduledProposals(queryRoute, cdc),
 		getCmdQueryVote(queryRoute, cdc),
 		getCmdQueryVotes(queryRoute, cdc),
 		GetCmdQueryParam(queryRoute, cdc),
@@ -169,6 +170,38 @@ $ %s query gov proposals --status (DepositPeriod|VotingPeriod|Passed|Rejected)
 	return cmd
 }
 
+// GetCmdQueryScheduledProposals implements the query scheduled proposals command.
+func GetCmdQueryScheduledProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "scheduled-proposals",
+		Args:  cobra.NoArgs,
+		Short: "Query the passed proposals whose execution is pending",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the passed proposals scheduled for execution at a future height or time.
+
+Example:
+$ %s query gov scheduled-proposals
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryScheduledProposals), nil)
+			if err != nil {
+				return err
+			}
+
+			var proposals types.Proposals
+			if err := cdc.UnmarshalJSON(res, &proposals); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(proposals)
+		},
+	}
+}
+
 // GetCmdQueryProposer implements the query proposer command.
 func GetCmdQueryProposer(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/okex/exchain/libs/cosmos-sdk/client"
 	"github.com/okex/exchain/libs/cosmos-sdk/client/context"
@@ -70,6 +71,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec, pcmds []*cobra.Command) *cobra.
 	}
 
 	cmdSubmitProp := getCmdSubmitProposal(cdc)
+	cmdSubmitProp.AddCommand(flags.PostCommands(getCmdSubmitCancelScheduledProposal(cdc))[0])
 	for _, pcmd := range pcmds {
 		cmdSubmitProp.AddCommand(flags.PostCommands(pcmd)[0])
 	}
@@ -77,6 +79,7 @@ func GetTxCmd(storeKey string, cdc *codec.Codec, pcmds []*cobra.Command) *cobra.
 	govTxCmd.AddCommand(flags.PostCommands(
 		getCmdDeposit(cdc),
 		GetCmdVote(cdc),
+		GetCmdWeightedVote(cdc),
 		cmdSubmitProp,
 	)...)
 
@@ -127,8 +130,13 @@ $ %s tx gov submit-proposal --title="Test Proposal" --description="My awesome pr
 				return err
 			}
 
+			execution, err := govutils.ParseExecutionFlags()
+			if err != nil {
+				return err
+			}
+
 			content := types.ContentFromProposalType(proposal.Title, proposal.Description, proposal.Type)
-			msg := types.NewMsgSubmitProposal(content, amount, cliCtx.GetFromAddress())
+			msg := types.NewMsgSubmitProposal(content, amount, cliCtx.GetFromAddress()).WithExecution(execution)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -143,6 +151,58 @@ $ %s tx gov submit-proposal --title="Test Proposal" --description="My awesome pr
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposal, "",
 		"proposal file path (if this path is given, other proposal flags are ignored)")
+	govutils.AddExecutionFlags(cmd)
+
+	return cmd
+}
+
+// getCmdSubmitCancelScheduledProposal implements submitting a proposal cancelling the scheduled execution of
+// a passed proposal transaction command.
+func getCmdSubmitCancelScheduledProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-scheduled-proposal [proposal-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal cancelling the scheduled execution of a proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal cancelling the execution of a proposal scheduled at a future height or time,
+along with an initial deposit. The cancellation takes effect if it passes before the scheduled execution.
+
+Example:
+$ %s tx gov submit-proposal cancel-scheduled-proposal 1 --title="Test Proposal" \
+	--description="Cancel the upgrade of proposal 1" --deposit="10%s" --from mykey
+`,
+				version.ClientName, sdk.DefaultBondDenom,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// validate that the proposal id is a uint
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("proposal-id %s not a valid uint, please input a valid proposal-id", args[0])
+			}
+
+			amount, err := sdk.ParseDecCoins(viper.GetString(flagDeposit))
+			if err != nil {
+				return err
+			}
+
+			content := types.NewCancelScheduledProposal(viper.GetString(flagTitle), viper.GetString(flagDescription),
+				proposalID)
+			msg := types.NewMsgSubmitProposal(content, amount, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagTitle, "", "title of proposal")
+	cmd.Flags().String(flagDescription, "", "description of proposal")
+	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 
 	return cmd
 }
@@ -243,4 +303,54 @@ $ %s tx gov vote 1 yes --from mykey
 	}
 }
 
+// GetCmdWeightedVote implements creating a new weighted vote command.
+func GetCmdWeightedVote(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "weighted-vote [proposal-id] [weighted-options]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Vote for an active proposal splitting the voting power, options: yes/no/no_with_veto/abstain",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a vote for an active proposal splitting the voting power over
+several options, with the weights summing to 1. You can find the proposal-id by
+running "%s query gov proposals".
+
+
+Example:
+$ %s tx gov weighted-vote 1 yes=0.6,no=0.3,abstain=0.1 --from mykey
+`,
+				version.ClientName, version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// Get voting address
+			from := cliCtx.GetFromAddress()
+
+			// validate that the proposal id is a uint
+			proposalID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("proposal-id %s not a valid int, please input a valid proposal-id", args[0])
+			}
+
+			// Find out which vote options user chose
+			options, err := types.WeightedVoteOptionsFromString(govutils.NormalizeWeightedVoteOptions(args[1]))
+			if err != nil {
+				return err
+			}
+
+			// Build vote message and run basic validation
+			msg := types.NewMsgVoteWeighted(from, proposalID, options)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
 // DONTCOVER
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/okex/exchain/x/gov/types"
+)
+
+// Execution flags of a proposal
+const (
+	FlagExecutionHeight = "execution-height"
+	FlagExecutionTime   = "execution-time"
+)
+
+// AddExecutionFlags adds the flags scheduling the execution of a proposal once passed to cmd
+func AddExecutionFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint64(FlagExecutionHeight, 0,
+		"(optional) block height the proposal is executed at once passed")
+	cmd.Flags().String(FlagExecutionTime, "",
+		"(optional) block time the proposal is executed at once passed, in RFC3339 format (e.g. 2006-01-02T15:04:05Z)")
+}
+
+// ParseExecutionFlags returns the execution of a proposal scheduled by the execution flags,
+// nil if the proposal is executed when passed
+func ParseExecutionFlags() (*types.ProposalExecution, error) {
+	height := viper.GetUint64(FlagExecutionHeight)
+	timeStr := viper.GetString(FlagExecutionTime)
+
+	switch {
+	case height != 0 && timeStr != "":
+		return nil, fmt.Errorf("--%s and --%s flags can't be both provided", FlagExecutionHeight, FlagExecutionTime)
+	case height != 0:
+		return types.NewHeightExecution(height), nil
+	case timeStr != "":
+		t, err := time.Parse(time.RFC3339, timeStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s %s: %s", FlagExecutionTime, timeStr, err)
+		}
+		return types.NewTimeExecution(t), nil
+	default:
+		return nil, nil
+	}
+}
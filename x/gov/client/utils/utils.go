@@ -1,6 +1,10 @@
 package utils
 
-import "github.com/okex/exchain/x/gov/types"
+import (
+	"strings"
+
+	"github.com/okex/exchain/x/gov/types"
+)
 
 // NormalizeVoteOption - normalize user specified vote option
 func NormalizeVoteOption(option string) string {
@@ -22,6 +26,17 @@ func NormalizeVoteOption(option string) string {
 	}
 }
 
+// NormalizeWeightedVoteOptions - normalize user specified weighted vote options, eg. "yes=0.6,no=0.4"
+func NormalizeWeightedVoteOptions(options string) string {
+	fields := strings.Split(options, ",")
+	for i, field := range fields {
+		option := strings.Split(strings.TrimSpace(field), "=")
+		option[0] = NormalizeVoteOption(option[0])
+		fields[i] = strings.Join(option, "=")
+	}
+	return strings.Join(fields, ",")
+}
+
 //NormalizeProposalType - normalize user specified proposal type
 func NormalizeProposalType(proposalType string) string {
 	switch proposalType {
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/log"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/gov/types"
 
 	"github.com/okex/exchain/x/common/perf"
@@ -19,6 +20,9 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
 	defer perf.GetPerf().OnEndBlockExit(ctx, types.ModuleName, seq)
 
 	handleWaitingProposals(ctx, k, logger)
+	if tmtypes.HigherThanVenus11(ctx.BlockHeight()) {
+		handleScheduledProposals(ctx, k, logger)
+	}
 	handleInActiveProposals(ctx, k, logger)
 	handleActiveProposals(ctx, k, logger)
 }
@@ -50,6 +54,31 @@ func handleWaitingProposals(ctx sdk.Context, k keeper.Keeper, logger log.Logger)
 	})
 }
 
+// handle passed proposals whose execution is scheduled at the current block height or time
+func handleScheduledProposals(ctx sdk.Context, k keeper.Keeper, logger log.Logger) {
+	k.IterateScheduledProposalsQueue(ctx, uint64(ctx.BlockHeight()), ctx.BlockHeader().Time,
+		func(proposal Proposal) bool {
+			k.RemoveFromScheduledProposalQueue(ctx, proposal)
+			tagValue, logMsg := executeProposal(ctx, k, &proposal)
+			k.SetProposal(ctx, proposal)
+
+			logger.Info(
+				fmt.Sprintf("proposal %d (%s) scheduled at %s executed; result: %s",
+					proposal.ProposalID, proposal.GetTitle(), proposal.Execution, logMsg,
+				),
+			)
+
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeScheduledProposal,
+					sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", proposal.ProposalID)),
+					sdk.NewAttribute(types.AttributeKeyProposalResult, tagValue),
+				),
+			)
+			return false
+		})
+}
+
 func handleInActiveProposals(ctx sdk.Context, k keeper.Keeper, logger log.Logger) {
 	// delete inactive proposal from store and its deposits
 	k.IterateInactiveProposalsQueue(ctx, ctx.BlockHeader().Time, func(proposal Proposal) bool {
@@ -11,6 +11,7 @@ import (
 	"github.com/okex/exchain/x/staking"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -238,3 +239,126 @@ func TestEndBlockerIterateWaitingProposalsQueue(t *testing.T) {
 	require.False(t, waitingQueue.Valid())
 	waitingQueue.Close()
 }
+
+func passProposalByVotes(t *testing.T, ctx sdk.Context, govHandler sdk.Handler, proposalID uint64) {
+	for _, addr := range keeper.Addrs[:3] {
+		_, err := govHandler(ctx, NewMsgVote(addr, proposalID, types.OptionYes))
+		require.Nil(t, err)
+	}
+}
+
+func TestEndBlockerIterateScheduledProposalsQueue(t *testing.T) {
+	ctx, _, gk, sk, _ := keeper.CreateTestInput(t, false, 100000)
+	govHandler := NewHandler(gk)
+
+	ctx.SetBlockHeight(int64(sk.GetEpoch(ctx)))
+	skHandler := staking.NewHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(keeper.Addrs[:4]))
+	for i, addr := range keeper.Addrs[:4] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	keeper.CreateValidators(t, skHandler, ctx, valAddrs, []int64{10, 10, 10, 10})
+	staking.EndBlocker(ctx, sk)
+
+	initialDeposit := sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 150)}
+	content := types.NewTextProposal("Test", "description")
+
+	// the proposals are scheduled since Venus11
+	futureExecution := types.NewHeightExecution(uint64(ctx.BlockHeight() + 1))
+	_, err := govHandler(ctx, NewMsgSubmitProposal(content, initialDeposit, keeper.Addrs[0]).WithExecution(futureExecution))
+	require.NotNil(t, err)
+	tmtypes.InitMilestoneVenus11Height(1)
+	defer tmtypes.InitMilestoneVenus11Height(0)
+
+	// the execution must be in the future
+	pastExecution := types.NewHeightExecution(uint64(ctx.BlockHeight()))
+	_, err = govHandler(ctx, NewMsgSubmitProposal(content, initialDeposit, keeper.Addrs[0]).WithExecution(pastExecution))
+	require.NotNil(t, err)
+
+	height := uint64(ctx.BlockHeight() + 1000)
+	newProposalMsg := NewMsgSubmitProposal(content, initialDeposit, keeper.Addrs[0]).
+		WithExecution(types.NewHeightExecution(height))
+	res, err := govHandler(ctx, newProposalMsg)
+	require.Nil(t, err)
+	var proposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &proposalID)
+
+	passProposalByVotes(t, ctx, govHandler, proposalID)
+	scheduledProposals := gk.GetScheduledProposals(ctx)
+	require.Len(t, scheduledProposals, 1)
+	require.Equal(t, proposalID, scheduledProposals[0].ProposalID)
+	require.Equal(t, StatusPassed, scheduledProposals[0].Status)
+
+	// not executed before the scheduled height
+	ctx.SetBlockHeight(int64(height - 1))
+	EndBlocker(ctx, gk)
+	require.Len(t, gk.GetScheduledProposals(ctx), 1)
+
+	ctx.SetBlockHeight(int64(height))
+	EndBlocker(ctx, gk)
+	require.Len(t, gk.GetScheduledProposals(ctx), 0)
+	proposal, ok := gk.GetProposal(ctx, proposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusPassed, proposal.Status)
+}
+
+func TestEndBlockerCancelScheduledProposal(t *testing.T) {
+	ctx, _, gk, sk, _ := keeper.CreateTestInput(t, false, 100000)
+	govHandler := NewHandler(gk)
+
+	ctx.SetBlockHeight(int64(sk.GetEpoch(ctx)))
+	skHandler := staking.NewHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(keeper.Addrs[:4]))
+	for i, addr := range keeper.Addrs[:4] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	keeper.CreateValidators(t, skHandler, ctx, valAddrs, []int64{10, 10, 10, 10})
+	staking.EndBlocker(ctx, sk)
+
+	tmtypes.InitMilestoneVenus11Height(1)
+	defer tmtypes.InitMilestoneVenus11Height(0)
+
+	initialDeposit := sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 150)}
+	executionTime := ctx.BlockHeader().Time.Add(time.Hour * 24 * 10)
+	newProposalMsg := NewMsgSubmitProposal(types.NewTextProposal("Test", "description"), initialDeposit,
+		keeper.Addrs[0]).WithExecution(types.NewTimeExecution(executionTime))
+	res, err := govHandler(ctx, newProposalMsg)
+	require.Nil(t, err)
+	var scheduledProposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &scheduledProposalID)
+
+	// a proposal without execution can't be cancelled
+	res = newTextProposal(t, ctx, initialDeposit, govHandler)
+	var textProposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &textProposalID)
+	cancelContent := types.NewCancelScheduledProposal("Cancel", "description", textProposalID)
+	_, err = govHandler(ctx, NewMsgSubmitProposal(cancelContent, initialDeposit, keeper.Addrs[0]))
+	require.NotNil(t, err)
+
+	passProposalByVotes(t, ctx, govHandler, scheduledProposalID)
+	require.Len(t, gk.GetScheduledProposals(ctx), 1)
+
+	cancelContent = types.NewCancelScheduledProposal("Cancel", "description", scheduledProposalID)
+	res, err = govHandler(ctx, NewMsgSubmitProposal(cancelContent, initialDeposit, keeper.Addrs[0]))
+	require.Nil(t, err)
+	var cancelProposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &cancelProposalID)
+
+	passProposalByVotes(t, ctx, govHandler, cancelProposalID)
+	require.Len(t, gk.GetScheduledProposals(ctx), 0)
+	proposal, ok := gk.GetProposal(ctx, cancelProposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusPassed, proposal.Status)
+	proposal, ok = gk.GetProposal(ctx, scheduledProposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusFailed, proposal.Status)
+
+	// the cancelled proposal is never executed
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = executionTime
+	ctx.SetBlockHeader(newHeader)
+	EndBlocker(ctx, gk)
+	proposal, ok = gk.GetProposal(ctx, scheduledProposalID)
+	require.True(t, ok)
+	require.Equal(t, StatusFailed, proposal.Status)
+}
@@ -18,6 +18,7 @@ type GenesisState struct {
 	Votes              Votes             `json:"votes" yaml:"votes"`
 	Proposals          []Proposal        `json:"proposals" yaml:"proposals"`
 	WaitingProposals   map[string]uint64 `json:"waiting_proposals" yaml:"waiting_proposals"`
+	ScheduledProposals []uint64          `json:"scheduled_proposals,omitempty" yaml:"scheduled_proposals,omitempty"`
 	DepositParams      DepositParams     `json:"deposit_params" yaml:"deposit_params"`
 	VotingParams       VotingParams      `json:"voting_params" yaml:"voting_params"`
 	TallyParams        TallyParams       `json:"tally_params" yaml:"tally_params"`
@@ -89,6 +90,18 @@ func ValidateGenesis(data GenesisState) error {
 			data.DepositParams.MinDeposit.String())
 	}
 
+	proposals := make(map[uint64]Proposal, len(data.Proposals))
+	for _, proposal := range data.Proposals {
+		proposals[proposal.ProposalID] = proposal
+	}
+	for _, proposalID := range data.ScheduledProposals {
+		proposal, ok := proposals[proposalID]
+		if !ok || proposal.Execution == nil || proposal.Status != StatusPassed {
+			return fmt.Errorf("governance scheduled proposal %d should be a passed proposal with an execution",
+				proposalID)
+		}
+	}
+
 	return nil
 }
 
@@ -133,6 +146,14 @@ func InitGenesis(ctx sdk.Context, k keeper.Keeper, supplyKeeper keeper.SupplyKee
 		k.InsertWaitingProposalQueue(ctx, height, proposalID)
 	}
 
+	for _, proposalID := range data.ScheduledProposals {
+		proposal, ok := k.GetProposal(ctx, proposalID)
+		if !ok {
+			panic(fmt.Sprintf("scheduled proposal %d does not exist", proposalID))
+		}
+		k.InsertScheduledProposalQueue(ctx, proposal)
+	}
+
 	// add coins if not provided on genesis
 	if moduleAcc.GetCoins().IsZero() {
 		if err := moduleAcc.SetCoins(totalDeposits); err != nil {
@@ -170,12 +191,19 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) GenesisState {
 		return false
 	})
 
+	var scheduledProposals []uint64
+	k.IterateAllScheduledProposals(ctx, func(proposal types.Proposal) (stop bool) {
+		scheduledProposals = append(scheduledProposals, proposal.ProposalID)
+		return false
+	})
+
 	return GenesisState{
 		StartingProposalID: startingProposalID,
 		Deposits:           proposalsDeposits,
 		Votes:              proposalsVotes,
 		Proposals:          proposals,
 		WaitingProposals:   waitingProposals,
+		ScheduledProposals: scheduledProposals,
 		DepositParams:      depositParams,
 		VotingParams:       votingParams,
 		TallyParams:        tallyParams,
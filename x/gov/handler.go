@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/gov/keeper"
@@ -24,6 +25,9 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+
+		case MsgVoteWeighted:
+			return handleMsgVoteWeighted(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized gov message type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -37,6 +41,14 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper keeper.Keeper, msg MsgSubmi
 		return sdk.EnvelopedErr{err}.Result()
 	}
 
+	// the proposals are scheduled and cancelled since Venus11
+	if !tmtypes.HigherThanVenus11(ctx.BlockHeight()) {
+		if _, ok := msg.Content.(types.CancelScheduledProposal); ok || msg.Execution != nil {
+			errMsg := fmt.Sprintf("scheduled proposal is not supported at height %d", ctx.BlockHeight())
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+
 	// use ctx directly
 	if !keeper.ProposalHandlerRouter().HasRoute(msg.Content.ProposalRoute()) {
 		err = keeper.CheckMsgSubmitProposal(ctx, msg)
@@ -48,10 +60,19 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper keeper.Keeper, msg MsgSubmi
 		return sdk.EnvelopedErr{err}.Result()
 	}
 
+	if msg.Execution != nil && msg.Execution.IsDue(uint64(ctx.BlockHeight()), ctx.BlockHeader().Time) {
+		return sdk.EnvelopedErr{types.ErrInvalidProposalExecution(
+			fmt.Sprintf("the execution at %s is not in the future", msg.Execution))}.Result()
+	}
+
 	proposal, err := keeper.SubmitProposal(ctx, msg.Content)
 	if err != nil {
 		return sdk.EnvelopedErr{err}.Result()
 	}
+	if msg.Execution != nil {
+		proposal.Execution = msg.Execution
+		keeper.SetProposal(ctx, proposal)
+	}
 
 	err = keeper.AddDeposit(ctx, proposal.ProposalID, msg.Proposer,
 		msg.InitialDeposit, types.EventTypeSubmitProposal)
@@ -112,6 +133,32 @@ func handleMsgVote(ctx sdk.Context, k keeper.Keeper, msg MsgVote) (*sdk.Result,
 		return sdk.EnvelopedErr{err}.Result()
 	}
 
+	return handleProposalAfterVote(ctx, k, proposal, msg.Voter)
+}
+
+func handleMsgVoteWeighted(ctx sdk.Context, k keeper.Keeper, msg MsgVoteWeighted) (*sdk.Result, error) {
+	if !tmtypes.HigherThanVenus9(ctx.BlockHeight()) {
+		errMsg := fmt.Sprintf("weighted vote is not supported at height %d", ctx.BlockHeight())
+		return sdk.ErrUnknownRequest(errMsg).Result()
+	}
+
+	proposal, ok := k.GetProposal(ctx, msg.ProposalID)
+	if !ok {
+		return sdk.EnvelopedErr{types.ErrUnknownProposal(msg.ProposalID)}.Result()
+	}
+
+	err, _ := k.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
+	if err != nil {
+		return sdk.EnvelopedErr{err}.Result()
+	}
+
+	return handleProposalAfterVote(ctx, k, proposal, msg.Voter)
+}
+
+// handleProposalAfterVote tallies the votes on the proposal, and ends its voting period if the vote decided it
+func handleProposalAfterVote(
+	ctx sdk.Context, k keeper.Keeper, proposal types.Proposal, voter sdk.AccAddress,
+) (*sdk.Result, error) {
 	status, distribute, tallyResults := keeper.Tally(ctx, k, proposal, false)
 	// update tally results after vote every time
 	proposal.FinalTallyResult = tallyResults
@@ -137,7 +184,7 @@ func handleMsgVote(ctx sdk.Context, k keeper.Keeper, msg MsgVote) (*sdk.Result,
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Voter.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, voter.String()),
 			sdk.NewAttribute(types.AttributeKeyProposalStatus, proposal.Status.String()),
 		),
 	)
@@ -155,23 +202,14 @@ func handleProposalAfterTally(
 	}
 
 	if status == StatusPassed {
-		handler := k.Router().GetRoute(proposal.ProposalRoute())
-		cacheCtx, writeCache := ctx.CacheContext()
-
-		// The proposal handler may execute state mutating logic depending
-		// on the proposal content. If the handler fails, no state mutation
-		// is written and the error message is logged.
-		err := handler(cacheCtx, proposal)
-		if err == nil {
+		if proposal.Execution != nil &&
+			!proposal.Execution.IsDue(uint64(ctx.BlockHeight()), ctx.BlockHeader().Time) {
 			proposal.Status = StatusPassed
-			// write state to the underlying multi-store
-			writeCache()
-			return types.AttributeValueProposalPassed, "passed"
+			k.InsertScheduledProposalQueue(ctx, *proposal)
+			return types.AttributeValueProposalScheduled, fmt.Sprintf("passed, scheduled for execution at %s",
+				proposal.Execution)
 		}
-
-		proposal.Status = StatusFailed
-		return types.AttributeValueProposalFailed, fmt.Sprintf("passed, but failed on execution: %s",
-			err.Error())
+		return executeProposal(ctx, k, proposal)
 	} else if status == StatusRejected {
 		if k.ProposalHandlerRouter().HasRoute(proposal.ProposalRoute()) {
 			k.ProposalHandlerRouter().GetRoute(proposal.ProposalRoute()).RejectedHandler(ctx, proposal.Content)
@@ -182,6 +220,32 @@ func handleProposalAfterTally(
 	return "", ""
 }
 
+// executeProposal executes a passed proposal, which ends as failed if its execution fails
+func executeProposal(ctx sdk.Context, k keeper.Keeper, proposal *types.Proposal) (string, string) {
+	cacheCtx, writeCache := ctx.CacheContext()
+
+	// The proposal handler may execute state mutating logic depending
+	// on the proposal content. If the handler fails, no state mutation
+	// is written and the error message is logged.
+	var err error
+	if content, ok := proposal.Content.(types.CancelScheduledProposal); ok {
+		err = k.CancelScheduledProposal(cacheCtx, content.ProposalID)
+	} else {
+		handler := k.Router().GetRoute(proposal.ProposalRoute())
+		err = handler(cacheCtx, proposal)
+	}
+	if err == nil {
+		proposal.Status = StatusPassed
+		// write state to the underlying multi-store
+		writeCache()
+		return types.AttributeValueProposalPassed, "passed"
+	}
+
+	proposal.Status = StatusFailed
+	return types.AttributeValueProposalFailed, fmt.Sprintf("passed, but failed on execution: %s",
+		err.Error())
+}
+
 func hasOnlyDefaultBondDenom(decCoins sdk.SysCoins) sdk.Error {
 	if len(decCoins) != 1 || decCoins[0].Denom != sdk.DefaultBondDenom || !decCoins.IsValid() {
 		return types.ErrInvalidCoins()
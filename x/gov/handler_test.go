@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	"github.com/okex/exchain/libs/tendermint/libs/cli/flags"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/staking"
 	"github.com/stretchr/testify/require"
 
@@ -75,6 +76,40 @@ func TestHandleMsgVote(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestHandleMsgVoteWeighted(t *testing.T) {
+	ctx, _, gk, _, _ := keeper.CreateTestInput(t, false, 1000)
+	govHandler := NewHandler(gk)
+
+	proposalCoins := sdk.SysCoins{sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 500)}
+	content := types.NewTextProposal("Test", "description")
+	newProposalMsg := NewMsgSubmitProposal(content, proposalCoins, keeper.Addrs[0])
+	res, err := govHandler(ctx, newProposalMsg)
+	require.Nil(t, err)
+	var proposalID uint64
+	gk.Cdc().MustUnmarshalBinaryLengthPrefixed(res.Data, &proposalID)
+
+	options, err := types.WeightedVoteOptionsFromString("Yes=0.6,NoWithVeto=0.4")
+	require.Nil(t, err)
+	newVoteMsg := NewMsgVoteWeighted(keeper.Addrs[4], proposalID, options)
+	// the weighted votes are supported since Venus9
+	res, err = govHandler(ctx, newVoteMsg)
+	require.NotNil(t, err)
+
+	tmtypes.InitMilestoneVenus9Height(1)
+	defer tmtypes.InitMilestoneVenus9Height(0)
+	ctx.SetBlockHeight(2)
+	res, err = govHandler(ctx, newVoteMsg)
+	require.Nil(t, err)
+
+	newVoteMsg = NewMsgVoteWeighted(keeper.Addrs[4], 0, options)
+	res, err = govHandler(ctx, newVoteMsg)
+	require.NotNil(t, err)
+
+	newVoteMsg = NewMsgVoteWeighted(keeper.Addrs[4], proposalID, options[:1])
+	res, err = govHandler(ctx, newVoteMsg)
+	require.NotNil(t, err)
+}
+
 func TestHandleMsgVote2(t *testing.T) {
 	ctx, _, gk, sk, _ := keeper.CreateTestInput(t, false, 100000)
 	govHandler := NewHandler(gk)
@@ -158,6 +158,28 @@ func (keeper Keeper) RemoveFromWaitingProposalQueue(ctx sdk.Context, blockHeight
 	store.Delete(types.WaitingProposalQueueKey(proposalID, blockHeight))
 }
 
+// InsertScheduledProposalQueue inserts a passed proposal into the scheduled proposal queue of its execution
+func (keeper Keeper) InsertScheduledProposalQueue(ctx sdk.Context, proposal types.Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(proposal.ProposalID)
+	store.Set(types.ScheduledProposalQueueKey(proposal.ProposalID, *proposal.Execution), bz)
+}
+
+// RemoveFromScheduledProposalQueue removes a proposal from the scheduled proposal queue of its execution
+func (keeper Keeper) RemoveFromScheduledProposalQueue(ctx sdk.Context, proposal types.Proposal) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(types.ScheduledProposalQueueKey(proposal.ProposalID, *proposal.Execution))
+}
+
+// IsScheduledProposal returns whether the execution of a proposal is pending in the scheduled proposal queue
+func (keeper Keeper) IsScheduledProposal(ctx sdk.Context, proposal types.Proposal) bool {
+	if proposal.Execution == nil {
+		return false
+	}
+	store := ctx.KVStore(keeper.storeKey)
+	return store.Has(types.ScheduledProposalQueueKey(proposal.ProposalID, *proposal.Execution))
+}
+
 // Iterators
 
 // IterateProposals iterates over the all the proposals and performs a callback function
@@ -261,6 +283,55 @@ func (keeper Keeper) IterateAllWaitingProposals(ctx sdk.Context,
 	}
 }
 
+// IterateScheduledProposalsQueue iterates over the proposals in the scheduled proposal queue
+// whose execution is due at the block of height and blockTime and performs a callback function
+func (keeper Keeper) IterateScheduledProposalsQueue(
+	ctx sdk.Context, height uint64, blockTime time.Time, cb func(proposal types.Proposal,
+	) (stop bool)) {
+	keeper.iterateScheduledProposals(ctx,
+		sdk.PrefixEndBytes(types.ScheduledProposalByHeightKey(height)),
+		sdk.PrefixEndBytes(types.ScheduledProposalByTimeKey(blockTime)), cb)
+}
+
+// IterateAllScheduledProposals iterates over the all proposals in the scheduled proposal queue
+// and performs a callback function
+func (keeper Keeper) IterateAllScheduledProposals(ctx sdk.Context, cb func(proposal types.Proposal) (stop bool)) {
+	keeper.iterateScheduledProposals(ctx,
+		sdk.PrefixEndBytes(types.ScheduledProposalByHeightQueuePrefix),
+		sdk.PrefixEndBytes(types.ScheduledProposalByTimeQueuePrefix), cb)
+}
+
+// iterateScheduledProposals iterates over the proposals scheduled at a height up to heightEnd,
+// then over the proposals scheduled at a time up to timeEnd
+func (keeper Keeper) iterateScheduledProposals(ctx sdk.Context, heightEnd, timeEnd []byte,
+	cb func(proposal types.Proposal) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	if keeper.iterateScheduledQueue(ctx,
+		store.Iterator(types.ScheduledProposalByHeightQueuePrefix, heightEnd), cb) {
+		return
+	}
+	keeper.iterateScheduledQueue(ctx,
+		store.Iterator(types.ScheduledProposalByTimeQueuePrefix, timeEnd), cb)
+}
+
+func (keeper Keeper) iterateScheduledQueue(ctx sdk.Context, iterator sdk.Iterator,
+	cb func(proposal types.Proposal) (stop bool)) (stop bool) {
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var proposalID uint64
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &proposalID)
+		proposal, found := keeper.GetProposal(ctx, proposalID)
+		if !found {
+			panic(fmt.Sprintf("proposal %d does not exist", proposalID))
+		}
+
+		if cb(proposal) {
+			return true
+		}
+	}
+	return false
+}
+
 // IterateAllDeposits iterates over the all the stored deposits and performs a callback function
 func (keeper Keeper) IterateAllDeposits(ctx sdk.Context, cb func(deposit types.Deposit) (stop bool)) {
 	store := ctx.KVStore(keeper.storeKey)
@@ -338,6 +409,17 @@ func (keeper Keeper) CheckMsgSubmitProposal(ctx sdk.Context, msg types.MsgSubmit
 	if err != nil {
 		return common.ErrInsufficientCoins(types.DefaultCodespace, err.Error())
 	}
+	// check the proposal to cancel is still waiting for its execution
+	if content, ok := msg.Content.(types.CancelScheduledProposal); ok {
+		proposal, found := keeper.GetProposal(ctx, content.ProposalID)
+		if !found {
+			return types.ErrUnknownProposal(content.ProposalID)
+		}
+		if proposal.Execution == nil || (proposal.Status != types.StatusDepositPeriod &&
+			proposal.Status != types.StatusVotingPeriod && !keeper.IsScheduledProposal(ctx, proposal)) {
+			return types.ErrProposalNotScheduled(content.ProposalID)
+		}
+	}
 	return nil
 }
 
@@ -88,6 +88,39 @@ func (keeper Keeper) GetProposals(ctx sdk.Context) (proposals types.Proposals) {
 	return
 }
 
+// GetScheduledProposals returns all the passed proposals whose execution is pending
+func (keeper Keeper) GetScheduledProposals(ctx sdk.Context) (proposals types.Proposals) {
+	keeper.IterateAllScheduledProposals(ctx, func(proposal types.Proposal) bool {
+		proposals = append(proposals, proposal)
+		return false
+	})
+	return
+}
+
+// CancelScheduledProposal cancels the pending execution of a passed proposal, which ends as failed
+func (keeper Keeper) CancelScheduledProposal(ctx sdk.Context, proposalID uint64) sdk.Error {
+	proposal, ok := keeper.GetProposal(ctx, proposalID)
+	if !ok {
+		return types.ErrUnknownProposal(proposalID)
+	}
+	if !keeper.IsScheduledProposal(ctx, proposal) {
+		return types.ErrProposalNotScheduled(proposalID)
+	}
+
+	keeper.RemoveFromScheduledProposalQueue(ctx, proposal)
+	proposal.Status = types.StatusFailed
+	keeper.SetProposal(ctx, proposal)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeScheduledProposal,
+			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", proposalID)),
+			sdk.NewAttribute(types.AttributeKeyProposalResult, types.AttributeValueProposalCancelled),
+		),
+	)
+	return nil
+}
+
 // GetProposalsFiltered get Proposals from store by ProposalID
 // voterAddr will filter proposals by whether or not that address has voted on them
 // depositorAddr will filter proposals by whether or not that address has deposited to them
@@ -34,6 +34,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryVote(ctx, path[1:], req, keeper)
 		case types.QueryTally:
 			return queryTally(ctx, path[1:], req, keeper)
+		case types.QueryScheduledProposals:
+			return queryScheduledProposals(ctx, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -192,6 +194,19 @@ func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	return bz, nil
 }
 
+func queryScheduledProposals(ctx sdk.Context, keeper Keeper) ([]byte, sdk.Error) {
+	proposals := keeper.GetScheduledProposals(ctx)
+	if proposals == nil {
+		proposals = types.Proposals{}
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, proposals)
+	if err != nil {
+		return nil, common.ErrMarshalJSONFailed(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
 // nolint: unparam
 func queryProposals(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
 	var params types.QueryProposalsParams
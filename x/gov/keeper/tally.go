@@ -2,21 +2,22 @@ package keeper
 
 import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/okex/exchain/x/gov/types"
 	"github.com/okex/exchain/x/staking/exported"
 )
 
 // validatorGovInfo used for tallying
 type validatorGovInfo struct {
-	Address             sdk.ValAddress   // address of the validator operator
-	BondedTokens        sdk.Int          // Power of a Validator
-	DelegatorShares     sdk.Dec          // Total outstanding delegator shares
-	DelegatorDeductions sdk.Dec          // Delegator deductions from validator's delegators voting independently
-	Vote                types.VoteOption // Vote of the validator
+	Address             sdk.ValAddress            // address of the validator operator
+	BondedTokens        sdk.Int                   // Power of a Validator
+	DelegatorShares     sdk.Dec                   // Total outstanding delegator shares
+	DelegatorDeductions sdk.Dec                   // Delegator deductions from validator's delegators voting independently
+	Vote                types.WeightedVoteOptions // Vote of the validator, empty if it didn't vote
 }
 
 func newValidatorGovInfo(address sdk.ValAddress, bondedTokens sdk.Int, delegatorShares,
-	delegatorDeductions sdk.Dec, vote types.VoteOption) validatorGovInfo {
+	delegatorDeductions sdk.Dec, vote types.WeightedVoteOptions) validatorGovInfo {
 
 	return validatorGovInfo{
 		Address:             address,
@@ -43,7 +44,7 @@ func tallyDelegatorVotes(
 		// if delegator tally voting power
 		valAddrStr := sdk.ValAddress(vote.Voter).String()
 		if val, ok := currValidators[valAddrStr]; ok {
-			val.Vote = vote.Option
+			val.Vote = tallyVoteOptions(ctx, vote)
 			currValidators[valAddrStr] = val
 		} else {
 			// iterate over all delegations from voter, deduct from any delegated-to validators
@@ -60,9 +61,9 @@ func tallyDelegatorVotes(
 					votedPower := delegation.GetLastAddedShares()
 					// calculate vote power of delegator for voterPowerRate
 					if voteP != nil && vote.Voter.Equals(voteP.Voter) {
-						voterPower.Add(votedPower)
+						*voterPower = voterPower.Add(votedPower)
 					}
-					results[vote.Option] = results[vote.Option].Add(votedPower)
+					addWeightedVotes(results, tallyVoteOptions(ctx, vote), votedPower)
 					*totalVotedPower = totalVotedPower.Add(votedPower)
 				}
 			}
@@ -70,6 +71,104 @@ func tallyDelegatorVotes(
 	}
 }
 
+// delegatorVote is the vote of a delegator not being a validator, with the shares it votes with
+type delegatorVote struct {
+	vote       types.Vote
+	delegation exported.DelegatorI
+	validators []sdk.ValAddress
+	shares     sdk.Dec
+}
+
+// proxyShares returns the part of the shares of a proxy coming from tokens it was delegated or its own
+func proxyShares(proxy exported.DelegatorI, tokens sdk.Dec) sdk.Dec {
+	totalTokens := proxy.GetTokens().Add(proxy.GetTotalDelegatedTokens())
+	if !totalTokens.IsPositive() {
+		return sdk.ZeroDec()
+	}
+	return proxy.GetLastAddedShares().Mul(tokens).Quo(totalTokens)
+}
+
+// tallyProxyDelegatorVotes tallies the votes of the delegators since Venus10, a delegator bound to a proxy
+// inherits its vote unless voting itself with its part of the shares of the proxy
+func tallyProxyDelegatorVotes(
+	ctx sdk.Context, keeper Keeper, currValidators map[string]validatorGovInfo, proposalID uint64,
+	voteP *types.Vote, voterPower, totalVotedPower, inheritedPower *sdk.Dec, results map[types.VoteOption]sdk.Dec,
+) {
+	// iterate over all the votes
+	votesIterator := keeper.GetVotes(ctx, proposalID)
+	if voteP != nil {
+		votesIterator = append(votesIterator, *voteP)
+	}
+
+	delegatorVotes := make([]delegatorVote, 0, len(votesIterator))
+	// shares of the proxies voted by the delegators bound to them instead
+	overriddenShares := make(map[string]sdk.Dec)
+	for i := 0; i < len(votesIterator); i++ {
+		vote := votesIterator[i]
+
+		// if validator, just record it in the map
+		// if delegator tally voting power
+		valAddrStr := sdk.ValAddress(vote.Voter).String()
+		if val, ok := currValidators[valAddrStr]; ok {
+			val.Vote = tallyVoteOptions(ctx, vote)
+			currValidators[valAddrStr] = val
+			continue
+		}
+
+		delegation := keeper.sk.Delegator(ctx, vote.Voter)
+		if delegation == nil {
+			continue
+		}
+		delVote := delegatorVote{
+			vote:       vote,
+			delegation: delegation,
+			validators: delegation.GetShareAddedValidatorAddresses(),
+			shares:     delegation.GetLastAddedShares(),
+		}
+		if proxyAddr := delegation.GetProxyAddress(); len(proxyAddr) != 0 {
+			proxy := keeper.sk.Delegator(ctx, proxyAddr)
+			if proxy == nil {
+				continue
+			}
+			delVote.validators = proxy.GetShareAddedValidatorAddresses()
+			delVote.shares = proxyShares(proxy, delegation.GetTokens())
+			if overridden, ok := overriddenShares[proxyAddr.String()]; ok {
+				overriddenShares[proxyAddr.String()] = overridden.Add(delVote.shares)
+			} else {
+				overriddenShares[proxyAddr.String()] = delVote.shares
+			}
+		}
+		delegatorVotes = append(delegatorVotes, delVote)
+	}
+
+	for _, delVote := range delegatorVotes {
+		// a proxy votes with the shares of the delegators bound to it not voting themselves
+		votedPower := delVote.shares
+		inheritedVotedPower := proxyShares(delVote.delegation, delVote.delegation.GetTotalDelegatedTokens())
+		if overridden, ok := overriddenShares[delVote.vote.Voter.String()]; ok {
+			votedPower = votedPower.Sub(overridden)
+			inheritedVotedPower = inheritedVotedPower.Sub(overridden)
+		}
+
+		// deduct from any delegated-to validators
+		for _, val := range delVote.validators {
+			valAddrStr := val.String()
+			if valInfo, ok := currValidators[valAddrStr]; ok {
+				valInfo.DelegatorDeductions = valInfo.DelegatorDeductions.Add(votedPower)
+				currValidators[valAddrStr] = valInfo
+
+				// calculate vote power of delegator for voterPowerRate
+				if voteP != nil && delVote.vote.Voter.Equals(voteP.Voter) {
+					*voterPower = voterPower.Add(votedPower)
+				}
+				addWeightedVotes(results, tallyVoteOptions(ctx, delVote.vote), votedPower)
+				*totalVotedPower = totalVotedPower.Add(votedPower)
+				*inheritedPower = inheritedPower.Add(inheritedVotedPower)
+			}
+		}
+	}
+}
+
 func tallyValidatorVotes(
 	currValidators map[string]validatorGovInfo, voteP *types.Vote, voterPower,
 	totalPower, totalVotedPower *sdk.Dec, results map[types.VoteOption]sdk.Dec,
@@ -78,7 +177,7 @@ func tallyValidatorVotes(
 	for key, val := range currValidators {
 		// calculate all vote power of current validators including delegated for voterPowerRate
 		*totalPower = totalPower.Add(val.DelegatorShares)
-		if val.Vote == types.OptionEmpty {
+		if len(val.Vote) == 0 {
 			continue
 		}
 
@@ -87,14 +186,29 @@ func tallyValidatorVotes(
 			// calculate vote power of validator after deduction for voterPowerRate
 			*voterPower = voterPower.Add(valValidVotedPower)
 		}
-		results[val.Vote] = results[val.Vote].Add(valValidVotedPower)
+		addWeightedVotes(results, val.Vote, valValidVotedPower)
 		*totalVotedPower = totalVotedPower.Add(valValidVotedPower)
 	}
 }
 
+// tallyVoteOptions returns the options a vote is tallied with, the weighted votes are tallied since Venus9
+func tallyVoteOptions(ctx sdk.Context, vote types.Vote) types.WeightedVoteOptions {
+	if !tmtypes.HigherThanVenus9(ctx.BlockHeight()) {
+		return types.NewNonSplitVoteOption(vote.Option)
+	}
+	return vote.WeightedOptions()
+}
+
+// addWeightedVotes splits the voting power over the options of a vote by their weights
+func addWeightedVotes(results map[types.VoteOption]sdk.Dec, options types.WeightedVoteOptions, votedPower sdk.Dec) {
+	for _, option := range options {
+		results[option.Option] = results[option.Option].Add(votedPower.Mul(option.Weight))
+	}
+}
+
 func preTally(
 	ctx sdk.Context, keeper Keeper, proposal types.Proposal, voteP *types.Vote,
-) (results map[types.VoteOption]sdk.Dec, totalVotedPower sdk.Dec, voterPowerRate sdk.Dec) {
+) (results map[types.VoteOption]sdk.Dec, totalVotedPower, inheritedPower sdk.Dec, voterPowerRate sdk.Dec) {
 	results = make(map[types.VoteOption]sdk.Dec)
 	results[types.OptionYes] = sdk.ZeroDec()
 	results[types.OptionAbstain] = sdk.ZeroDec()
@@ -102,6 +216,7 @@ func preTally(
 	results[types.OptionNoWithVeto] = sdk.ZeroDec()
 
 	totalVotedPower = sdk.ZeroDec()
+	inheritedPower = sdk.ZeroDec()
 	totalPower := sdk.ZeroDec()
 	voterPower := sdk.ZeroDec()
 	currValidators := make(map[string]validatorGovInfo)
@@ -113,14 +228,19 @@ func preTally(
 			validator.GetBondedTokens(),
 			validator.GetDelegatorShares(),
 			sdk.ZeroDec(),
-			types.OptionEmpty,
+			nil,
 		)
 
 		return false
 	})
 
-	tallyDelegatorVotes(ctx, keeper, currValidators, proposal.ProposalID,
-		voteP, &voterPower, &totalVotedPower, results)
+	if tmtypes.HigherThanVenus10(ctx.BlockHeight()) {
+		tallyProxyDelegatorVotes(ctx, keeper, currValidators, proposal.ProposalID,
+			voteP, &voterPower, &totalVotedPower, &inheritedPower, results)
+	} else {
+		tallyDelegatorVotes(ctx, keeper, currValidators, proposal.ProposalID,
+			voteP, &voterPower, &totalVotedPower, results)
+	}
 
 	tallyValidatorVotes(currValidators, voteP, &voterPower, &totalPower, &totalVotedPower, results)
 	if totalPower.GT(sdk.ZeroDec()) {
@@ -129,7 +249,7 @@ func preTally(
 		voterPowerRate = sdk.ZeroDec()
 	}
 
-	return results, totalVotedPower, voterPowerRate
+	return results, totalVotedPower, inheritedPower, voterPowerRate
 }
 
 // tally and return status before voting period end time
@@ -201,10 +321,15 @@ func tallyStatusExpireVotePeriod(
 // Tally counts the votes for proposal
 func Tally(ctx sdk.Context, keeper Keeper, proposal types.Proposal, isExpireVoteEndTime bool,
 ) (types.ProposalStatus, bool, types.TallyResult) {
-	results, totalVotedPower, _ := preTally(ctx, keeper, proposal, nil)
+	results, totalVotedPower, inheritedPower, _ := preTally(ctx, keeper, proposal, nil)
 	tallyResults := types.NewTallyResultFromMap(results)
 	tallyResults.TotalPower = keeper.totalPower(ctx)
 	tallyResults.TotalVotedPower = totalVotedPower
+	if tmtypes.HigherThanVenus10(ctx.BlockHeight()) {
+		directPower := totalVotedPower.Sub(inheritedPower)
+		tallyResults.InheritedVotedPower = &inheritedPower
+		tallyResults.DirectVotedPower = &directPower
+	}
 
 	if isExpireVoteEndTime {
 		status, distribute := tallyStatusExpireVotePeriod(ctx, keeper, tallyResults)
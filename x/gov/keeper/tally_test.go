@@ -7,6 +7,7 @@ import (
 	"github.com/okex/exchain/x/common"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/okex/exchain/x/gov/types"
@@ -313,3 +314,139 @@ func TestTallyDelegatorOverride(t *testing.T) {
 	require.Equal(t, types.StatusPassed, status)
 	require.Equal(t, expectedTallyResult, tallyResults)
 }
+
+func TestTallyWeightedVotes(t *testing.T) {
+	ctx, _, keeper, sk, _ := CreateTestInput(t, false, 100000)
+	ctx.SetBlockHeight(int64(sk.GetEpoch(ctx)))
+	ctx.SetBlockTime(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	stakingHandler := staking.NewHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(Addrs[:2]))
+	for i, addr := range Addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	CreateValidators(t, stakingHandler, ctx, valAddrs, []int64{5, 5})
+	staking.EndBlocker(ctx, sk)
+
+	coin, err := sdk.ParseDecCoin("2.0" + common.NativeToken)
+	require.Nil(t, err)
+	delegator1Msg := staking.NewMsgDeposit(Addrs[3], coin)
+	stakingHandler(ctx, delegator1Msg)
+
+	addSharesMsg := staking.NewMsgAddShares(Addrs[3], []sdk.ValAddress{sdk.ValAddress(Addrs[1])})
+	stakingHandler(ctx, addSharesMsg)
+
+	content := types.NewTextProposal("Test", "description")
+	proposal, err := keeper.SubmitProposal(ctx, content)
+	require.Nil(t, err)
+	proposal.Status = types.StatusVotingPeriod
+	keeper.SetProposal(ctx, proposal)
+	proposalID := proposal.ProposalID
+
+	err, _ = keeper.AddVote(ctx, proposalID, Addrs[0], types.OptionYes)
+	require.Nil(t, err)
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[1], types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionYes, sdk.NewDecWithPrec(5, 1)),
+		types.NewWeightedVoteOption(types.OptionAbstain, sdk.NewDecWithPrec(5, 1)),
+	})
+	require.Nil(t, err)
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[3], types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionNo, sdk.NewDecWithPrec(75, 2)),
+		types.NewWeightedVoteOption(types.OptionNoWithVeto, sdk.NewDecWithPrec(25, 2)),
+	})
+	require.Nil(t, err)
+
+	// the weighted votes are tallied since Venus9
+	tmtypes.InitMilestoneVenus9Height(ctx.BlockHeight())
+	defer tmtypes.InitMilestoneVenus9Height(0)
+	ctx.SetBlockHeight(ctx.BlockHeight() + 1)
+
+	// the validator 1 splits its power left by the delegator voting independently, the delegator splits its shares
+	expectedTallyResult := newTallyResult(t, "4", "1.5", "0.5", "1.5", "0.5", "4")
+	status, dist, tallyResults := Tally(ctx, keeper, proposal, true)
+	require.False(t, dist)
+	require.Equal(t, types.StatusRejected, status)
+	require.True(t, tallyResults.Equals(expectedTallyResult), tallyResults.String())
+}
+
+func TestTallyProxyInherit(t *testing.T) {
+	ctx, _, keeper, sk, _ := CreateTestInput(t, false, 100000)
+	ctx.SetBlockHeight(int64(sk.GetEpoch(ctx)))
+	ctx.SetBlockTime(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	stakingHandler := staking.NewHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(Addrs[:2]))
+	for i, addr := range Addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	CreateValidators(t, stakingHandler, ctx, valAddrs, []int64{5, 5})
+	staking.EndBlocker(ctx, sk)
+
+	// the proxy delegates 1 token, and the delegators bound to it 1 and 2 tokens
+	for i, amount := range []string{"1.0", "1.0", "2.0"} {
+		coin, err := sdk.ParseDecCoin(amount + common.NativeToken)
+		require.Nil(t, err)
+		_, err = stakingHandler(ctx, staking.NewMsgDeposit(Addrs[3+i], coin))
+		require.Nil(t, err)
+	}
+	_, err := stakingHandler(ctx, staking.NewMsgRegProxy(Addrs[3], true))
+	require.Nil(t, err)
+	_, err = stakingHandler(ctx, staking.NewMsgAddShares(Addrs[3], []sdk.ValAddress{sdk.ValAddress(Addrs[1])}))
+	require.Nil(t, err)
+	for _, delAddr := range Addrs[4:6] {
+		_, err = stakingHandler(ctx, staking.NewMsgBindProxy(delAddr, Addrs[3]))
+		require.Nil(t, err)
+	}
+
+	content := types.NewTextProposal("Test", "description")
+	proposal, err := keeper.SubmitProposal(ctx, content)
+	require.Nil(t, err)
+	proposal.Status = types.StatusVotingPeriod
+	keeper.SetProposal(ctx, proposal)
+	proposalID := proposal.ProposalID
+
+	err, _ = keeper.AddVote(ctx, proposalID, Addrs[0], types.OptionYes)
+	require.Nil(t, err)
+	err, _ = keeper.AddVote(ctx, proposalID, Addrs[1], types.OptionNo)
+	require.Nil(t, err)
+	err, _ = keeper.AddVote(ctx, proposalID, Addrs[3], types.OptionYes)
+	require.Nil(t, err)
+
+	// the proxy votes with all its shares before Venus10, without the inherited and direct voted power
+	expectedTallyResult := newTallyResult(t, "6", "5", "0.0", "1", "0.0", "6")
+	_, _, tallyResults := Tally(ctx, keeper, proposal, true)
+	require.True(t, tallyResults.Equals(expectedTallyResult), tallyResults.String())
+	require.Nil(t, tallyResults.InheritedVotedPower)
+	require.Nil(t, tallyResults.DirectVotedPower)
+
+	tmtypes.InitMilestoneVenus10Height(ctx.BlockHeight())
+	defer tmtypes.InitMilestoneVenus10Height(0)
+	ctx.SetBlockHeight(ctx.BlockHeight() + 1)
+
+	// the delegators inherit the vote of the proxy
+	_, _, tallyResults = Tally(ctx, keeper, proposal, true)
+	require.True(t, tallyResults.Equals(expectedTallyResult), tallyResults.String())
+	require.True(t, tallyResults.InheritedVotedPower.Equal(sdk.NewDec(3)), tallyResults.String())
+	require.True(t, tallyResults.DirectVotedPower.Equal(sdk.NewDec(3)), tallyResults.String())
+
+	// a delegator overrides the vote of the proxy with its part of the shares of the proxy
+	err, _ = keeper.AddVote(ctx, proposalID, Addrs[5], types.OptionNoWithVeto)
+	require.Nil(t, err)
+
+	expectedTallyResult = newTallyResult(t, "6", "3", "0.0", "1", "2", "6")
+	_, _, tallyResults = Tally(ctx, keeper, proposal, true)
+	require.True(t, tallyResults.Equals(expectedTallyResult), tallyResults.String())
+	require.True(t, tallyResults.InheritedVotedPower.Equal(sdk.NewDec(1)), tallyResults.String())
+	require.True(t, tallyResults.DirectVotedPower.Equal(sdk.NewDec(5)), tallyResults.String())
+
+	// the voting power of a delegator voting itself is its part of the shares of the proxy
+	vote := types.NewVote(proposalID, Addrs[4], types.OptionYes)
+	_, _, _, voterPowerRate := preTally(ctx, keeper, proposal, &vote)
+	require.True(t, voterPowerRate.Equal(sdk.OneDec().Quo(keeper.totalPower(ctx))), voterPowerRate.String())
+
+	// without the vote of the proxy, the delegators inherit the vote of its validator
+	keeper.deleteVote(ctx, proposalID, Addrs[3])
+
+	expectedTallyResult = newTallyResult(t, "6", "1", "0.0", "3", "2", "6")
+	_, _, tallyResults = Tally(ctx, keeper, proposal, true)
+	require.True(t, tallyResults.Equals(expectedTallyResult), tallyResults.String())
+	require.True(t, tallyResults.InheritedVotedPower.IsZero(), tallyResults.String())
+}
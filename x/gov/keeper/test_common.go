@@ -245,6 +245,7 @@ func MakeTestCodec() *codec.Codec {
 
 	cdc.RegisterInterface((*types.Content)(nil), nil)
 	cdc.RegisterConcrete(types.TextProposal{}, "test/gov/TextProposal", nil)
+	cdc.RegisterConcrete(types.CancelScheduledProposal{}, "test/gov/CancelScheduledProposal", nil)
 	cdc.RegisterConcrete(params.ParameterChangeProposal{}, "test/params/ParameterChangeProposal", nil)
 	cdc.RegisterConcrete(types.Proposal{}, "test/gov/Proposal", nil)
 
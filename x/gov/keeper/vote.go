@@ -10,22 +10,46 @@ import (
 func (keeper Keeper) AddVote(
 	ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, option types.VoteOption,
 ) (sdk.Error, string) {
+	proposal, err := keeper.getVotingProposal(ctx, proposalID)
+	if err != nil {
+		return err, ""
+	}
+	if !types.ValidVoteOption(option) {
+		return types.ErrInvalidVote(option), ""
+	}
+
+	return keeper.addVote(ctx, proposal, types.NewVote(proposalID, voterAddr, option))
+}
+
+// AddWeightedVote adds a vote splitting the voting power of the voter over several options on a
+// specific proposal
+func (keeper Keeper) AddWeightedVote(
+	ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, options types.WeightedVoteOptions,
+) (sdk.Error, string) {
+	proposal, err := keeper.getVotingProposal(ctx, proposalID)
+	if err != nil {
+		return err, ""
+	}
+	if !types.ValidWeightedVoteOptions(options) {
+		return types.ErrInvalidWeightedVote(options), ""
+	}
+
+	return keeper.addVote(ctx, proposal, types.NewWeightedVote(proposalID, voterAddr, options))
+}
+
+func (keeper Keeper) getVotingProposal(ctx sdk.Context, proposalID uint64) (types.Proposal, sdk.Error) {
 	proposal, ok := keeper.GetProposal(ctx, proposalID)
 	if !ok {
-		return types.ErrUnknownProposal(proposalID), ""
+		return proposal, types.ErrUnknownProposal(proposalID)
 	}
 	if proposal.Status != types.StatusVotingPeriod {
-		return types.ErrInvalidateProposalStatus(), ""
-	}
-
-	if !types.ValidVoteOption(option) {
-		return types.ErrInvalidVote(option), ""
+		return proposal, types.ErrInvalidateProposalStatus()
 	}
+	return proposal, nil
+}
 
+func (keeper Keeper) addVote(ctx sdk.Context, proposal types.Proposal, vote types.Vote) (sdk.Error, string) {
 	voteFeeStr := ""
-	vote := types.Vote{
-		ProposalID: proposalID, Voter: voterAddr, Option: option,
-	}
 	if keeper.ProposalHandlerRouter().HasRoute(proposal.ProposalRoute()) {
 		var err sdk.Error
 		voteFeeStr, err = keeper.ProposalHandlerRouter().GetRoute(proposal.ProposalRoute()).VoteHandler(ctx, proposal, vote)
@@ -34,13 +58,17 @@ func (keeper Keeper) AddVote(
 		}
 	}
 
-	keeper.SetVote(ctx, proposalID, vote)
+	keeper.SetVote(ctx, vote.ProposalID, vote)
 
+	option := vote.Option.String()
+	if len(vote.Options) != 0 {
+		option = vote.Options.String()
+	}
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			types.EventTypeProposalVote,
-			sdk.NewAttribute(types.AttributeKeyOption, option.String()),
-			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", proposalID)),
+			sdk.NewAttribute(types.AttributeKeyOption, option),
+			sdk.NewAttribute(types.AttributeKeyProposalID, fmt.Sprintf("%d", vote.ProposalID)),
 		),
 	)
 
@@ -61,6 +61,45 @@ func TestKeeper_AddVote(t *testing.T) {
 	require.Equal(t, expectedVote, vote)
 }
 
+func TestKeeper_AddWeightedVote(t *testing.T) {
+	ctx, _, keeper, _, _ := CreateTestInput(t, false, 1000)
+
+	content := types.NewTextProposal("Test", "description")
+	proposal, err := keeper.SubmitProposal(ctx, content)
+	require.Nil(t, err)
+	proposalID := proposal.ProposalID
+	proposal.Status = types.StatusVotingPeriod
+	keeper.SetProposal(ctx, proposal)
+
+	options := types.WeightedVoteOptions{
+		types.NewWeightedVoteOption(types.OptionYes, sdk.NewDecWithPrec(6, 1)),
+		types.NewWeightedVoteOption(types.OptionNo, sdk.NewDecWithPrec(3, 1)),
+	}
+	// weights not summing to 1
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[0], options)
+	require.NotNil(t, err)
+
+	// duplicated option
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[0], append(options,
+		types.NewWeightedVoteOption(types.OptionNo, sdk.NewDecWithPrec(1, 1))))
+	require.NotNil(t, err)
+
+	options = append(options, types.NewWeightedVoteOption(types.OptionAbstain, sdk.NewDecWithPrec(1, 1)))
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[0], options)
+	require.Nil(t, err)
+	vote, ok := keeper.GetVote(ctx, proposalID, Addrs[0])
+	require.True(t, ok)
+	require.True(t, vote.Equals(types.Vote{ProposalID: proposalID, Voter: Addrs[0], Options: options}))
+	require.Equal(t, options, vote.WeightedOptions())
+
+	// a single option is stored as a vote
+	err, _ = keeper.AddWeightedVote(ctx, proposalID, Addrs[0], types.NewNonSplitVoteOption(types.OptionNo))
+	require.Nil(t, err)
+	vote, ok = keeper.GetVote(ctx, proposalID, Addrs[0])
+	require.True(t, ok)
+	require.Equal(t, types.Vote{ProposalID: proposalID, Voter: Addrs[0], Option: types.OptionNo}, vote)
+}
+
 func TestKeeper_GetVote(t *testing.T) {
 	ctx, _, keeper, _, _ := CreateTestInput(t, false, 1000)
 
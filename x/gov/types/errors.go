@@ -24,6 +24,8 @@ const (
 	CodeInvalidHeight            uint32 = BaseGovError + 10
 	CodeInvalidCoins             uint32 = BaseGovError + 11
 	CodeUnknownParamType         uint32 = BaseGovError + 12
+	CodeInvalidExecution         uint32 = BaseGovError + 13
+	CodeProposalNotScheduled     uint32 = BaseGovError + 14
 )
 
 func ErrInvalidAddress(address string) sdk.Error {
@@ -46,6 +48,10 @@ func ErrInvalidVote(voteOption VoteOption) sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeInvalidVote, fmt.Sprintf("'%v' is not a valid voting option", voteOption.String()))
 }
 
+func ErrInvalidWeightedVote(options WeightedVoteOptions) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeInvalidVote, fmt.Sprintf("'%v' are not valid weighted voting options", options.String()))
+}
+
 func ErrInvalidGenesis() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeInvalidGenesis, "initial proposal ID hasn't been set")
 }
@@ -80,3 +86,11 @@ func ErrInvalidCoins() sdk.Error {
 func ErrUnknownGovParamType() sdk.Error {
 	return sdkerrors.New(DefaultCodespace, CodeUnknownParamType, "unkonwn gov param type")
 }
+
+func ErrInvalidProposalExecution(msg string) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeInvalidExecution, fmt.Sprintf("invalid proposal execution: %s", msg))
+}
+
+func ErrProposalNotScheduled(proposalID uint64) sdk.Error {
+	return sdkerrors.New(DefaultCodespace, CodeProposalNotScheduled, fmt.Sprintf("proposal %d is not scheduled for execution", proposalID))
+}
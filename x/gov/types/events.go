@@ -10,6 +10,7 @@ const (
 	EventTypeProposalVoteTally = "proposal_vote_tally"
 	EventTypeInactiveProposal  = "inactive_proposal"
 	EventTypeActiveProposal    = "active_proposal"
+	EventTypeScheduledProposal = "scheduled_proposal"
 
 	AttributeKeyProposalResult     = "proposal_result"
 	AttributeKeyProposalLog        = "proposal_result_log"
@@ -22,3 +23,8 @@ const (
 	AttributeValueProposalRejected = "proposal_rejected" // didn't meet vote quorum
 	AttributeValueProposalFailed   = "proposal_failed"   // error on proposal handler
 )
+
+const (
+	AttributeValueProposalScheduled = "proposal_scheduled" // passed, executed at a later height or time
+	AttributeValueProposalCancelled = "proposal_cancelled" // passed, its scheduled execution cancelled
+)
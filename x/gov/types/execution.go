@@ -0,0 +1,47 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+)
+
+// ProposalExecution schedules the execution of a proposal once passed, at a block height or at a block time
+type ProposalExecution struct {
+	Height uint64    `json:"height" yaml:"height"` // height of the block the proposal is executed at, 0 if scheduled at a time
+	Time   time.Time `json:"time" yaml:"time"`     // time of the block the proposal is executed at, zero if scheduled at a height
+}
+
+// NewHeightExecution creates a ProposalExecution executing a proposal at a block height
+func NewHeightExecution(height uint64) *ProposalExecution {
+	return &ProposalExecution{Height: height}
+}
+
+// NewTimeExecution creates a ProposalExecution executing a proposal at a block time
+func NewTimeExecution(t time.Time) *ProposalExecution {
+	return &ProposalExecution{Time: t.UTC()}
+}
+
+// ValidateBasic checks that the execution is scheduled either at a height or at a time
+func (e ProposalExecution) ValidateBasic() sdk.Error {
+	if (e.Height == 0) == e.Time.IsZero() {
+		return ErrInvalidProposalExecution("the execution must be scheduled either at a height or at a time")
+	}
+	return nil
+}
+
+// IsDue returns whether the proposal is executed at the block of height and blockTime
+func (e ProposalExecution) IsDue(height uint64, blockTime time.Time) bool {
+	if e.Height != 0 {
+		return height >= e.Height
+	}
+	return !blockTime.Before(e.Time)
+}
+
+func (e ProposalExecution) String() string {
+	if e.Height != 0 {
+		return fmt.Sprintf("height %d", e.Height)
+	}
+	return fmt.Sprintf("time %s", e.Time.Format(time.RFC3339))
+}
@@ -39,6 +39,10 @@ const (
 // - 0x10<proposalID_Bytes><depositorAddr_Bytes>: Deposit
 //
 // - 0x20<proposalID_Bytes><voterAddr_Bytes>: Voter
+//
+// - 0x31<height_Bytes><proposalID_Bytes>: scheduledProposalID
+//
+// - 0x32<executionTime_Bytes><proposalID_Bytes>: scheduledProposalID
 var (
 	ProposalsKeyPrefix          = []byte{0x00}
 	ActiveProposalQueuePrefix   = []byte{0x01}
@@ -51,8 +55,34 @@ var (
 
 	// PrefixWaitingProposalQueue defines the prefix of waiting proposal queue
 	PrefixWaitingProposalQueue = []byte{0x30}
+
+	// ScheduledProposalByHeightQueuePrefix defines the prefix of the queue of the passed proposals executed at a height
+	ScheduledProposalByHeightQueuePrefix = []byte{0x31}
+	// ScheduledProposalByTimeQueuePrefix defines the prefix of the queue of the passed proposals executed at a time
+	ScheduledProposalByTimeQueuePrefix = []byte{0x32}
 )
 
+// ScheduledProposalByHeightKey gets the scheduled proposal queue key by execution height
+func ScheduledProposalByHeightKey(height uint64) []byte {
+	return append(ScheduledProposalByHeightQueuePrefix, sdk.Uint64ToBigEndian(height)...)
+}
+
+// ScheduledProposalByTimeKey gets the scheduled proposal queue key by execution time
+func ScheduledProposalByTimeKey(executionTime time.Time) []byte {
+	return append(ScheduledProposalByTimeQueuePrefix, sdk.FormatTimeBytes(executionTime)...)
+}
+
+// ScheduledProposalQueueKey returns the key for a proposalID in the scheduled proposal queue of its execution
+func ScheduledProposalQueueKey(proposalID uint64, execution ProposalExecution) []byte {
+	bz := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bz, proposalID)
+
+	if execution.Height != 0 {
+		return append(ScheduledProposalByHeightKey(execution.Height), bz...)
+	}
+	return append(ScheduledProposalByTimeKey(execution.Time), bz...)
+}
+
 // WaitingProposalByBlockHeightKey gets the waiting proposal queue key by block height
 func WaitingProposalByBlockHeightKey(blockHeight uint64) []byte {
 	return append(PrefixWaitingProposalQueue, sdk.Uint64ToBigEndian(blockHeight)...)
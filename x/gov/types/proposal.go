@@ -24,6 +24,8 @@ type Proposal struct {
 
 	VotingStartTime time.Time `json:"voting_start_time" yaml:"voting_start_time"` // Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
 	VotingEndTime   time.Time `json:"voting_end_time" yaml:"voting_end_time"`     // Time that the VotingPeriod for this proposal will end and votes will be tallied
+
+	Execution *ProposalExecution `json:"execution,omitempty" yaml:"execution,omitempty"` // Height or time the proposal is executed at once passed, executed when passed if nil
 }
 
 func NewProposal(ctx sdk.Context, totalVoting sdk.Dec, content Content, id uint64, submitTime, depositEndTime time.Time) Proposal {
@@ -227,6 +229,10 @@ type TallyResult struct {
 	Abstain         sdk.Dec `json:"abstain"`
 	No              sdk.Dec `json:"no"`
 	NoWithVeto      sdk.Dec `json:"no_with_veto"`
+	// power of the delegators bound to a proxy who inherited the vote of the proxy, nil before Venus10
+	InheritedVotedPower *sdk.Dec `json:"inherited_voted_power,omitempty"`
+	// power voted by the accounts themselves, the part of TotalVotedPower not inherited, nil before Venus10
+	DirectVotedPower *sdk.Dec `json:"direct_voted_power,omitempty"`
 }
 
 func NewTallyResult(yes, abstain, no, noWithVeto sdk.Dec) TallyResult {
@@ -268,19 +274,26 @@ func (tr TallyResult) Equals(comp TallyResult) bool {
 }
 
 func (tr TallyResult) String() string {
-	return fmt.Sprintf(`Tally Result:
+	out := fmt.Sprintf(`Tally Result:
   TotalPower %s
   TotalVotedPower %s
   Yes:        %s
   Abstain:    %s
   No:         %s
   NoWithVeto: %s`, tr.TotalPower, tr.TotalVotedPower, tr.Yes, tr.Abstain, tr.No, tr.NoWithVeto)
+	if tr.InheritedVotedPower != nil && tr.DirectVotedPower != nil {
+		out += fmt.Sprintf(`
+  InheritedVotedPower %s
+  DirectVotedPower %s`, tr.InheritedVotedPower, tr.DirectVotedPower)
+	}
+	return out
 }
 
 // Proposal types
 const (
-	ProposalTypeText            string = "Text"
-	ProposalTypeSoftwareUpgrade string = "SoftwareUpgrade"
+	ProposalTypeText                    string = "Text"
+	ProposalTypeSoftwareUpgrade         string = "SoftwareUpgrade"
+	ProposalTypeCancelScheduledProposal string = "CancelScheduledProposal"
 )
 
 // Text Proposal
@@ -341,9 +354,41 @@ func (sup SoftwareUpgradeProposal) String() string {
 `, sup.Title, sup.Description)
 }
 
+// CancelScheduledProposal cancels the scheduled execution of a passed proposal
+type CancelScheduledProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	ProposalID  uint64 `json:"proposal_id" yaml:"proposal_id"` // ID of the proposal whose execution is cancelled
+}
+
+func NewCancelScheduledProposal(title, description string, proposalID uint64) Content {
+	return CancelScheduledProposal{title, description, proposalID}
+}
+
+// Implements Proposal Interface
+var _ Content = CancelScheduledProposal{}
+
+// nolint
+func (csp CancelScheduledProposal) GetTitle() string       { return csp.Title }
+func (csp CancelScheduledProposal) GetDescription() string { return csp.Description }
+func (csp CancelScheduledProposal) ProposalRoute() string  { return RouterKey }
+func (csp CancelScheduledProposal) ProposalType() string   { return ProposalTypeCancelScheduledProposal }
+func (csp CancelScheduledProposal) ValidateBasic() sdk.Error {
+	return ValidateAbstract(DefaultCodespace, csp)
+}
+
+func (csp CancelScheduledProposal) String() string {
+	return fmt.Sprintf(`Cancel Scheduled Proposal:
+  Title:       %s
+  Description: %s
+  Proposal ID: %d
+`, csp.Title, csp.Description, csp.ProposalID)
+}
+
 var validProposalTypes = map[string]struct{}{
-	ProposalTypeText:            {},
-	ProposalTypeSoftwareUpgrade: {},
+	ProposalTypeText:                    {},
+	ProposalTypeSoftwareUpgrade:         {},
+	ProposalTypeCancelScheduledProposal: {},
 }
 
 // RegisterProposalType registers a proposal type. It will panic if the type is
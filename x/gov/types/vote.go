@@ -3,23 +3,45 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 )
 
 // Vote
 type Vote struct {
-	ProposalID uint64         `json:"proposal_id" yaml:"proposal_id"` //  proposalID of the proposal
-	Voter      sdk.AccAddress `json:"voter" yaml:"voter"`             //  address of the voter
-	Option     VoteOption     `json:"option" yaml:"option"`           //  option from OptionSet chosen by the voter
+	ProposalID uint64              `json:"proposal_id" yaml:"proposal_id"`             //  proposalID of the proposal
+	Voter      sdk.AccAddress      `json:"voter" yaml:"voter"`                         //  address of the voter
+	Option     VoteOption          `json:"option" yaml:"option"`                       //  option from OptionSet chosen by the voter
+	Options    WeightedVoteOptions `json:"options,omitempty" yaml:"options,omitempty"` //  weighted options chosen by the voter splitting its vote
 }
 
 // NewVote creates a new Vote instance
 func NewVote(proposalID uint64, voter sdk.AccAddress, option VoteOption) Vote {
-	return Vote{proposalID, voter, option}
+	return Vote{ProposalID: proposalID, Voter: voter, Option: option}
+}
+
+// NewWeightedVote creates a new Vote instance with weighted options, a vote with a single option
+// is stored as a Vote with this option
+func NewWeightedVote(proposalID uint64, voter sdk.AccAddress, options WeightedVoteOptions) Vote {
+	if len(options) == 1 {
+		return NewVote(proposalID, voter, options[0].Option)
+	}
+	return Vote{ProposalID: proposalID, Voter: voter, Options: options}
+}
+
+// WeightedOptions returns the weighted options of the vote, a vote with a single option weights it 1
+func (v Vote) WeightedOptions() WeightedVoteOptions {
+	if len(v.Options) != 0 {
+		return v.Options
+	}
+	return NewNonSplitVoteOption(v.Option)
 }
 
 func (v Vote) String() string {
+	if len(v.Options) != 0 {
+		return fmt.Sprintf("voter %s voted with options %s on proposal %d", v.Voter, v.Options, v.ProposalID)
+	}
 	return fmt.Sprintf("voter %s voted with option %s on proposal %d", v.Voter, v.Option, v.ProposalID)
 }
 
@@ -32,6 +54,10 @@ func (v Votes) String() string {
 	}
 	out := fmt.Sprintf("Votes for Proposal %d:", v[0].ProposalID)
 	for _, vot := range v {
+		if len(vot.Options) != 0 {
+			out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Options)
+			continue
+		}
 		out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Option)
 	}
 	return out
@@ -41,7 +67,8 @@ func (v Votes) String() string {
 func (v Vote) Equals(comp Vote) bool {
 	return v.Voter.Equals(comp.Voter) &&
 		v.ProposalID == comp.ProposalID &&
-		v.Option == comp.Option
+		v.Option == comp.Option &&
+		v.Options.Equals(comp.Options)
 }
 
 // Empty returns whether a vote is empty.
@@ -93,6 +120,93 @@ func ValidVoteOption(option VoteOption) bool {
 	return false
 }
 
+// WeightedVoteOption defines a vote option with the part of the voting power it's given
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option" yaml:"option"`
+	Weight sdk.Dec    `json:"weight" yaml:"weight"`
+}
+
+// NewWeightedVoteOption creates a new WeightedVoteOption instance
+func NewWeightedVoteOption(option VoteOption, weight sdk.Dec) WeightedVoteOption {
+	return WeightedVoteOption{Option: option, Weight: weight}
+}
+
+func (w WeightedVoteOption) String() string {
+	return fmt.Sprintf("%s=%s", w.Option, w.Weight)
+}
+
+// WeightedVoteOptions is a collection of WeightedVoteOption objects
+type WeightedVoteOptions []WeightedVoteOption
+
+// NewNonSplitVoteOption returns the weighted options of a vote giving all the voting power to option
+func NewNonSplitVoteOption(option VoteOption) WeightedVoteOptions {
+	return WeightedVoteOptions{NewWeightedVoteOption(option, sdk.OneDec())}
+}
+
+func (w WeightedVoteOptions) String() string {
+	out := make([]string, len(w))
+	for i, option := range w {
+		out[i] = option.String()
+	}
+	return strings.Join(out, ",")
+}
+
+// Equals returns whether two collections of weighted options are equal.
+func (w WeightedVoteOptions) Equals(comp WeightedVoteOptions) bool {
+	if len(w) != len(comp) {
+		return false
+	}
+	for i := range w {
+		if w[i].Option != comp[i].Option || !w[i].Weight.Equal(comp[i].Weight) {
+			return false
+		}
+	}
+	return true
+}
+
+// WeightedVoteOptionsFromString returns the weighted options from a string formatted as
+// "Yes=0.6,No=0.4". It returns an error if the string is invalid.
+func WeightedVoteOptionsFromString(str string) (WeightedVoteOptions, error) {
+	var options WeightedVoteOptions
+	for _, field := range strings.Split(str, ",") {
+		fields := strings.Split(strings.TrimSpace(field), "=")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("'%s' is not a valid weighted vote option", field)
+		}
+		option, err := VoteOptionFromString(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		weight, err := sdk.NewDecFromStr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid weight: %s", fields[1], err)
+		}
+		options = append(options, NewWeightedVoteOption(option, weight))
+	}
+	return options, nil
+}
+
+// ValidWeightedVoteOptions returns true if the options are valid and distinct, and their positive
+// weights sum to 1, and false otherwise.
+func ValidWeightedVoteOptions(options WeightedVoteOptions) bool {
+	if len(options) == 0 {
+		return false
+	}
+	totalWeight := sdk.ZeroDec()
+	usedOptions := make(map[VoteOption]bool)
+	for _, option := range options {
+		if !ValidVoteOption(option.Option) || usedOptions[option.Option] {
+			return false
+		}
+		if option.Weight.IsNil() || !option.Weight.IsPositive() || option.Weight.GT(sdk.OneDec()) {
+			return false
+		}
+		usedOptions[option.Option] = true
+		totalWeight = totalWeight.Add(option.Weight)
+	}
+	return totalWeight.Equal(sdk.OneDec())
+}
+
 // Marshal needed for protobuf compatibility.
 func (vo VoteOption) Marshal() ([]byte, error) {
 	return []byte{byte(vo)}, nil
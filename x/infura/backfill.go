@@ -0,0 +1,218 @@
+package infura
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	evm "github.com/okex/exchain/x/evm/watcher"
+	"github.com/okex/exchain/x/infura/types"
+)
+
+// checkpointInterval limits how often the checkpoint file is rewritten
+const checkpointInterval = time.Second
+
+// BackfillCheckpoint records the progress of a backfill, all the heights in [Start, Height] have been written
+type BackfillCheckpoint struct {
+	Start  int64 `json:"start"`
+	End    int64 `json:"end"`
+	Height int64 `json:"height"`
+}
+
+// Backfiller rebuilds the stream data of the committed blocks from the block store and the state db,
+// and writes them through the stream engine
+type Backfiller struct {
+	blockStore *store.BlockStore
+	stateDB    dbm.DB
+	txDecoder  sdk.TxDecoder
+	engine     types.IStreamUpsertEngine
+	logger     log.Logger
+}
+
+// NewBackfillStreamEngine creates the stream engine configured by the infura flags,
+// the engine must support upsert to write the blocks idempotently
+func NewBackfillStreamEngine(logger log.Logger) (types.IStreamUpsertEngine, error) {
+	engine, err := newStreamEngine(initConfig(), logger)
+	if err != nil {
+		return nil, err
+	}
+	upsertEngine, ok := engine.(types.IStreamUpsertEngine)
+	if !ok {
+		return nil, fmt.Errorf("stream engine %T doesn't support upsert", engine)
+	}
+	return upsertEngine, nil
+}
+
+func NewBackfiller(blockStore *store.BlockStore, stateDB dbm.DB, txDecoder sdk.TxDecoder,
+	engine types.IStreamUpsertEngine, logger log.Logger) *Backfiller {
+	return &Backfiller{
+		blockStore: blockStore,
+		stateDB:    stateDB,
+		txDecoder:  txDecoder,
+		engine:     engine,
+		logger:     logger,
+	}
+}
+
+// Run writes the blocks in [start, end] by the workers in parallel.
+// If the checkpoint file of the same range exists, it resumes from the height after the checkpoint.
+func (b *Backfiller) Run(start, end int64, workers int, checkpointPath string) error {
+	if start <= 0 || end < start {
+		return fmt.Errorf("invalid height range [%d, %d]", start, end)
+	}
+	if base, latest := b.blockStore.Base(), b.blockStore.Height(); start < base || end > latest {
+		return fmt.Errorf("height range [%d, %d] is out of the block store [%d, %d]", start, end, base, latest)
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	checkpoint := BackfillCheckpoint{Start: start, End: end, Height: start - 1}
+	if saved, err := loadCheckpoint(checkpointPath); err != nil {
+		return err
+	} else if saved != nil && saved.Start == start && saved.End == end {
+		checkpoint = *saved
+		b.logger.Info("resume backfill from checkpoint", "height", checkpoint.Height)
+	}
+	if checkpoint.Height >= end {
+		b.logger.Info("backfill has been finished", "start", start, "end", end)
+		return nil
+	}
+
+	heights := make(chan int64, workers)
+	results := make(chan backfillResult, workers)
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				results <- backfillResult{height: height, err: b.backfillHeight(height)}
+			}
+		}()
+	}
+	go func() {
+		defer close(heights)
+		for height := checkpoint.Height + 1; height <= end; height++ {
+			select {
+			case heights <- height:
+			case <-quit:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// the checkpoint only advances over the contiguous finished heights
+	finished := make(map[int64]bool)
+	lastSaved := time.Now()
+	var firstErr error
+	for result := range results {
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("backfill height %d failed: %s", result.height, result.err.Error())
+				close(quit)
+			}
+			continue
+		}
+		finished[result.height] = true
+		for finished[checkpoint.Height+1] {
+			delete(finished, checkpoint.Height+1)
+			checkpoint.Height++
+		}
+		if time.Since(lastSaved) >= checkpointInterval {
+			if err := saveCheckpoint(checkpointPath, checkpoint); err != nil {
+				b.logger.Error("save backfill checkpoint failed", "err", err)
+			}
+			lastSaved = time.Now()
+			b.logger.Info("backfill progress", "height", checkpoint.Height, "end", end)
+		}
+	}
+	if err := saveCheckpoint(checkpointPath, checkpoint); err != nil {
+		b.logger.Error("save backfill checkpoint failed", "err", err)
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	b.logger.Info("backfill finished", "start", start, "end", end)
+	return nil
+}
+
+type backfillResult struct {
+	height int64
+	err    error
+}
+
+func (b *Backfiller) backfillHeight(height int64) error {
+	data, err := b.streamData(height)
+	if err != nil {
+		return err
+	}
+	if !b.engine.Upsert(data) {
+		return fmt.Errorf("stream engine failed to write")
+	}
+	return nil
+}
+
+// streamData rebuilds the same stream data as the one produced by the watcher when the block is committed
+func (b *Backfiller) streamData(height int64) (types.StreamData, error) {
+	block := b.blockStore.LoadBlock(height)
+	if block == nil {
+		return types.StreamData{}, fmt.Errorf("block %d is not found", height)
+	}
+	abciResponses, err := sm.LoadABCIResponses(b.stateDB, height)
+	if err != nil {
+		return types.StreamData{}, err
+	}
+	evmBlock, txs, receipts, codes := evm.ReplayBlock(block, abciResponses.DeliverTxs, b.txDecoder)
+	return types.StreamData{
+		TransactionReceipts: receipts,
+		Block:               evmBlock,
+		Transactions:        txs,
+		ContractCodes:       codes,
+	}, nil
+}
+
+func loadCheckpoint(path string) (*BackfillCheckpoint, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	checkpoint := &BackfillCheckpoint{}
+	if err := json.Unmarshal(bz, checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %s", path, err.Error())
+	}
+	return checkpoint, nil
+}
+
+// saveCheckpoint replaces the checkpoint file by renaming, so it's never broken by a crash
+func saveCheckpoint(path string, checkpoint BackfillCheckpoint) error {
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, bz, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,220 @@
+package infura
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	abci "github.com/okex/exchain/libs/tendermint/abci/types"
+	"github.com/okex/exchain/libs/tendermint/crypto/tmhash"
+	"github.com/okex/exchain/libs/tendermint/libs/kv"
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	sm "github.com/okex/exchain/libs/tendermint/state"
+	"github.com/okex/exchain/libs/tendermint/store"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	dbm "github.com/okex/exchain/libs/tm-db"
+	evmtypes "github.com/okex/exchain/x/evm/types"
+	"github.com/okex/exchain/x/infura/types"
+)
+
+// mockUpsertEngine keeps the last data written of every block
+type mockUpsertEngine struct {
+	mtx     sync.Mutex
+	blocks  map[int64]types.EngineData
+	upserts []int64
+	failAt  int64
+}
+
+func newMockUpsertEngine() *mockUpsertEngine {
+	return &mockUpsertEngine{blocks: make(map[int64]types.EngineData)}
+}
+
+func (e *mockUpsertEngine) Write(data types.IStreamData) bool {
+	return e.Upsert(data)
+}
+
+func (e *mockUpsertEngine) Upsert(data types.IStreamData) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	engineData := data.ConvertEngineData()
+	height := int64(engineData.Block.Number)
+	if height == e.failAt {
+		return false
+	}
+	e.blocks[height] = engineData
+	e.upserts = append(e.upserts, height)
+	return true
+}
+
+// contractCode is the runtime code of the contract deployed in every test block
+var contractCode = []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+
+func contractAddress(height int64) ethcmn.Address {
+	return ethcmn.BigToAddress(big.NewInt(0x1000 + height))
+}
+
+// newTestBlockStore saves the blocks of [1, latest] with a contract creation tx and a transfer tx in each block
+func newTestBlockStore(t *testing.T, latest int64) (*store.BlockStore, dbm.DB) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	blockStore, stateDB := store.NewBlockStore(dbm.NewMemDB()), dbm.NewMemDB()
+	nonce := uint64(0)
+	for height := int64(1); height <= latest; height++ {
+		to := ethcmn.BytesToAddress([]byte{0x1})
+		msgs := []*evmtypes.MsgEthereumTx{
+			evmtypes.NewMsgEthereumTxContract(nonce, big.NewInt(0), 100000, big.NewInt(1), []byte{0x1}),
+			evmtypes.NewMsgEthereumTx(nonce+1, &to, big.NewInt(1), 21000, big.NewInt(1), nil),
+		}
+		nonce += 2
+
+		var txs tmtypes.Txs
+		var deliverTxs []*abci.ResponseDeliverTx
+		for i, msg := range msgs {
+			require.NoError(t, msg.Sign(big.NewInt(3), key))
+			txBytes, err := rlp.EncodeToBytes(msg)
+			require.NoError(t, err)
+			txs = append(txs, txBytes)
+
+			resultData := &evmtypes.ResultData{TxHash: ethcmn.BytesToHash(msg.TxHash())}
+			if i == 0 {
+				resultData.ContractAddress = contractAddress(height)
+				resultData.Ret = contractCode
+			}
+			data, err := evmtypes.EncodeResultData(resultData)
+			require.NoError(t, err)
+			deliverTxs = append(deliverTxs, &abci.ResponseDeliverTx{
+				Data:    data,
+				GasUsed: 21000,
+				Events: []abci.Event{{
+					Type:       sdk.EventTypeMessage,
+					Attributes: []kv.Pair{{Key: []byte(sdk.AttributeKeyModule), Value: []byte(evmtypes.AttributeValueCategory)}},
+				}},
+			})
+		}
+
+		block := tmtypes.MakeBlock(height, txs, tmtypes.NewCommit(height-1, 0, tmtypes.BlockID{}, nil), nil)
+		// the header without the validators hash has no hash
+		block.ValidatorsHash = tmhash.Sum([]byte("validators"))
+		blockStore.SaveBlock(block, block.MakePartSet(tmtypes.BlockPartSizeBytes), tmtypes.NewCommit(height, 0, tmtypes.BlockID{}, nil))
+		sm.SaveABCIResponses(stateDB, height, &sm.ABCIResponses{DeliverTxs: deliverTxs})
+	}
+	return blockStore, stateDB
+}
+
+func testTxDecoder(txBytes []byte, _ ...int64) (sdk.Tx, error) {
+	var msg evmtypes.MsgEthereumTx
+	if err := rlp.DecodeBytes(txBytes, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func TestBackfillerStreamData(t *testing.T) {
+	blockStore, stateDB := newTestBlockStore(t, 1)
+	backfiller := NewBackfiller(blockStore, stateDB, testTxDecoder, newMockUpsertEngine(), log.NewNopLogger())
+
+	data, err := backfiller.streamData(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), uint64(data.Block.Number))
+	require.Equal(t, 2, len(data.Transactions))
+	require.Equal(t, 2, len(data.TransactionReceipts))
+	// the code of the deployed contract is written like the live stream does
+	require.Equal(t, map[string][]byte{contractAddress(1).String(): contractCode}, data.ContractCodes)
+
+	_, err = backfiller.streamData(2)
+	require.Error(t, err)
+}
+
+func TestBackfillerRun(t *testing.T) {
+	blockStore, stateDB := newTestBlockStore(t, 8)
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	engine := newMockUpsertEngine()
+	backfiller := NewBackfiller(blockStore, stateDB, testTxDecoder, engine, log.NewNopLogger())
+
+	require.Error(t, backfiller.Run(0, 5, 2, checkpointPath))
+	require.Error(t, backfiller.Run(5, 4, 2, checkpointPath))
+	require.Error(t, backfiller.Run(1, 9, 2, checkpointPath))
+
+	require.NoError(t, backfiller.Run(1, 5, 3, checkpointPath))
+	require.Equal(t, 5, len(engine.blocks))
+	for height := int64(1); height <= 5; height++ {
+		data := engine.blocks[height]
+		require.Equal(t, 2, len(data.TransactionReceipts))
+		require.Equal(t, 1, len(data.ContractCodes))
+		require.Equal(t, contractAddress(height).String(), data.ContractCodes[0].Address)
+	}
+	checkpoint, err := loadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.Equal(t, BackfillCheckpoint{Start: 1, End: 5, Height: 5}, *checkpoint)
+
+	// the finished range is skipped
+	engine.upserts = nil
+	require.NoError(t, backfiller.Run(1, 5, 3, checkpointPath))
+	require.Empty(t, engine.upserts)
+
+	// the checkpoint of another range is ignored
+	require.NoError(t, backfiller.Run(4, 6, 1, checkpointPath))
+	require.Equal(t, []int64{4, 5, 6}, engine.upserts)
+}
+
+func TestBackfillerResume(t *testing.T) {
+	blockStore, stateDB := newTestBlockStore(t, 8)
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	engine := newMockUpsertEngine()
+	engine.failAt = 4
+	backfiller := NewBackfiller(blockStore, stateDB, testTxDecoder, engine, log.NewNopLogger())
+
+	// the checkpoint stops before the failed height, the heights written after it are written again on resume
+	require.Error(t, backfiller.Run(1, 8, 1, checkpointPath))
+	require.Equal(t, []int64{1, 2, 3}, engine.upserts[:3])
+	require.NotContains(t, engine.upserts, int64(4))
+	checkpoint, err := loadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), checkpoint.Height)
+
+	// the backfill resumes from the failed height
+	engine.failAt = 0
+	engine.upserts = nil
+	require.NoError(t, backfiller.Run(1, 8, 1, checkpointPath))
+	require.Equal(t, []int64{4, 5, 6, 7, 8}, engine.upserts)
+	require.Equal(t, 8, len(engine.blocks))
+
+	// the heights written by more workers are checkpointed contiguously
+	require.NoError(t, os.Remove(checkpointPath))
+	engine.failAt = 6
+	require.Error(t, backfiller.Run(1, 8, 4, checkpointPath))
+	checkpoint, err = loadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	require.True(t, checkpoint.Height < 6)
+}
+
+func TestBackfillCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "infura", "checkpoint.json")
+
+	checkpoint, err := loadCheckpoint(path)
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+
+	require.NoError(t, saveCheckpoint(path, BackfillCheckpoint{Start: 1, End: 10, Height: 3}))
+	require.NoError(t, saveCheckpoint(path, BackfillCheckpoint{Start: 1, End: 10, Height: 7}))
+	checkpoint, err = loadCheckpoint(path)
+	require.NoError(t, err)
+	require.Equal(t, BackfillCheckpoint{Start: 1, End: 10, Height: 7}, *checkpoint)
+	// the temporary file is renamed to the checkpoint
+	_, err = os.Stat(path + ".tmp")
+	require.True(t, os.IsNotExist(err))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0644))
+	_, err = loadCheckpoint(path)
+	require.Error(t, err)
+}
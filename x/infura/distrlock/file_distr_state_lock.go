@@ -0,0 +1,182 @@
+package distrlock
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+const (
+	lockFileSuffix  = ".lock"
+	stateFileSuffix = ".state"
+)
+
+type fileLock struct {
+	Locker   string `json:"locker"`
+	ExpireAt int64  `json:"expire_at"` // unix time in milliseconds
+}
+
+// FileDistributeStateService elects the leader by the file lock of a directory shared by the nodes on the same box.
+// The holder and the expiry of a lock are saved in the lock file, and they are read and written
+// under the exclusive file lock, so the nodes fetch the lock in turn like the redis SETNX.
+type FileDistributeStateService struct {
+	dir      string
+	logger   log.Logger
+	lockerID string // unique identifier of locker
+}
+
+func NewFileDistributeStateService(dir string, logger log.Logger, lockerID string) (*FileDistributeStateService, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileDistributeStateService{
+		dir:      dir,
+		logger:   logger,
+		lockerID: lockerID,
+	}, nil
+}
+
+func (s *FileDistributeStateService) GetLockerID() string {
+	return s.lockerID
+}
+
+func (s *FileDistributeStateService) GetDistState(stateKey string) string {
+	state, err := ioutil.ReadFile(s.statePath(stateKey))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Error("read infura state failed", "key", stateKey, "err", err)
+		}
+		return ""
+	}
+	return string(state)
+}
+
+func (s *FileDistributeStateService) SetDistState(stateKey string, stateValue string) error {
+	return s.writeState(stateKey, stateValue)
+}
+
+func (s *FileDistributeStateService) FetchDistLock(lockKey string, locker string, expiredInMS int) (bool, error) {
+	success := false
+	err := s.withLockFile(lockKey, func(f *os.File, lock *fileLock) error {
+		if lock != nil && time.Now().UnixMilli() < lock.ExpireAt {
+			return nil
+		}
+		success = true
+		return writeLock(f, &fileLock{
+			Locker:   locker,
+			ExpireAt: time.Now().Add(time.Duration(expiredInMS) * time.Millisecond).UnixMilli(),
+		})
+	})
+	return success && err == nil, err
+}
+
+func (s *FileDistributeStateService) ReleaseDistLock(lockKey string, locker string) (bool, error) {
+	success := false
+	err := s.withLockFile(lockKey, func(f *os.File, lock *fileLock) error {
+		if !isLockedBy(lock, locker) {
+			return nil
+		}
+		success = true
+		return writeLock(f, nil)
+	})
+	return success && err == nil, err
+}
+
+func (s *FileDistributeStateService) UnlockDistLockWithState(lockKey string, locker string, stateKey string, stateValue string) (bool, error) {
+	success := false
+	err := s.withLockFile(lockKey, func(f *os.File, lock *fileLock) error {
+		if !isLockedBy(lock, locker) {
+			return nil
+		}
+		if err := s.writeState(stateKey, stateValue); err != nil {
+			return err
+		}
+		success = true
+		return writeLock(f, nil)
+	})
+	return success && err == nil, err
+}
+
+// withLockFile reads the lock saved in the lock file and runs fn under the exclusive file lock,
+// the lock is nil if it's not held by anyone
+func (s *FileDistributeStateService) withLockFile(lockKey string, fn func(f *os.File, lock *fileLock) error) error {
+	f, err := os.OpenFile(filepath.Join(s.dir, lockKey+lockFileSuffix), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := lockFile(f); err != nil {
+		return err
+	}
+	defer unlockFile(f)
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	var lock *fileLock
+	if len(content) > 0 {
+		lock = &fileLock{}
+		if err := json.Unmarshal(content, lock); err != nil {
+			// the broken lock is treated as released
+			s.logger.Error("parse infura lock file failed", "key", lockKey, "err", err)
+			lock = nil
+		}
+	}
+	return fn(f, lock)
+}
+
+// writeLock overwrites the lock file with the lock, the file is emptied if lock is nil
+func writeLock(f *os.File, lock *fileLock) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if lock == nil {
+		return f.Sync()
+	}
+	content, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+func isLockedBy(lock *fileLock, locker string) bool {
+	return lock != nil && lock.Locker == locker && time.Now().UnixMilli() < lock.ExpireAt
+}
+
+// writeState replaces the state file by renaming, so the readers never see a partial state
+func (s *FileDistributeStateService) writeState(stateKey string, stateValue string) error {
+	tmp, err := ioutil.TempFile(s.dir, stateKey+stateFileSuffix+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(stateValue); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.statePath(stateKey))
+}
+
+func (s *FileDistributeStateService) statePath(stateKey string) string {
+	return filepath.Join(s.dir, stateKey+stateFileSuffix)
+}
@@ -0,0 +1,125 @@
+package distrlock
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+func newTestFileService(t *testing.T, dir string, lockerID string) *FileDistributeStateService {
+	s, err := NewFileDistributeStateService(dir, log.NewNopLogger(), lockerID)
+	require.NoError(t, err)
+	return s
+}
+
+func TestFileDistributeStateServiceLock(t *testing.T) {
+	dir := t.TempDir()
+	// the nodes on the same box share the lock directory
+	s0, s1 := newTestFileService(t, dir, "node0"), newTestFileService(t, dir, "node1")
+	require.Equal(t, "node0", s0.GetLockerID())
+
+	ok, err := s0.FetchDistLock("lock", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s1.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s1.ReleaseDistLock("lock", "node1")
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s0.ReleaseDistLock("lock", "node0")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = s1.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s0.FetchDistLock("other", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// a broken lock file is treated as released
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "broken"+lockFileSuffix), []byte("{"), 0644))
+	ok, err = s0.FetchDistLock("broken", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestFileDistributeStateServiceState(t *testing.T) {
+	dir := t.TempDir()
+	s0, s1 := newTestFileService(t, dir, "node0"), newTestFileService(t, dir, "node1")
+	require.Equal(t, "", s0.GetDistState("task"))
+	require.NoError(t, s0.SetDistState("task", "1"))
+	require.Equal(t, "1", s1.GetDistState("task"))
+
+	ok, err := s1.UnlockDistLockWithState("lock", "node1", "task", "2")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, "1", s0.GetDistState("task"))
+
+	ok, err = s1.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s1.UnlockDistLockWithState("lock", "node1", "task", "2")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "2", s0.GetDistState("task"))
+
+	ok, err = s0.FetchDistLock("lock", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the state survives the restart of the service
+	require.Equal(t, "2", newTestFileService(t, dir, "node2").GetDistState("task"))
+}
+
+func TestFileDistributeStateServiceExpiry(t *testing.T) {
+	dir := t.TempDir()
+	s0, s1 := newTestFileService(t, dir, "node0"), newTestFileService(t, dir, "node1")
+	ok, err := s0.FetchDistLock("lock", "node0", 50)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	time.Sleep(100 * time.Millisecond)
+	ok, err = s0.ReleaseDistLock("lock", "node0")
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s1.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s0.UnlockDistLockWithState("lock", "node0", "task", "1")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, "", s1.GetDistState("task"))
+}
+
+func TestFileDistributeStateServiceContention(t *testing.T) {
+	dir := t.TempDir()
+
+	var wg sync.WaitGroup
+	var fetched int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			locker := fmt.Sprintf("node%d", i)
+			s, err := NewFileDistributeStateService(dir, log.NewNopLogger(), locker)
+			require.NoError(t, err)
+			ok, err := s.FetchDistLock("lock", locker, 60000)
+			require.NoError(t, err)
+			if ok {
+				atomic.AddInt32(&fetched, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), fetched)
+}
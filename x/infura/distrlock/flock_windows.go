@@ -0,0 +1,19 @@
+//go:build windows
+// +build windows
+
+package distrlock
+
+import (
+	"errors"
+	"os"
+)
+
+var errFileLockUnsupported = errors.New("file lock is not supported on windows")
+
+func lockFile(f *os.File) error {
+	return errFileLockUnsupported
+}
+
+func unlockFile(f *os.File) error {
+	return errFileLockUnsupported
+}
@@ -0,0 +1,88 @@
+package distrlock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+type localLock struct {
+	locker   string
+	expireAt time.Time
+}
+
+// LocalDistributeStateService keeps the locks and the states in memory,
+// it's used by the single node setups and the integration tests
+type LocalDistributeStateService struct {
+	mtx      sync.Mutex
+	states   map[string]string
+	locks    map[string]localLock
+	logger   log.Logger
+	lockerID string // unique identifier of locker
+}
+
+func NewLocalDistributeStateService(logger log.Logger, lockerID string) *LocalDistributeStateService {
+	return &LocalDistributeStateService{
+		states:   make(map[string]string),
+		locks:    make(map[string]localLock),
+		logger:   logger,
+		lockerID: lockerID,
+	}
+}
+
+func (s *LocalDistributeStateService) GetLockerID() string {
+	return s.lockerID
+}
+
+func (s *LocalDistributeStateService) GetDistState(stateKey string) string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.states[stateKey]
+}
+
+func (s *LocalDistributeStateService) SetDistState(stateKey string, stateValue string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.states[stateKey] = stateValue
+	return nil
+}
+
+func (s *LocalDistributeStateService) FetchDistLock(lockKey string, locker string, expiredInMS int) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if lock, ok := s.locks[lockKey]; ok && time.Now().Before(lock.expireAt) {
+		return false, nil
+	}
+	s.locks[lockKey] = localLock{
+		locker:   locker,
+		expireAt: time.Now().Add(time.Duration(expiredInMS) * time.Millisecond),
+	}
+	return true, nil
+}
+
+func (s *LocalDistributeStateService) ReleaseDistLock(lockKey string, locker string) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if !s.isLockedBy(lockKey, locker) {
+		return false, nil
+	}
+	delete(s.locks, lockKey)
+	return true, nil
+}
+
+func (s *LocalDistributeStateService) UnlockDistLockWithState(lockKey string, locker string, stateKey string, stateValue string) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if !s.isLockedBy(lockKey, locker) {
+		return false, nil
+	}
+	s.states[stateKey] = stateValue
+	delete(s.locks, lockKey)
+	return true, nil
+}
+
+func (s *LocalDistributeStateService) isLockedBy(lockKey string, locker string) bool {
+	lock, ok := s.locks[lockKey]
+	return ok && lock.locker == locker && time.Now().Before(lock.expireAt)
+}
@@ -0,0 +1,107 @@
+package distrlock
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+)
+
+func TestLocalDistributeStateServiceLock(t *testing.T) {
+	s := NewLocalDistributeStateService(log.NewNopLogger(), "node0")
+	require.Equal(t, "node0", s.GetLockerID())
+
+	ok, err := s.FetchDistLock("lock", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	// the lock is held until it's released
+	ok, err = s.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.False(t, ok)
+	// only the holder releases the lock
+	ok, err = s.ReleaseDistLock("lock", "node1")
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s.ReleaseDistLock("lock", "node0")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = s.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	// the locks of different keys are independent
+	ok, err = s.FetchDistLock("other", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestLocalDistributeStateServiceState(t *testing.T) {
+	s := NewLocalDistributeStateService(log.NewNopLogger(), "node0")
+	require.Equal(t, "", s.GetDistState("task"))
+	require.NoError(t, s.SetDistState("task", "1"))
+	require.Equal(t, "1", s.GetDistState("task"))
+
+	// the state is only written by the holder of the lock
+	ok, err := s.UnlockDistLockWithState("lock", "node0", "task", "2")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, "1", s.GetDistState("task"))
+
+	ok, err = s.FetchDistLock("lock", "node0", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s.UnlockDistLockWithState("lock", "node0", "task", "2")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "2", s.GetDistState("task"))
+
+	// the lock is released with the state
+	ok, err = s.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestLocalDistributeStateServiceExpiry(t *testing.T) {
+	s := NewLocalDistributeStateService(log.NewNopLogger(), "node0")
+	ok, err := s.FetchDistLock("lock", "node0", 50)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	time.Sleep(100 * time.Millisecond)
+	// the expired lock is fetched by others, and can't be released by the old holder
+	ok, err = s.ReleaseDistLock("lock", "node0")
+	require.NoError(t, err)
+	require.False(t, ok)
+	ok, err = s.FetchDistLock("lock", "node1", 60000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = s.UnlockDistLockWithState("lock", "node0", "task", "1")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, "", s.GetDistState("task"))
+}
+
+func TestLocalDistributeStateServiceContention(t *testing.T) {
+	s := NewLocalDistributeStateService(log.NewNopLogger(), "node0")
+
+	var wg sync.WaitGroup
+	var fetched int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ok, err := s.FetchDistLock("lock", fmt.Sprintf("node%d", i), 60000)
+			require.NoError(t, err)
+			if ok {
+				atomic.AddInt32(&fetched, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), fetched)
+}
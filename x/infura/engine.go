@@ -3,24 +3,59 @@ package infura
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/okex/exchain/libs/tendermint/libs/log"
 	"github.com/okex/exchain/x/infura/types"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
 )
 
 const batchSize = 1000
 
+const (
+	EngineTypeMySQL    = "mysql"
+	EngineTypePostgres = "postgres"
+	EngineTypeSQLite   = "sqlite"
+	EngineTypeFile     = "file"
+)
+
 func newStreamEngine(cfg *types.Config, logger log.Logger) (types.IStreamEngine, error) {
-	if cfg.MysqlUrl == "" {
-		return nil, errors.New("infura.mysql-url is empty")
+	switch cfg.EngineType {
+	case "", EngineTypeMySQL:
+		if cfg.MysqlUrl == "" {
+			return nil, errors.New("infura.mysql-url is empty")
+		}
+		return newMySQLEngine(cfg.MysqlUrl, cfg.MysqlUser, cfg.MysqlPass, cfg.MysqlDB, logger)
+	case EngineTypePostgres:
+		if cfg.PostgresUrl == "" {
+			return nil, errors.New("infura.postgres-url is empty")
+		}
+		return newPostgresEngine(cfg.PostgresUrl, logger)
+	case EngineTypeSQLite:
+		if cfg.SQLitePath == "" {
+			return nil, errors.New("infura.sqlite-path is empty")
+		}
+		return newSQLiteEngine(cfg.SQLitePath, logger)
+	case EngineTypeFile:
+		if cfg.FilePath == "" {
+			return nil, errors.New("infura.file-path is empty")
+		}
+		return newFileEngine(cfg.FilePath, logger)
+	default:
+		return nil, fmt.Errorf("unsupported infura.engine-type: %s", cfg.EngineType)
 	}
-	return newMySQLEngine(cfg.MysqlUrl, cfg.MysqlUser, cfg.MysqlPass, cfg.MysqlDB, logger)
 }
 
-type MySQLEngine struct {
+// SQLEngine writes the stream data into a relational database by gorm,
+// all the databases share the same models and batching semantics
+type SQLEngine struct {
+	name   string
 	db     *gorm.DB
 	logger log.Logger
 }
@@ -28,26 +63,62 @@ type MySQLEngine struct {
 func newMySQLEngine(url, user, pass, dbName string, l log.Logger) (types.IStreamEngine, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		user, pass, url, dbName)
+	return newSQLEngine("MySQL", mysql.Open(dsn), l)
+}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+// newPostgresEngine creates the engine with the dsn of PostgreSQL,
+// e.g. "host=localhost user=infura password=infura dbname=infura port=5432 sslmode=disable"
+func newPostgresEngine(dsn string, l log.Logger) (types.IStreamEngine, error) {
+	return newSQLEngine("PostgreSQL", &postgresDialector{Dialector: postgres.Open(dsn).(*postgres.Dialector)}, l)
+}
+
+func newSQLEngine(name string, dialector gorm.Dialector, l log.Logger) (types.IStreamEngine, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{
 		SkipDefaultTransaction: true,
 		Logger:                 logger.Default.LogMode(logger.Info),
 	})
 	if err != nil {
 		return nil, err
 	}
-	db.AutoMigrate(&types.TransactionReceipt{}, &types.TransactionLog{},
+	err = db.AutoMigrate(&types.TransactionReceipt{}, &types.TransactionLog{},
 		&types.LogTopic{}, &types.Block{}, &types.Transaction{}, &types.ContractCode{})
-	return &MySQLEngine{
+	if err != nil {
+		return nil, err
+	}
+	return &SQLEngine{
+		name:   name,
 		db:     db,
 		logger: l,
 	}, nil
 }
 
-func (e *MySQLEngine) Write(streamData types.IStreamData) bool {
-	e.logger.Debug("Begin MySqlEngine write")
+func (e *SQLEngine) Write(streamData types.IStreamData) bool {
+	e.logger.Debug(fmt.Sprintf("Begin %sEngine write", e.name))
+	data := streamData.ConvertEngineData()
+	trx := e.db.Begin()
+	if err := writeEngineData(trx, data); err != nil {
+		return e.rollbackWithError(trx, err)
+	}
+	trx.Commit()
+	e.logger.Debug(fmt.Sprintf("End %sEngine write", e.name))
+	return true
+}
+
+// Upsert replaces the data of the block written before, so the block can be written repeatedly
+func (e *SQLEngine) Upsert(streamData types.IStreamData) bool {
 	data := streamData.ConvertEngineData()
 	trx := e.db.Begin()
+	if err := deleteEngineData(trx, data.Block.Number); err != nil {
+		return e.rollbackWithError(trx, err)
+	}
+	if err := writeEngineData(trx, data); err != nil {
+		return e.rollbackWithError(trx, err)
+	}
+	trx.Commit()
+	return true
+}
+
+func writeEngineData(trx *gorm.DB, data types.EngineData) error {
 	// write TransactionReceipts
 	for i := 0; i < len(data.TransactionReceipts); i += batchSize {
 		end := i + batchSize
@@ -56,14 +127,14 @@ func (e *MySQLEngine) Write(streamData types.IStreamData) bool {
 		}
 		ret := trx.CreateInBatches(data.TransactionReceipts[i:end], len(data.TransactionReceipts[i:end]))
 		if ret.Error != nil {
-			return e.rollbackWithError(trx, ret.Error)
+			return ret.Error
 		}
 	}
 
 	// write Block
 	ret := trx.Omit("Transactions").Create(data.Block)
 	if ret.Error != nil {
-		return e.rollbackWithError(trx, ret.Error)
+		return ret.Error
 	}
 
 	// write Transactions
@@ -74,25 +145,63 @@ func (e *MySQLEngine) Write(streamData types.IStreamData) bool {
 		}
 		ret := trx.CreateInBatches(data.Block.Transactions[i:end], len(data.Block.Transactions[i:end]))
 		if ret.Error != nil {
-			return e.rollbackWithError(trx, ret.Error)
+			return ret.Error
 		}
 	}
 
-	// write contract code
+	// write contract code, the code of a contract redeployed at the same address replaces the one written before
 	for _, code := range data.ContractCodes {
-		ret := trx.Create(code)
+		ret := trx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "code", "block_number"}),
+		}).Create(code)
 		if ret.Error != nil {
-			return e.rollbackWithError(trx, ret.Error)
+			return ret.Error
 		}
 	}
+	return nil
+}
 
-	trx.Commit()
-	e.logger.Debug("End MySqlEngine write")
-	return true
+// deleteEngineData deletes all the data of the block permanently
+func deleteEngineData(trx *gorm.DB, height int64) error {
+	logIDs := trx.Unscoped().Model(&types.TransactionLog{}).Select("id").Where("block_number = ?", height)
+	if err := trx.Unscoped().Where("transaction_log_id IN (?)", logIDs).Delete(&types.LogTopic{}).Error; err != nil {
+		return err
+	}
+	models := []interface{}{&types.TransactionLog{}, &types.TransactionReceipt{}, &types.Transaction{}, &types.ContractCode{}}
+	for _, model := range models {
+		if err := trx.Unscoped().Where("block_number = ?", height).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return trx.Unscoped().Where("number = ?", height).Delete(&types.Block{}).Error
 }
 
-func (e *MySQLEngine) rollbackWithError(trx *gorm.DB, err error) bool {
+func (e *SQLEngine) rollbackWithError(trx *gorm.DB, err error) bool {
 	trx.Rollback()
 	e.logger.Error(err.Error())
 	return false
 }
+
+// postgresDialector translates the MySQL column types declared by the models into PostgreSQL ones
+type postgresDialector struct {
+	*postgres.Dialector
+}
+
+func (d postgresDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return postgres.Migrator{Migrator: migrator.Migrator{Config: migrator.Config{
+		DB:                          db,
+		Dialector:                   d,
+		CreateIndexAfterCreateTable: true,
+	}}}
+}
+
+func (d postgresDialector) DataTypeOf(field *schema.Field) string {
+	switch strings.ToLower(string(field.DataType)) {
+	case "tinyint(4)":
+		return "smallint"
+	case "int(11)":
+		return "bigint"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
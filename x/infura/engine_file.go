@@ -0,0 +1,135 @@
+package infura
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/infura/types"
+)
+
+// fileRecord is a line of the JSON-lines file, table is the name of the table the model is written into by SQLEngine
+type fileRecord struct {
+	Table string      `json:"table"`
+	Data  interface{} `json:"data"`
+}
+
+// FileEngine appends the stream data into a JSON-lines file, one model per line.
+// The data of a block is written as a whole, the file is truncated back if any write fails.
+type FileEngine struct {
+	mtx    sync.Mutex // the blocks may be written by the backfill workers concurrently
+	file   *os.File
+	logger log.Logger
+}
+
+func newFileEngine(path string, l log.Logger) (types.IStreamEngine, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FileEngine{
+		file:   file,
+		logger: l,
+	}, nil
+}
+
+func (e *FileEngine) Write(streamData types.IStreamData) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.logger.Debug("Begin FileEngine write")
+	data := streamData.ConvertEngineData()
+	offset, err := e.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		e.logger.Error(err.Error())
+		return false
+	}
+	writer := bufio.NewWriter(e.file)
+	encoder := json.NewEncoder(writer)
+	encode := func(table string, model interface{}) error {
+		return encoder.Encode(fileRecord{Table: table, Data: model})
+	}
+
+	// write TransactionReceipts
+	for i := 0; i < len(data.TransactionReceipts); i += batchSize {
+		end := i + batchSize
+		if end > len(data.TransactionReceipts) {
+			end = len(data.TransactionReceipts)
+		}
+		for _, receipt := range data.TransactionReceipts[i:end] {
+			if err := encode("transaction_receipts", receipt); err != nil {
+				return e.rollbackWithError(offset, err)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return e.rollbackWithError(offset, err)
+		}
+	}
+
+	// write Block
+	block := *data.Block
+	block.Transactions = nil
+	if err := encode("blocks", &block); err != nil {
+		return e.rollbackWithError(offset, err)
+	}
+
+	// write Transactions
+	for i := 0; i < len(data.Block.Transactions); i += batchSize {
+		end := i + batchSize
+		if end > len(data.Block.Transactions) {
+			end = len(data.Block.Transactions)
+		}
+		for _, tx := range data.Block.Transactions[i:end] {
+			if err := encode("transactions", tx); err != nil {
+				return e.rollbackWithError(offset, err)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return e.rollbackWithError(offset, err)
+		}
+	}
+
+	// write contract code
+	for _, code := range data.ContractCodes {
+		if err := encode("contract_codes", code); err != nil {
+			return e.rollbackWithError(offset, err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return e.rollbackWithError(offset, err)
+	}
+	if err := e.file.Sync(); err != nil {
+		return e.rollbackWithError(offset, err)
+	}
+	e.logger.Debug("End FileEngine write")
+	return true
+}
+
+// Upsert appends the data of the block again since the file is append-only,
+// the consumers should keep the last records of a block
+func (e *FileEngine) Upsert(streamData types.IStreamData) bool {
+	return e.Write(streamData)
+}
+
+// rollbackWithError truncates the file to the offset before the failed write
+func (e *FileEngine) rollbackWithError(offset int64, err error) bool {
+	e.logger.Error(err.Error())
+	if truncErr := e.file.Truncate(offset); truncErr != nil {
+		e.logger.Error(truncErr.Error())
+	}
+	if _, seekErr := e.file.Seek(offset, io.SeekStart); seekErr != nil {
+		e.logger.Error(seekErr.Error())
+	}
+	return false
+}
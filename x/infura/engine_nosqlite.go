@@ -0,0 +1,16 @@
+//go:build !cgo || !sqlite
+// +build !cgo !sqlite
+
+package infura
+
+import (
+	"errors"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/infura/types"
+)
+
+// newSQLiteEngine fails without cgo and the sqlite build tag, the SQLite driver is a cgo library
+func newSQLiteEngine(string, log.Logger) (types.IStreamEngine, error) {
+	return nil, errors.New("the sqlite engine is not built in, build exchaind with cgo and the sqlite build tag (WITH_SQLITE=true)")
+}
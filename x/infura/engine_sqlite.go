@@ -0,0 +1,15 @@
+//go:build cgo && sqlite
+// +build cgo,sqlite
+
+package infura
+
+import (
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/infura/types"
+	"gorm.io/driver/sqlite"
+)
+
+// newSQLiteEngine creates the engine with an embedded SQLite database file
+func newSQLiteEngine(path string, l log.Logger) (types.IStreamEngine, error) {
+	return newSQLEngine("SQLite", sqlite.Open(path), l)
+}
@@ -0,0 +1,60 @@
+//go:build cgo && sqlite
+// +build cgo,sqlite
+
+package infura
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/okex/exchain/libs/tendermint/libs/log"
+	"github.com/okex/exchain/x/infura/types"
+)
+
+func TestSQLEngineUpsert(t *testing.T) {
+	engine, err := newSQLiteEngine(filepath.Join(t.TempDir(), "infura.db"), log.NewNopLogger())
+	require.NoError(t, err)
+	sqlEngine := engine.(*SQLEngine)
+
+	blockStore, stateDB := newTestBlockStore(t, 2)
+	backfiller := NewBackfiller(blockStore, stateDB, testTxDecoder, sqlEngine, log.NewNopLogger())
+	data1, err := backfiller.streamData(1)
+	require.NoError(t, err)
+	data2, err := backfiller.streamData(2)
+	require.NoError(t, err)
+
+	count := func(model interface{}) int64 {
+		var n int64
+		require.NoError(t, sqlEngine.db.Model(model).Count(&n).Error)
+		return n
+	}
+	// the block written repeatedly replaces the data written before
+	for i := 0; i < 3; i++ {
+		require.True(t, sqlEngine.Upsert(data1))
+		require.Equal(t, int64(1), count(&types.Block{}))
+		require.Equal(t, int64(2), count(&types.Transaction{}))
+		require.Equal(t, int64(2), count(&types.TransactionReceipt{}))
+		require.Equal(t, int64(1), count(&types.ContractCode{}))
+	}
+
+	// the data of the other blocks are kept
+	require.True(t, sqlEngine.Upsert(data2))
+	require.True(t, sqlEngine.Upsert(data1))
+	require.Equal(t, int64(2), count(&types.Block{}))
+	require.Equal(t, int64(4), count(&types.Transaction{}))
+	require.Equal(t, int64(4), count(&types.TransactionReceipt{}))
+	require.Equal(t, int64(2), count(&types.ContractCode{}))
+
+	// a contract redeployed at the same address replaces the code written before
+	redeployedCode := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0x00}
+	data2.ContractCodes[contractAddress(1).String()] = redeployedCode
+	require.True(t, sqlEngine.Upsert(data2))
+	require.Equal(t, int64(2), count(&types.ContractCode{}))
+	var code types.ContractCode
+	require.NoError(t, sqlEngine.db.Where("address = ?", contractAddress(1).String()).First(&code).Error)
+	require.Equal(t, hexutil.Encode(redeployedCode), code.Code)
+	require.Equal(t, int64(2), code.BlockNumber)
+}
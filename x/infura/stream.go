@@ -25,9 +25,21 @@ const (
 	FlagMysqlUser      = "infura.mysql-user"
 	FlagMysqlPass      = "infura.mysql-pass"
 	FlagMysqlDB        = "infura.mysql-db"
+	FlagEngineType     = "infura.engine-type"
+	FlagPostgresUrl    = "infura.postgres-url"
+	FlagSQLitePath     = "infura.sqlite-path"
+	FlagFilePath       = "infura.file-path"
+	FlagLockType       = "infura.lock-type"
+	FlagLockDir        = "infura.lock-dir"
 	FlagCacheQueueSize = "infura.cache-queue-size"
 )
 
+const (
+	LockTypeRedis = "redis"
+	LockTypeLocal = "local"
+	LockTypeFile  = "file"
+)
+
 // Stream maintains the infura engine
 type Stream struct {
 	enable     bool
@@ -57,9 +69,9 @@ func NewStream(logger log.Logger) *Stream {
 	}
 	se.engine = engine
 
-	scheduler, err := newRedisLockService(se.cfg.RedisUrl, se.cfg.RedisAuth, se.cfg.RedisDB, logger)
+	scheduler, err := newDistributeStateService(se.cfg, logger)
 	if err != nil {
-		errStr := fmt.Sprintf("parse redis scheduler failed error: %s, redis url: %s", err.Error(), se.cfg.RedisUrl)
+		errStr := fmt.Sprintf("parse %s scheduler failed error: %s", se.cfg.LockType, err.Error())
 		logger.Error(errStr)
 		panic(errStr)
 	}
@@ -84,10 +96,32 @@ func initConfig() *types.Config {
 		MysqlUser:      viper.GetString(FlagMysqlUser),
 		MysqlPass:      viper.GetString(FlagMysqlPass),
 		MysqlDB:        viper.GetString(FlagMysqlDB),
+		EngineType:     viper.GetString(FlagEngineType),
+		PostgresUrl:    viper.GetString(FlagPostgresUrl),
+		SQLitePath:     viper.GetString(FlagSQLitePath),
+		FilePath:       viper.GetString(FlagFilePath),
+		LockType:       viper.GetString(FlagLockType),
+		LockDir:        viper.GetString(FlagLockDir),
 		CacheQueueSize: viper.GetInt(FlagCacheQueueSize),
 	}
 }
 
+func newDistributeStateService(cfg *types.Config, logger log.Logger) (types.IDistributeStateService, error) {
+	switch cfg.LockType {
+	case "", LockTypeRedis:
+		return newRedisLockService(cfg.RedisUrl, cfg.RedisAuth, cfg.RedisDB, logger)
+	case LockTypeLocal:
+		return distrlock.NewLocalDistributeStateService(logger, uuid.New().String()), nil
+	case LockTypeFile:
+		if cfg.LockDir == "" {
+			return nil, fmt.Errorf("infura.lock-dir is empty")
+		}
+		return distrlock.NewFileDistributeStateService(cfg.LockDir, logger, uuid.New().String())
+	default:
+		return nil, fmt.Errorf("unsupported infura.lock-type: %s", cfg.LockType)
+	}
+}
+
 func newRedisLockService(redisURL string, redisPass string, db int, logger log.Logger) (types.IDistributeStateService, error) {
 	if redisURL == "" {
 		return nil, fmt.Errorf("no valid redisUrl found, no IDistributeStateService is created, redisUrl: %s", redisURL)
@@ -8,5 +8,11 @@ type Config struct {
 	MysqlUser      string
 	MysqlPass      string
 	MysqlDB        string
+	EngineType     string
+	PostgresUrl    string
+	SQLitePath     string
+	FilePath       string
+	LockType       string
+	LockDir        string
 	CacheQueueSize int
 }
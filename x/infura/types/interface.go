@@ -5,6 +5,12 @@ type IStreamEngine interface {
 	Write(data IStreamData) bool
 }
 
+// IStreamUpsertEngine writes the data idempotently, the data of the same block written before is replaced
+type IStreamUpsertEngine interface {
+	IStreamEngine
+	Upsert(data IStreamData) bool
+}
+
 type IStreamData interface {
 	ConvertEngineData() EngineData
 }
@@ -1,7 +1,10 @@
 package order
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/common/perf"
@@ -12,7 +15,8 @@ import (
 
 // EndBlocker called every block
 // 1. execute matching engine
-// 2. flush cache
+// 2. emit depth book updates
+// 3. flush cache
 func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	seq := perf.GetPerf().OnEndBlockEnter(ctx, types.ModuleName)
@@ -20,6 +24,11 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 
 	match.GetEngine().Run(ctx, keeper)
 
+	// the depth books in store are still the ones of the last block before flushing cache
+	if types.EnableDepthBookEvent {
+		emitDepthBookEvents(ctx, keeper)
+	}
+
 	// flush cache at the end
 	keeper.Cache2Disk(ctx)
 
@@ -41,3 +50,37 @@ func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
 	message += tailmsg("PartialFillNum", ret.PartialFillNum)
 	perf.GetPerf().EnqueueMsg(message)
 }
+
+// emitDepthBookEvents emits the changed price levels and the fills of the products updated in the block
+func emitDepthBookEvents(ctx sdk.Context, keeper keeper.Keeper) {
+	products := keeper.GetUpdatedDepthbookKeys()
+	sort.Strings(products)
+
+	blockMatchResult := keeper.GetBlockMatchResult()
+	for _, product := range products {
+		update := types.DepthBookUpdate{
+			Product: product,
+			Height:  ctx.BlockHeight(),
+			Items:   types.DiffDepthBook(keeper.GetDepthBookFromDB(ctx, product), keeper.GetDepthBookCopy(product)),
+		}
+		if blockMatchResult != nil && blockMatchResult.BlockHeight == ctx.BlockHeight() {
+			if result, ok := blockMatchResult.ResultMap[product]; ok {
+				update.Fills = &result
+			}
+		}
+		if len(update.Items) == 0 && update.Fills == nil {
+			continue
+		}
+
+		bz, err := json.Marshal(update)
+		if err != nil {
+			ctx.Logger().Error("failed to marshal depth book update", "product", product, "error", err)
+			continue
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeDepthBook,
+			sdk.NewAttribute(types.AttributeKeyProduct, product),
+			sdk.NewAttribute(types.AttributeKeyDepthBookUpdate, string(bz)),
+		))
+	}
+}
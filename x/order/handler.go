@@ -13,6 +13,7 @@ import (
 	"github.com/okex/exchain/x/common"
 	"github.com/okex/exchain/x/common/perf"
 	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/types"
 	"github.com/willf/bitset"
 )
@@ -33,8 +34,10 @@ func CalculateGas(msg sdk.Msg, params *types.Params) (gas uint64) {
 // NewOrderHandler returns the handler with version 0.
 func NewOrderHandler(keeper keeper.Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
-		// disable order tx handler
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Order messages are not allowd.")
+		// order tx handler is disabled until Venus12, and then it only accepts the orders of the continuous auction products
+		if !types2.HigherThanVenus12(ctx.BlockHeight()) {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Order messages are not allowd.")
+		}
 
 		gas := CalculateGas(msg, keeper.GetParams(ctx))
 
@@ -84,6 +87,9 @@ func checkOrderNewMsg(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgNewOrd
 	if tokenPair == nil {
 		return types.ErrTokenPairNotExist(msg.Product)
 	}
+	if !keeper.GetDexKeeper().IsContinuousAuctionProduct(ctx, msg.Product) {
+		return types.ErrNotContinuousAuctionProduct(msg.Product)
+	}
 
 	// check if the order is involved with the tokenpair in dex Delist
 	isDelisting, err := keeper.GetDexKeeper().CheckTokenPairUnderDexDelist(ctx, msg.Product)
@@ -156,6 +162,10 @@ func handleNewOrder(ctx sdk.Context, k Keeper, sender sdk.AccAddress,
 		Error:   err,
 		OrderID: order.OrderID,
 	}
+	if err == nil {
+		// orders of continuous auction products cross the depth book immediately
+		res.Deals = continuousauction.MatchNewOrder(ctxItem, k, order)
+	}
 
 	if err == nil {
 		logger.Debug(fmt.Sprintf("BlockHeight<%d>, handler<%s>\n"+
@@ -325,6 +335,9 @@ func validateCancelOrder(ctx sdk.Context, keeper keeper.Keeper, msg types.MsgCan
 	if !order.Sender.Equals(msg.Sender) {
 		return types.ErrNotOrderOwner(msg.OrderID)
 	}
+	if !keeper.GetDexKeeper().IsContinuousAuctionProduct(ctx, order.Product) {
+		return types.ErrNotContinuousAuctionProduct(order.Product)
+	}
 	if keeper.IsProductLocked(ctx, order.Product) {
 		return types.ErrIsProductLocked(order.Product)
 	}
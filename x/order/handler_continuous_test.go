@@ -0,0 +1,94 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	"github.com/okex/exchain/x/dex"
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleMsgNewOrdersContinuousAuction(t *testing.T) {
+	viper.Set(flags.FlagHome, t.TempDir())
+	testInput := keeper.CreateTestInput(t)
+	k := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	err := testInput.DexKeeper.SaveTokenPair(ctx, dex.GetBuiltInTokenPair())
+	require.Nil(t, err)
+
+	handler := NewOrderHandler(k)
+	sellMsg := types.NewMsgNewOrders(testInput.TestAddrs[1], []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.NewOrderItem(types.TestTokenPair, types.SellOrder, "9.0", "0.5"),
+	})
+
+	// order messages are not allowed until Venus12
+	_, err = handler(ctx, sellMsg)
+	require.NotNil(t, err)
+
+	tmtypes.InitMilestoneVenus12Height(1)
+	defer tmtypes.InitMilestoneVenus12Height(0)
+	// only the orders of the continuous auction products are accepted
+	_, err = handler(ctx, sellMsg)
+	require.NotNil(t, err)
+
+	dexParams := *dex.DefaultParams()
+	dexParams.ContinuousAuctionProducts = []string{types.TestTokenPair}
+	testInput.DexKeeper.SetParams(ctx, dexParams)
+	// a taker makes at most MaxDealsPerBlock deals, including the deals of the makers
+	params := k.GetParams(ctx)
+	params.MaxDealsPerBlock = 2
+	k.SetParams(ctx, params)
+
+	_, err = handler(ctx, sellMsg)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+
+	// the buy order takes the sell order of the lowest price as soon as it's placed, then it reaches
+	// the deals limit and its unfilled quantity still crossing the book is cancelled
+	_, err = handler(ctx, types.NewMsgNewOrders(testInput.TestAddrs[0], []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+	}))
+	require.Nil(t, err)
+	sell0 := k.GetOrder(ctx, types.FormatOrderID(10, 1))
+	sell1 := k.GetOrder(ctx, types.FormatOrderID(10, 2))
+	buy := k.GetOrder(ctx, types.FormatOrderID(10, 3))
+	require.EqualValues(t, types.OrderStatusOpen, sell0.Status)
+	require.EqualValues(t, types.OrderStatusFilled, sell1.Status)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, buy.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.5"), buy.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.0"), k.GetLastPrice(ctx, types.TestTokenPair))
+
+	book := k.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), book.Items[0].Price)
+	require.EqualValues(t, sdk.MustNewDecFromStr("1.0"), book.Items[0].SellQuantity)
+	require.True(t, book.Items[0].BuyQuantity.IsZero())
+
+	// a buy order filled within the deals limit takes a part of the remaining sell order
+	_, err = handler(ctx, types.NewMsgNewOrders(testInput.TestAddrs[0], []types.OrderItem{
+		types.NewOrderItem(types.TestTokenPair, types.BuyOrder, "10.0", "0.4"),
+	}))
+	require.Nil(t, err)
+	sell0 = k.GetOrder(ctx, types.FormatOrderID(10, 1))
+	buy = k.GetOrder(ctx, types.FormatOrderID(10, 4))
+	require.EqualValues(t, types.OrderStatusOpen, sell0.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.6"), sell0.RemainQuantity)
+	require.EqualValues(t, types.OrderStatusFilled, buy.Status)
+
+	book = k.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.6"), book.Items[0].SellQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), k.GetLastPrice(ctx, types.TestTokenPair))
+
+	// the orders of the continuous auction products can be cancelled
+	_, err = handler(ctx, types.NewMsgCancelOrders(testInput.TestAddrs[1], []string{types.FormatOrderID(10, 1)}))
+	require.Nil(t, err)
+	require.EqualValues(t, types.OrderStatusPartialFilledCancelled, k.GetOrder(ctx, types.FormatOrderID(10, 1)).Status)
+	require.Equal(t, 0, len(k.GetDepthBookCopy(types.TestTokenPair).Items))
+}
@@ -49,4 +49,5 @@ type DexKeeper interface {
 	GetLockedProductsCopy(ctx sdk.Context) *types.ProductLockMap
 	IsAnyProductLocked(ctx sdk.Context) bool
 	GetOperator(ctx sdk.Context, addr sdk.AccAddress) (operator dex.DEXOperator, isExist bool)
+	IsContinuousAuctionProduct(ctx sdk.Context, product string) bool
 }
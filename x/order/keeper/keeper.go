@@ -353,7 +353,7 @@ func (k Keeper) GetBlockMatchResult() *types.BlockMatchResult {
 
 // nolint
 func (k Keeper) SetBlockMatchResult(result *types.BlockMatchResult) {
-	if k.enableBackend {
+	if k.enableBackend || types.EnableDepthBookEvent {
 		k.cache.setBlockMatchResult(result)
 	}
 }
@@ -534,6 +534,25 @@ func (k Keeper) FilterDelistedProducts(ctx sdk.Context, products []string) []str
 	return cleanProducts
 }
 
+// GetAuctionType returns the auction type of the engine which matches the product
+func (k Keeper) GetAuctionType(ctx sdk.Context, product string) string {
+	if k.dexKeeper.IsContinuousAuctionProduct(ctx, product) {
+		return types.AuctionTypeContinuous
+	}
+	return types.AuctionTypePeriodic
+}
+
+// FilterProductsByAuctionType returns the products matched by the specified auction type
+func (k Keeper) FilterProductsByAuctionType(ctx sdk.Context, products []string, auctionType string) []string {
+	var filtered []string
+	for _, product := range products {
+		if k.GetAuctionType(ctx, product) == auctionType {
+			filtered = append(filtered, product)
+		}
+	}
+	return filtered
+}
+
 // nolint
 func (k Keeper) AddTxHandlerMsgResult(resultSet bitset.BitSet) {
 	if k.enableBackend {
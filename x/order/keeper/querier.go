@@ -73,6 +73,8 @@ type BookResItem struct {
 type BookRes struct {
 	Asks []BookResItem `json:"asks"`
 	Bids []BookResItem `json:"bids"`
+	// Height is the block height of the depth book, it's the sequence of the depth book websocket subscription
+	Height int64 `json:"height"`
 }
 
 // nolint: unparam
@@ -110,8 +112,9 @@ func queryDepthBook(ctx sdk.Context, path []string, req abci.RequestQuery, keepe
 	}
 
 	bookRes := BookRes{
-		Asks: asks,
-		Bids: bids,
+		Asks:   asks,
+		Bids:   bids,
+		Height: ctx.BlockHeight(),
 	}
 	bz := keeper.cdc.MustMarshalJSON(bookRes)
 	return bz, nil
@@ -208,8 +211,9 @@ func queryDepthBookV2(ctx sdk.Context, path []string, req abci.RequestQuery, kee
 	}
 
 	bookRes := BookRes{
-		Asks: asks,
-		Bids: bids,
+		Asks:   asks,
+		Bids:   bids,
+		Height: ctx.BlockHeight(),
 	}
 
 	res, err := common.JSONMarshalV2(bookRes)
@@ -134,6 +134,7 @@ func TestQueryDepthBook(t *testing.T) {
 			{sdk.MustNewDecFromStr("0.4").String(), sdk.MustNewDecFromStr("1.3").String()},
 			{sdk.MustNewDecFromStr("0.3").String(), sdk.MustNewDecFromStr("2.8").String()},
 		},
+		Height: ctx.BlockHeight(),
 	}
 	require.EqualValues(t, expectBookRes, bookRes)
 
@@ -154,6 +155,7 @@ func TestQueryDepthBook(t *testing.T) {
 		Bids: []BookResItem{
 			{sdk.MustNewDecFromStr("0.4").String(), sdk.MustNewDecFromStr("1.3").String()},
 		},
+		Height: ctx.BlockHeight(),
 	}
 	require.EqualValues(t, expectBookRes, bookRes)
 
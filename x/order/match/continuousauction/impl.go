@@ -6,11 +6,9 @@ import (
 	"github.com/okex/exchain/x/order/keeper"
 )
 
-// nolint
+// CaEngine is the continuous auction match engine
 type CaEngine struct {
 }
 
-// nolint
-func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {
-	// TODO
-}
+// Run does nothing, the orders of continuous auction products are matched by MatchNewOrder when they are placed
+func (e *CaEngine) Run(ctx sdk.Context, keeper keeper.Keeper) {}
@@ -0,0 +1,71 @@
+package continuousauction
+
+import (
+	"testing"
+
+	"github.com/okex/exchain/libs/cosmos-sdk/client/flags"
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/okex/exchain/x/dex"
+	orderkeeper "github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchNewOrder(t *testing.T) {
+	viper.Set(flags.FlagHome, t.TempDir())
+	testInput := orderkeeper.CreateTestInput(t)
+	keeper := testInput.OrderKeeper
+	ctx := testInput.Ctx.WithBlockHeight(10)
+	tokenPair := dex.GetBuiltInTokenPair()
+	err := testInput.DexKeeper.SaveTokenPair(ctx, tokenPair)
+	require.Nil(t, err)
+
+	// orders of periodic auction products are left to EndBlocker
+	periodicOrder := types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "1.0")
+	require.Nil(t, MatchNewOrder(ctx, keeper, periodicOrder))
+
+	dexParams := *dex.DefaultParams()
+	dexParams.ContinuousAuctionProducts = []string{types.TestTokenPair}
+	testInput.DexKeeper.SetParams(ctx, dexParams)
+	require.Equal(t, types.AuctionTypeContinuous, keeper.GetAuctionType(ctx, types.TestTokenPair))
+
+	// mock orders, the buy order takes both sell orders from the lowest price
+	orders := []*types.Order{
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "10.0", "1.0"),
+		types.MockOrder("", types.TestTokenPair, types.SellOrder, "9.0", "0.5"),
+		types.MockOrder("", types.TestTokenPair, types.BuyOrder, "10.0", "2.0"),
+	}
+	orders[0].Sender = testInput.TestAddrs[1]
+	orders[1].Sender = testInput.TestAddrs[1]
+	orders[2].Sender = testInput.TestAddrs[0]
+	// the sell orders don't cross, they rest on the book
+	for i := 0; i < len(orders); i++ {
+		err := keeper.PlaceOrder(ctx, orders[i])
+		require.NoError(t, err)
+		deals := MatchNewOrder(ctx, keeper, orders[i])
+		if i < 2 {
+			require.Empty(t, deals)
+		} else {
+			// the buy order is filled as soon as it's placed
+			require.Len(t, deals, 4)
+		}
+	}
+
+	// check order status
+	order0 := keeper.GetOrder(ctx, orders[0].OrderID)
+	order1 := keeper.GetOrder(ctx, orders[1].OrderID)
+	order2 := keeper.GetOrder(ctx, orders[2].OrderID)
+	require.EqualValues(t, types.OrderStatusFilled, order0.Status)
+	require.EqualValues(t, types.OrderStatusFilled, order1.Status)
+	require.EqualValues(t, types.OrderStatusOpen, order2.Status)
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), order2.RemainQuantity)
+	require.EqualValues(t, sdk.MustNewDecFromStr("9.666666666666666667"), order2.FilledAvgPrice)
+
+	// the rest of the buy order stays on the book
+	book := keeper.GetDepthBookCopy(types.TestTokenPair)
+	require.Equal(t, 1, len(book.Items))
+	require.EqualValues(t, sdk.MustNewDecFromStr("0.5"), book.Items[0].BuyQuantity)
+	require.True(t, book.Items[0].SellQuantity.IsZero())
+	require.EqualValues(t, sdk.MustNewDecFromStr("10.0"), keeper.GetLastPrice(ctx, types.TestTokenPair))
+}
@@ -0,0 +1,183 @@
+package continuousauction
+
+import (
+	"fmt"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+
+	"github.com/okex/exchain/x/order/keeper"
+	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
+)
+
+// MatchNewOrder matches a new order of a continuous auction product right after it's placed.
+// The order takes the resting orders on the opposite side of the depth book with price-time priority,
+// and it is filled at the price of the resting orders. The unfilled quantity keeps resting on the book.
+// An order makes at most MaxDealsPerBlock deals, including the deals of the orders it takes, and the unfilled
+// quantity of an order still crossing the book after that is cancelled, so the book never stays crossed.
+func MatchNewOrder(ctx sdk.Context, k keeper.Keeper, order *types.Order) []types.Deal {
+	if order.Status != types.OrderStatusOpen || k.GetAuctionType(ctx, order.Product) != types.AuctionTypeContinuous {
+		return nil
+	}
+
+	// the order is just built by the handler, rather than loaded from the store
+	if order.FilledAvgPrice.IsNil() {
+		order.FilledAvgPrice = sdk.ZeroDec()
+	}
+
+	feeParams := k.GetParams(ctx)
+	deals, price, quantity := matchOrder(ctx, k, order, feeParams, feeParams.MaxDealsPerBlock)
+	if order.Status == types.OrderStatusOpen && len(crossedIndexes(k.GetDepthBookCopy(order.Product), order)) > 0 {
+		k.CancelOrder(ctx, order, ctx.Logger().With("module", "order"))
+	}
+	if len(deals) == 0 {
+		return nil
+	}
+	k.SetLastPrice(ctx, order.Product, price)
+	addMatchResult(ctx, k, order.Product, price, quantity, deals)
+
+	ctx.Logger().With("module", "order").Info(fmt.Sprintf(
+		"BlockHeight<%d> match order(%s-%s): lastPrice: %v, quantity: %v, dealsNum: %d",
+		ctx.BlockHeight(), order.Product, order.OrderID, price, quantity, len(deals)))
+	return deals
+}
+
+// addMatchResult merges the deals of an order into the match results of the block for querying
+func addMatchResult(ctx sdk.Context, k keeper.Keeper, product string, price, quantity sdk.Dec, deals []types.Deal) {
+	blockHeight := ctx.BlockHeight()
+	blockMatchResult := k.GetBlockMatchResult()
+	if blockMatchResult == nil || blockMatchResult.BlockHeight != blockHeight {
+		blockMatchResult = &types.BlockMatchResult{
+			BlockHeight: blockHeight,
+			ResultMap:   make(map[string]types.MatchResult),
+			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+		}
+	}
+
+	result, ok := blockMatchResult.ResultMap[product]
+	if !ok {
+		result = types.MatchResult{BlockHeight: blockHeight, Quantity: sdk.ZeroDec(), Deals: []types.Deal{}}
+	}
+	result.Price = price
+	result.Quantity = result.Quantity.Add(quantity)
+	result.Deals = append(result.Deals, deals...)
+	blockMatchResult.ResultMap[product] = result
+	k.SetBlockMatchResult(blockMatchResult)
+}
+
+// matchOrder fills the taker with the resting orders on the opposite side of the depth book,
+// from the best price level and the earliest order in a price level.
+// It returns the deals, the last filled price and the filled quantity of taker.
+func matchOrder(ctx sdk.Context, k keeper.Keeper, taker *types.Order,
+	feeParams *types.Params, remainDeals int64) ([]types.Deal, sdk.Dec, sdk.Dec) {
+
+	var deals []types.Deal
+	lastPrice := sdk.ZeroDec()
+	takerFilled := sdk.ZeroDec()
+	makerSide := types.SellOrder
+	if taker.Side == types.SellOrder {
+		makerSide = types.BuyOrder
+	}
+
+	book := k.GetDepthBookCopy(taker.Product)
+	for _, index := range crossedIndexes(book, taker) {
+		if remainDeals <= 0 || taker.RemainQuantity.IsZero() {
+			break
+		}
+
+		price := book.Items[index].Price
+		key := types.FormatOrderIDsKey(taker.Product, price, makerSide)
+		orderIDs := k.GetProductPriceOrderIDs(key)
+		unfilledOrderIDs := make([]string, 0, len(orderIDs))
+		filledAmount := sdk.ZeroDec()
+		for _, orderID := range orderIDs {
+			if remainDeals <= 0 || taker.RemainQuantity.IsZero() {
+				unfilledOrderIDs = append(unfilledOrderIDs, orderID)
+				continue
+			}
+			maker := k.GetOrder(ctx, orderID)
+			if maker == nil {
+				ctx.Logger().Error("[Order] Not exist orderID: ", orderID)
+				continue
+			}
+
+			fillQuantity := sdk.MinDec(maker.RemainQuantity, taker.RemainQuantity)
+			if deal := periodicauction.FillOrder(maker, ctx, k, price, fillQuantity, feeParams); deal != nil {
+				deals = append(deals, *deal)
+			}
+			if deal := periodicauction.FillOrder(taker, ctx, k, price, fillQuantity, feeParams); deal != nil {
+				deals = append(deals, *deal)
+			}
+			remainDeals -= 2
+			filledAmount = filledAmount.Add(fillQuantity)
+
+			if maker.Status != types.OrderStatusFilled {
+				unfilledOrderIDs = append(unfilledOrderIDs, orderID)
+			}
+		}
+		k.SetOrderIDs(key, unfilledOrderIDs)
+
+		if filledAmount.IsPositive() {
+			book.Sub(index, filledAmount, makerSide)
+			takerFilled = takerFilled.Add(filledAmount)
+			lastPrice = price
+		}
+	}
+
+	if takerFilled.IsPositive() {
+		removeTakerFromBook(k, book, taker, takerFilled)
+	}
+	// indexes are stable while filling, so the empty items are removed at last
+	for i := len(book.Items) - 1; i >= 0; i-- {
+		book.RemoveIfEmpty(i)
+	}
+	k.SetDepthBook(taker.Product, book)
+
+	return deals, lastPrice, takerFilled
+}
+
+// crossedIndexes returns the indexes of the price levels which cross the taker's price,
+// sorted from the best price to the worst price for the taker
+func crossedIndexes(book *types.DepthBook, taker *types.Order) []int {
+	var indexes []int
+	if taker.Side == types.BuyOrder {
+		// prices from low to high
+		for i := len(book.Items) - 1; i >= 0 && book.Items[i].Price.LTE(taker.Price); i-- {
+			if book.Items[i].SellQuantity.IsPositive() {
+				indexes = append(indexes, i)
+			}
+		}
+	} else {
+		// prices from high to low
+		for i := 0; i < len(book.Items) && book.Items[i].Price.GTE(taker.Price); i++ {
+			if book.Items[i].BuyQuantity.IsPositive() {
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	return indexes
+}
+
+// removeTakerFromBook subtracts the filled quantity of taker from its own price level,
+// and removes the taker from orderIDsMap if it's fully filled
+func removeTakerFromBook(k keeper.Keeper, book *types.DepthBook, taker *types.Order, filled sdk.Dec) {
+	for index := range book.Items {
+		if book.Items[index].Price.Equal(taker.Price) {
+			book.Sub(index, filled, taker.Side)
+			break
+		}
+	}
+
+	if taker.Status != types.OrderStatusFilled {
+		return
+	}
+	key := types.FormatOrderIDsKey(taker.Product, taker.Price, taker.Side)
+	orderIDs := k.GetProductPriceOrderIDs(key)
+	remainOrderIDs := make([]string, 0, len(orderIDs))
+	for _, orderID := range orderIDs {
+		if orderID != taker.OrderID {
+			remainOrderIDs = append(remainOrderIDs, orderID)
+		}
+	}
+	k.SetOrderIDs(key, remainOrderIDs)
+}
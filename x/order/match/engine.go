@@ -6,28 +6,25 @@ import (
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 
 	"github.com/okex/exchain/x/order/keeper"
-	"github.com/okex/exchain/x/order/match/continuousauction"
 	"github.com/okex/exchain/x/order/match/periodicauction"
+	"github.com/okex/exchain/x/order/types"
 )
 
 // nolint
-const DefaultAuctionType = "periodicauction"
+const DefaultAuctionType = types.AuctionTypePeriodic
 
 // nolint
 var (
-	once        sync.Once
-	engine      Engine
-	auctionType = DefaultAuctionType
+	once   sync.Once
+	engine Engine
 )
 
-// GetEngine : periodic auction only today
+// GetEngine returns the engine which runs in EndBlocker.
+// Products use periodic auction unless they are listed in the ContinuousAuctionProducts param of dex,
+// whose orders are matched by continuousauction.MatchNewOrder when they are placed.
 func GetEngine() Engine {
 	once.Do(func() {
-		if auctionType == DefaultAuctionType {
-			engine = &periodicauction.PaEngine{}
-		} else {
-			engine = &continuousauction.CaEngine{}
-		}
+		engine = &periodicauction.PaEngine{}
 	})
 	return engine
 }
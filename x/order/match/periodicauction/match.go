@@ -273,6 +273,8 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step0: get active products
 	products := keeper.GetDiskCache().GetNewDepthbookKeys()
 	products = keeper.FilterDelistedProducts(ctx, products)
+	// products matched by continuous auction are left to the continuous auction engine
+	products = keeper.FilterProductsByAuctionType(ctx, products, types.AuctionTypePeriodic)
 	keeper.GetDexKeeper().SortProducts(ctx, products) // sort products
 
 	// step1: calc best price and max execution for every active product, save latest price
@@ -289,12 +291,18 @@ func matchOrders(ctx sdk.Context, keeper keeper.Keeper) {
 	// step2: execute match results, fill orders in match results, transfer tokens and collect fees
 	executeMatch(ctx, keeper, products, updatedProductsBasePrice, lockMap)
 
-	// step3: save match results for querying
+	// step3: save match results for querying, keep the results of continuous auction products matched in this block
 	if len(updatedProductsBasePrice) > 0 {
-		blockMatchResult := &types.BlockMatchResult{
-			BlockHeight: blockHeight,
-			ResultMap:   updatedProductsBasePrice,
-			TimeStamp:   ctx.BlockHeader().Time.Unix(),
+		blockMatchResult := keeper.GetBlockMatchResult()
+		if blockMatchResult == nil || blockMatchResult.BlockHeight != blockHeight {
+			blockMatchResult = &types.BlockMatchResult{
+				BlockHeight: blockHeight,
+				ResultMap:   make(map[string]types.MatchResult),
+				TimeStamp:   ctx.BlockHeader().Time.Unix(),
+			}
+		}
+		for product, result := range updatedProductsBasePrice {
+			blockMatchResult.ResultMap[product] = result
 		}
 		keeper.SetBlockMatchResult(blockMatchResult)
 	}
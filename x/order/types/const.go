@@ -15,4 +15,7 @@ const (
 	TestTokenPair       = common.TestToken + "_" + sdk.DefaultBondDenom
 	BuyOrder            = "BUY"
 	SellOrder           = "SELL"
+
+	AuctionTypePeriodic   = "periodicauction"
+	AuctionTypeContinuous = "continuousauction"
 )
@@ -126,3 +126,44 @@ func (depthBook *DepthBook) Copy() *DepthBook {
 	itemList = append(itemList, depthBook.Items...)
 	return &DepthBook{Items: itemList}
 }
+
+// DepthBookUpdate is the change of the depth book of a product in a block.
+// Items are the price levels changed in the block with their latest quantities,
+// the level whose buy and sell quantities are both zero is removed from the depth book.
+type DepthBookUpdate struct {
+	Product string          `json:"product"`
+	Height  int64           `json:"height"`
+	Items   []DepthBookItem `json:"items"`
+	Fills   *MatchResult    `json:"fills,omitempty"`
+}
+
+// DiffDepthBook returns the price levels changed from the old depth book to the new one,
+// both of them are sorted by price desc and so is the result
+func DiffDepthBook(oldBook, newBook *DepthBook) []DepthBookItem {
+	var diff []DepthBookItem
+	i, j := 0, 0
+	for i < len(oldBook.Items) || j < len(newBook.Items) {
+		switch {
+		case j == len(newBook.Items) || (i < len(oldBook.Items) && oldBook.Items[i].Price.GT(newBook.Items[j].Price)):
+			// the level is removed
+			diff = append(diff, DepthBookItem{
+				Price:        oldBook.Items[i].Price,
+				BuyQuantity:  sdk.ZeroDec(),
+				SellQuantity: sdk.ZeroDec(),
+			})
+			i++
+		case i == len(oldBook.Items) || newBook.Items[j].Price.GT(oldBook.Items[i].Price):
+			// the level is added
+			diff = append(diff, newBook.Items[j])
+			j++
+		default:
+			if !oldBook.Items[i].BuyQuantity.Equal(newBook.Items[j].BuyQuantity) ||
+				!oldBook.Items[i].SellQuantity.Equal(newBook.Items[j].SellQuantity) {
+				diff = append(diff, newBook.Items[j])
+			}
+			i++
+			j++
+		}
+	}
+	return diff
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDepthBook(t *testing.T) {
+	oldBook := &DepthBook{}
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.6", "1.0"))
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, SellOrder, "0.5", "2.0"))
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.4", "3.0"))
+	oldBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.3", "4.0"))
+
+	// no change
+	require.Empty(t, DiffDepthBook(oldBook, oldBook.Copy()))
+
+	newBook := oldBook.Copy()
+	// remove the level 0.6
+	newBook.RemoveOrder(MockOrder("", TestTokenPair, SellOrder, "0.6", "1.0"))
+	// update the level 0.4
+	newBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.4", "1.5"))
+	// add the levels 0.45 and 0.2
+	newBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.45", "1.0"))
+	newBook.InsertOrder(MockOrder("", TestTokenPair, BuyOrder, "0.2", "1.0"))
+
+	diff := DiffDepthBook(oldBook, newBook)
+	require.Equal(t, 4, len(diff))
+	expected := []struct {
+		price, buy, sell string
+	}{
+		{"0.6", "0", "0"},
+		{"0.45", "1.0", "0"},
+		{"0.4", "4.5", "0"},
+		{"0.2", "1.0", "0"},
+	}
+	for i, item := range expected {
+		require.True(t, sdk.MustNewDecFromStr(item.price).Equal(diff[i].Price), diff[i].Price.String())
+		require.True(t, sdk.MustNewDecFromStr(item.buy).Equal(diff[i].BuyQuantity), diff[i].BuyQuantity.String())
+		require.True(t, sdk.MustNewDecFromStr(item.sell).Equal(diff[i].SellQuantity), diff[i].SellQuantity.String())
+	}
+
+	// all the levels are removed
+	require.Equal(t, len(newBook.Items), len(DiffDepthBook(newBook, &DepthBook{})))
+}
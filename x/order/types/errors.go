@@ -38,6 +38,7 @@ const (
 	CodeNotOrderOwner                         uint32 = 63026
 	CodeProductIsEmpty                        uint32 = 63027
 	CodeAllOrderFailedToExecute               uint32 = 63028
+	CodeNotContinuousAuctionProduct           uint32 = 63029
 )
 
 func ErrInvalidAddress(address string) sdk.EnvelopedErr {
@@ -152,3 +153,7 @@ func ErrNotOrderOwner(orderID string) sdk.EnvelopedErr {
 func ErrAllOrderFailedToExecute() sdk.EnvelopedErr {
 	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeAllOrderFailedToExecute, "all order items failed to execute")}
 }
+
+func ErrNotContinuousAuctionProduct(product string) sdk.EnvelopedErr {
+	return sdk.EnvelopedErr{Err: sdkerrors.New(DefaultCodespace, CodeNotContinuousAuctionProduct, fmt.Sprintf("%s is not a continuous auction product", product))}
+}
@@ -0,0 +1,14 @@
+package types
+
+// nolint
+const (
+	EventTypeDepthBook          = "depth_book"
+	AttributeKeyProduct         = "product"
+	AttributeKeyDepthBookUpdate = "update"
+
+	FlagEnableDepthBookEvent = "order.enable-depth-book-event"
+)
+
+// EnableDepthBookEvent indicates whether EndBlocker emits the depth book updates of the block as events,
+// which are pushed to the websocket subscribers of depth book
+var EnableDepthBookEvent = false
@@ -217,6 +217,7 @@ func (msg MsgCancelOrders) CalculateGas(gasUnit uint64) uint64 {
 // nolint
 type OrderResult struct {
 	Error   error  `json:"error"`
-	Message string `json:"msg"`     // order return error message
-	OrderID string `json:"orderid"` // order return orderid
+	Message string `json:"msg"`             // order return error message
+	OrderID string `json:"orderid"`         // order return orderid
+	Deals   []Deal `json:"deals,omitempty"` // deals made when the order is placed, continuous auction only
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/okex/exchain/libs/cosmos-sdk/x/auth/client/utils"
 	"github.com/spf13/cobra"
 
+	govutils "github.com/okex/exchain/x/gov/client/utils"
 	govTypes "github.com/okex/exchain/x/gov/types"
 	paramscutils "github.com/okex/exchain/x/params/client/utils"
 	"github.com/okex/exchain/x/params/types"
@@ -84,7 +85,12 @@ Where proposal.json contains:
 				proposal.Height,
 			)
 
-			msg := govTypes.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			execution, err := govutils.ParseExecutionFlags()
+			if err != nil {
+				return err
+			}
+
+			msg := govTypes.NewMsgSubmitProposal(content, proposal.Deposit, from).WithExecution(execution)
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -92,6 +98,7 @@ Where proposal.json contains:
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	govutils.AddExecutionFlags(cmd)
 
 	return cmd
 }
@@ -10,6 +10,9 @@ type DelegatorI interface {
 	GetShareAddedValidatorAddresses() []sdk.ValAddress
 	GetLastAddedShares() sdk.Dec
 	GetDelegatorAddress() sdk.AccAddress
+	GetTokens() sdk.Dec
+	GetTotalDelegatedTokens() sdk.Dec
+	GetProxyAddress() sdk.AccAddress
 }
 
 // ValidatorI expected validator functions
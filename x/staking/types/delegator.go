@@ -42,6 +42,21 @@ func (d Delegator) GetLastAddedShares() sdk.Dec {
 	return d.Shares
 }
 
+// GetTokens gets the self-delegated tokens of a delegator for other module
+func (d Delegator) GetTokens() sdk.Dec {
+	return d.Tokens
+}
+
+// GetTotalDelegatedTokens gets the tokens delegated to a proxy by the delegators bound to it for other module
+func (d Delegator) GetTotalDelegatedTokens() sdk.Dec {
+	return d.TotalDelegatedTokens
+}
+
+// GetProxyAddress gets the address of the proxy a delegator has bound for other module, nil if none
+func (d Delegator) GetProxyAddress() sdk.AccAddress {
+	return d.ProxyAddress
+}
+
 // RegProxy registers or deregisters the identity of proxy
 func (d *Delegator) RegProxy(reg bool) {
 	d.IsProxy = reg
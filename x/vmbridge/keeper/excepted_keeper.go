@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
 	authexported "github.com/okex/exchain/libs/cosmos-sdk/x/auth/exported"
+	ammswaptypes "github.com/okex/exchain/x/ammswap/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	wasmtypes "github.com/okex/exchain/x/wasm/types"
 )
@@ -38,3 +39,8 @@ type AccountKeeper interface {
 type BankKeeper interface {
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
 }
+
+// SwapKeeper defines the expected ammswap keeper interface
+type SwapKeeper interface {
+	GetTWAP(ctx sdk.Context, tokenPairName string, window int64) (ammswaptypes.TWAP, error)
+}
@@ -3,9 +3,11 @@ package keeper
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/vmbridge/types"
 	"math/big"
@@ -42,6 +44,10 @@ func methodDispatch(k *Keeper, csdb *evmtypes.CommitStateDB, sdkCtx sdk.Context,
 	if err != nil {
 		return nil, 0, err
 	}
+	// queryTWAP is supported since Venus15, it fails like an unknown method before
+	if method.Name == types.PrecompileQueryTWAP && !tmtypes.HigherThanVenus15(sdkCtx.BlockHeight()) {
+		return nil, 0, fmt.Errorf("no method with id: %#x", input[:4])
+	}
 
 	params := k.wasmKeeper.GetParams(sdkCtx)
 	if !params.VmbridgeEnable {
@@ -58,6 +64,8 @@ func methodDispatch(k *Keeper, csdb *evmtypes.CommitStateDB, sdkCtx sdk.Context,
 		result, leftGas, err = callToWasm(k, subCtx, caller, to, value, input)
 	case types.PrecompileQueryToWasm:
 		result, leftGas, err = queryToWasm(k, subCtx, caller, to, value, input)
+	case types.PrecompileQueryTWAP:
+		result, leftGas, err = queryTWAP(k, subCtx, value, input)
 	default:
 		result, leftGas, err = nil, 0, errors.New("methodDispatch failed: unknown method")
 	}
@@ -117,3 +125,26 @@ func queryToWasm(k *Keeper, sdkCtx sdk.Context, caller, to common.Address, value
 	result, err := types.EncodePrecompileQueryToWasmOutput(string(ret))
 	return result, left, err
 }
+
+func queryTWAP(k *Keeper, sdkCtx sdk.Context, value *big.Int, input []byte) ([]byte, uint64, error) {
+	if value.Sign() != 0 {
+		return nil, 0, errors.New("queryTWAP can not be send token")
+	}
+	tokenPairName, window, err := types.DecodePrecompileQueryTWAPInput(input)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !window.IsInt64() {
+		return nil, 0, errors.New("queryTWAP window overflow")
+	}
+
+	twap, err := k.swapKeeper.GetTWAP(sdkCtx, tokenPairName, window.Int64())
+	gasMeter := sdkCtx.GasMeter()
+	left := gasMeter.Limit() - gasMeter.GasConsumed()
+	if err != nil {
+		return nil, left, err
+	}
+
+	result, err := types.EncodePrecompileQueryTWAPOutput(twap.BasePrice.BigInt(), twap.QuotePrice.BigInt())
+	return result, left, err
+}
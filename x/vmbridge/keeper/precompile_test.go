@@ -9,11 +9,14 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	sdk "github.com/okex/exchain/libs/cosmos-sdk/types"
+	tmtypes "github.com/okex/exchain/libs/tendermint/types"
+	ammswaptypes "github.com/okex/exchain/x/ammswap/types"
 	evmtypes "github.com/okex/exchain/x/evm/types"
 	"github.com/okex/exchain/x/vmbridge/types"
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var (
@@ -660,6 +663,38 @@ func (suite *KeeperTestSuite) TestPrecompileHooks() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestPrecompileQueryTWAP() {
+	cmBridgePrecompileAddress := common.HexToAddress("0x0000000000000000000000000000000000000100")
+	caller := common.BytesToAddress(suite.addr)
+	swapTokenPair := ammswaptypes.NewSwapPair(ammswaptypes.TestBasePooledToken, ammswaptypes.TestQuotePooledToken)
+	tokenPairName := swapTokenPair.TokenPairName()
+	swapTokenPair.BasePooledCoin.Amount = sdk.NewDec(100)
+	swapTokenPair.QuotePooledCoin.Amount = sdk.NewDec(200)
+	suite.app.SwapKeeper.SetSwapTokenPair(suite.ctx, tokenPairName, swapTokenPair)
+	now := suite.ctx.BlockTime()
+	suite.app.SwapKeeper.UpdatePriceAccumulators(suite.ctx)
+	suite.ctx.SetBlockTime(now.Add(60 * time.Second))
+	suite.app.SwapKeeper.UpdatePriceAccumulators(suite.ctx)
+
+	evmCalldata, err := types.PreCompileABI.Pack(types.PrecompileQueryTWAP, tokenPairName, big.NewInt(60))
+	suite.Require().NoError(err)
+
+	// queryTWAP is supported since Venus15, it fails like an unknown method before
+	subCtx, _ := suite.ctx.CacheContext()
+	_, _, err = suite.app.VMBridgeKeeper.CallEvm(subCtx, caller, &cmBridgePrecompileAddress, big.NewInt(0), evmCalldata)
+	suite.Require().EqualError(err, fmt.Sprintf("no method with id: %#x", evmCalldata[:4]))
+
+	tmtypes.InitMilestoneVenus15Height(1)
+	defer tmtypes.InitMilestoneVenus15Height(0)
+	subCtx, _ = suite.ctx.CacheContext()
+	_, evmResult, err := suite.app.VMBridgeKeeper.CallEvm(subCtx, caller, &cmBridgePrecompileAddress, big.NewInt(0), evmCalldata)
+	suite.Require().NoError(err)
+	pack, err := types.PreCompileABI.Methods[types.PrecompileQueryTWAP].Outputs.Unpack(evmResult.Ret)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(2).BigInt(), pack[0])
+	suite.Require().Equal(sdk.MustNewDecFromStr("0.5").BigInt(), pack[1])
+}
+
 func (suite *KeeperTestSuite) queryPrecompileWasmBalance(ctx sdk.Context, caller, wasmContract, to string) (int, error) {
 	testQueryMsg := fmt.Sprintf("{\"balance\":{\"address\":\"%s\"}}", to)
 	wasmsmartRequest := wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: wasmContract, Msg: []byte(testQueryMsg)}}
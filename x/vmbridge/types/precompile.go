@@ -5,12 +5,15 @@ import (
 	_ "embed"
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"math/big"
+
 	evm_types "github.com/okex/exchain/x/evm/types"
 )
 
 const (
 	PrecompileCallToWasm  = "callToWasm"
 	PrecompileQueryToWasm = "queryToWasm"
+	PrecompileQueryTWAP   = "queryTWAP"
 )
 
 var (
@@ -80,6 +83,37 @@ func EncodePrecompileQueryToWasmOutput(response string) ([]byte, error) {
 	return PreCompileABI.EncodeOutput(PrecompileQueryToWasm, []byte(response))
 }
 
+func DecodePrecompileQueryTWAPInput(input []byte) (tokenPairName string, window *big.Int, err error) {
+	if !PreCompileABI.IsMatchFunction(PrecompileQueryTWAP, input) {
+		return "", nil, fmt.Errorf("decode precomplie query twap input :  input sginature is not %s", PrecompileQueryTWAP)
+	}
+	unpacked, err := PreCompileABI.DecodeInputParam(PrecompileQueryTWAP, input)
+	if err != nil {
+		return "", nil, fmt.Errorf("decode precomplie query twap input unpack err :  %s", err)
+	}
+	if len(unpacked) != 2 {
+		return "", nil, fmt.Errorf("decode precomplie query twap input unpack err :  unpack data len expect 2 but got %v", len(unpacked))
+	}
+	tokenPairName, ok := unpacked[0].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("decode precomplie query twap input unpack err : tokenPairName is not type of string")
+	}
+	window, ok = unpacked[1].(*big.Int)
+	if !ok {
+		return "", nil, fmt.Errorf("decode precomplie query twap input unpack err : window is not type of uint256")
+	}
+	return tokenPairName, window, nil
+}
+
+// EncodePrecompileQueryTWAPOutput encodes the prices as the fixed-point numbers with 18 decimals
+func EncodePrecompileQueryTWAPOutput(basePrice, quotePrice *big.Int) ([]byte, error) {
+	method, ok := PreCompileABI.Methods[PrecompileQueryTWAP]
+	if !ok {
+		return nil, fmt.Errorf("method %s is not exist in abi", PrecompileQueryTWAP)
+	}
+	return method.Outputs.Pack(basePrice, quotePrice)
+}
+
 func GetMethodByIdFromCallData(calldata []byte) (*abi.Method, error) {
 	return PreCompileABI.GetMethodById(calldata)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/stretchr/testify/require"
+	"math/big"
 	"testing"
 )
 
@@ -284,6 +285,33 @@ func TestEncodePrecompileQueryToWasmOutput(t *testing.T) {
 	require.Equal(t, testResult, result)
 }
 
+func TestDecodePrecompileQueryTWAPInput(t *testing.T) {
+	testTokenPairName := "eth-355_okt"
+	testWindow := big.NewInt(3600)
+
+	input, err := PreCompileABI.Pack(PrecompileQueryTWAP, testTokenPairName, testWindow)
+	require.NoError(t, err)
+	tokenPairName, window, err := DecodePrecompileQueryTWAPInput(input)
+	require.NoError(t, err)
+	require.Equal(t, testTokenPairName, tokenPairName)
+	require.Equal(t, testWindow, window)
+
+	input, err = encodeQueryToWasmInput("test call data")
+	require.NoError(t, err)
+	_, _, err = DecodePrecompileQueryTWAPInput(input)
+	require.ErrorContains(t, err, "input sginature is not queryTWAP")
+}
+
+func TestEncodePrecompileQueryTWAPOutput(t *testing.T) {
+	basePrice, quotePrice := big.NewInt(2e18), big.NewInt(5e17)
+
+	output, err := EncodePrecompileQueryTWAPOutput(basePrice, quotePrice)
+	require.NoError(t, err)
+	pack, err := PreCompileABI.Methods[PrecompileQueryTWAP].Outputs.Unpack(output)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{basePrice, quotePrice}, pack)
+}
+
 func TestGetMethodByIdFromCallData(t *testing.T) {
 	testaddr := "0x123"
 	testCalldata := "test call data"